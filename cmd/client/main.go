@@ -2,11 +2,11 @@ package main
 
 import (
 	"fmt"
-
-	"github.com/c-bata/go-prompt"
+	"os"
 
 	completer "secretKeeper/internal/client/prompt/completer"
 	executor "secretKeeper/internal/client/prompt/executor"
+	"secretKeeper/internal/client/prompt/repl"
 )
 
 var (
@@ -15,14 +15,14 @@ var (
 )
 
 func main() {
+	// any arguments switch the client to subcommand mode, e.g. secretkeeper get-secret 12 --field password
+	if len(os.Args) > 1 {
+		os.Exit(executor.NewSubcommandExecutor().Run(os.Args[1:]))
+	}
+
 	fmt.Printf("Build version: %s\nBuild date: %s\n", buildVersion, buildDate)
 
-	p := prompt.New(
-		executor.NewExecutor().Execute,
-		completer.NewCompleter().Complete,
-		prompt.OptionTitle("Gophkeeper"),
-		prompt.OptionPrefix(">>>"),
-		prompt.OptionInputTextColor(prompt.Yellow),
-	)
-	p.Run()
+	exec := executor.NewExecutor()
+
+	repl.Run(exec, completer.NewCompleter(exec))
 }
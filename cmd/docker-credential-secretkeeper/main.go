@@ -0,0 +1,26 @@
+// Command docker-credential-secretkeeper is docker credential helper backed by login/pass secrets of secretKeeper.
+//
+// Docker runs it for "credsStore": "secretkeeper" as "docker-credential-secretkeeper get|store|erase|list", request
+// is read from stdin, response and errors are written to stdout as docker expects.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	executor "secretKeeper/internal/client/prompt/executor"
+)
+
+func main() {
+	if len(os.Args) != 2 {
+		fmt.Fprintln(os.Stdout, "usage: docker-credential-secretkeeper get|store|erase|list")
+		os.Exit(executor.ExitUsage)
+	}
+
+	exec := executor.NewSubcommandExecutor()
+
+	if err := exec.DockerCredentialHelper(os.Args[1], os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stdout, err)
+		os.Exit(executor.ExitFailure)
+	}
+}
@@ -0,0 +1,15 @@
+// Command git-credential-secretkeeper is git credential helper backed by login/pass secrets of secretKeeper.
+//
+// Git runs it for credential.helper=secretkeeper as "git-credential-secretkeeper get|store|erase", it is the same as
+// "secretkeeper git-credential get|store|erase".
+package main
+
+import (
+	"os"
+
+	executor "secretKeeper/internal/client/prompt/executor"
+)
+
+func main() {
+	os.Exit(executor.NewSubcommandExecutor().Run(append([]string{"git-credential"}, os.Args[1:]...)))
+}
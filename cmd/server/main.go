@@ -23,9 +23,19 @@ func main() {
 
 	application.Server.Start(cancel)
 
+	if err = application.Scheduler.Start(ctx, application.Config.ExpirySchedule); err != nil {
+		application.Logger.Error(fmt.Errorf("error in starting expiry scheduler: %w", err).Error())
+	}
+
 	<-ctx.Done()
 
+	application.Scheduler.Stop()
 	application.Server.Stop()
 
+	// refresh is finished, so nothing uses connection of scheduler
+	if err = application.SchedulerDB.Close(context.Background()); err != nil {
+		application.Logger.Error(fmt.Errorf("error in closing scheduler db: %w", err).Error())
+	}
+
 	application.Logger.Info("Application stopped")
 }
@@ -3,6 +3,7 @@ package app
 import (
 	"context"
 	"fmt"
+	"os"
 
 	"github.com/robfig/cron/v3"
 	"google.golang.org/grpc"
@@ -12,6 +13,7 @@ import (
 	"secretKeeper/internal/client/model"
 	"secretKeeper/internal/client/service"
 	"secretKeeper/internal/client/storage"
+	"secretKeeper/pkg/breach"
 	"secretKeeper/pkg/cert"
 	"secretKeeper/pkg/crypt"
 	pb "secretKeeper/proto"
@@ -20,24 +22,38 @@ import (
 type App struct {
 	Cancel context.CancelFunc
 
-	SecretService     *service.SecretClientService
-	SecretTypeService *service.SecretTypeClientService
-	UserService       *service.UserClientService
+	SecretService       *service.SecretClientService
+	SecretTypeService   *service.SecretTypeClientService
+	UserService         *service.UserClientService
+	OrganizationService *service.OrganizationClientService
+	EmergencyService    *service.EmergencyClientService
+	SendService         *service.SendClientService
 
 	Storage storage.Memorier
 	Syncer  storage.Syncer
 	Cron    *cron.Cron
+	Session *storage.SessionFile
+	Config  config.Config
+	// Breach - checks passwords against corpus of breached ones, nil if corpus isn't configured.
+	Breach breach.Checker
+
+	conn *grpc.ClientConn
 }
 
-// NewApp - creates Client application.
-func NewApp() (*App, error) {
+// NewApp - creates Client application of profile, empty name stands for SECRETKEEPER_PROFILE or current profile.
+func NewApp(profile string) (*App, error) {
+	cfg, errCfg := config.NewConfig(profile)
+	if errCfg != nil {
+		return nil, errCfg
+	}
+
 	ctx, cancel := context.WithCancel(context.Background())
 	glCtx := model.GlobalContext{Ctx: ctx, Cancel: cancel}
 
-	cfg := config.NewConfig()
-
 	tlsCredential, err := cert.NewSSLConfigService().LoadClientCertificate(cfg)
 	if err != nil {
+		cancel()
+
 		return nil, fmt.Errorf("error in creating tls creds: %w", err)
 	}
 
@@ -46,46 +62,123 @@ func NewApp() (*App, error) {
 		"/proto.SecretType/GetSecretTypesList": true,
 		"/proto.Secret/GetListOfSecretsByType": true,
 		"/proto.Secret/CreateSecret":           true,
+		"/proto.Secret/CreateSecrets":          true,
 		"/proto.Secret/GetSecret":              true,
 		"/proto.Secret/DeleteSecret":           true,
 		"/proto.Secret/Edit":                   true,
+		"/proto.Secret/SetSecretExpiry":        true,
+		"/proto.Secret/GetDueEvents":           true,
+		"/proto.User/SetPublicKey":             true,
+		"/proto.User/SetPersonalKey":           true,
+		"/proto.User/GetPersonalKey":           true,
+		"/proto.User/SetRecovery":              true,
+
+		"/proto.Organization/CreateOrganization": true,
+		"/proto.Organization/ListOrganizations":  true,
+		"/proto.Organization/AddMember":          true,
+		"/proto.Organization/RemoveMember":       true,
+		"/proto.Organization/ListMembers":        true,
+		"/proto.Organization/CreateVault":        true,
+		"/proto.Organization/ListVaults":         true,
+		"/proto.Organization/GetVaultKey":        true,
+		"/proto.Organization/RotateVaultKey":     true,
+
+		"/proto.Emergency/LookupContact":      true,
+		"/proto.Emergency/AddContact":         true,
+		"/proto.Emergency/RemoveContact":      true,
+		"/proto.Emergency/ListContacts":       true,
+		"/proto.Emergency/ListGrantors":       true,
+		"/proto.Emergency/RequestAccess":      true,
+		"/proto.Emergency/DenyAccess":         true,
+		"/proto.Emergency/GetEmergencyAccess": true,
+
+		"/proto.Send/SendSecret": true,
 	}
 	intercept := interceptor.NewAuthInterceptor(protectedRoutes)
 
-	conn, errConn := grpc.Dial(":"+cfg.Port,
+	conn, errConn := grpc.Dial(cfg.ServerAddress(),
 		grpc.WithTransportCredentials(tlsCredential),
 		grpc.WithUnaryInterceptor(intercept.Unary()),
 	)
 	if errConn != nil {
+		cancel()
+
 		return nil, fmt.Errorf("error in creating grpc con:%w", errConn)
 	}
 
 	secretClient := pb.NewSecretClient(conn)
 	userClient := pb.NewUserClient(conn)
 	secretTypeClient := pb.NewSecretTypeClient(conn)
+	organizationClient := pb.NewOrganizationClient(conn)
+	emergencyClient := pb.NewEmergencyClient(conn)
+	sendClient := pb.NewSendClient(conn)
 
 	cr, errCr := crypt.NewCrypt()
 	if errCr != nil {
+		cancel()
+		conn.Close()
+
 		return nil, fmt.Errorf("could create crypt")
 	}
 
 	memoryStorage := storage.NewMemoryStorage()
-	syn := storage.NewSync(memoryStorage, secretClient, &glCtx, cr)
+	keyring := storage.NewKeyring()
+	personalCrypt := keyring.PersonalCrypter(cr)
+	syn := storage.NewSync(memoryStorage, secretClient, &glCtx, personalCrypt)
 
-	secretClientService := service.NewSecretClientService(&glCtx, secretClient, memoryStorage, cr, syn)
-	userClientService := service.NewUserClientService(&glCtx, userClient)
+	organizationClientService := service.NewOrganizationClientService(
+		&glCtx, organizationClient, secretClient, secretTypeClient, keyring,
+	)
+	secretClientService := service.NewSecretClientService(
+		&glCtx, secretClient, memoryStorage, personalCrypt, syn, organizationClientService,
+	)
+	userClientService := service.NewUserClientService(&glCtx, userClient, keyring)
+	emergencyClientService := service.NewEmergencyClientService(&glCtx, emergencyClient, keyring, cr)
+	sendClientService := service.NewSendClientService(&glCtx, sendClient)
 	secretTypeClientService := service.NewSecretTypeClientService(&glCtx, secretTypeClient)
 
 	c := cron.New()
-	c.AddFunc("* * * * *", syn.SyncAll)
+	syncJob := func() {
+		// stdout is left to results of commands
+		if errSync := syn.SyncAll(); errSync != nil {
+			fmt.Fprintf(os.Stderr, "sync: %v\n", errSync)
+		}
+	}
+
+	if _, errCron := c.AddFunc("@every "+cfg.SyncInterval.String(), syncJob); errCron != nil {
+		cancel()
+		conn.Close()
+
+		return nil, fmt.Errorf("error in scheduling sync: %w", errCron)
+	}
+
+	var breachChecker breach.Checker
+	if cfg.BreachSource != "" {
+		breachChecker = breach.NewChecker(cfg.BreachSource)
+	}
 
 	return &App{
-		SecretService:     secretClientService,
-		SecretTypeService: secretTypeClientService,
-		UserService:       userClientService,
-		Storage:           memoryStorage,
-		Syncer:            syn,
-		Cron:              c,
-		Cancel:            cancel,
+		SecretService:       secretClientService,
+		SecretTypeService:   secretTypeClientService,
+		UserService:         userClientService,
+		OrganizationService: organizationClientService,
+		EmergencyService:    emergencyClientService,
+		SendService:         sendClientService,
+		Storage:             memoryStorage,
+		Syncer:              syn,
+		Cron:                c,
+		Session:             storage.NewSessionFile(cfg.SessionPath),
+		Config:              cfg,
+		Breach:              breachChecker,
+		Cancel:              cancel,
+		conn:                conn,
 	}, nil
 }
+
+// Close - stops sync, cancels requests of App and closes its connection, e.g. when the prompt switches profile.
+func (a *App) Close() error {
+	<-a.Cron.Stop().Done()
+	a.Cancel()
+
+	return a.conn.Close()
+}
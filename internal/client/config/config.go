@@ -1,33 +1,162 @@
 package config
 
 import (
-	"github.com/caarlos0/env/v6"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
 
-	"secretKeeper/pkg/logger"
+	"github.com/caarlos0/env/v6"
 )
 
 type Config struct {
+	// Profile - is name of profile of config file the Config is read from, empty for environment alone.
+	Profile string `env:"SECRETKEEPER_PROFILE"`
+	// ConfigPath - is config file with profiles, empty value stands for a file in home directory.
+	ConfigPath string `env:"SECRETKEEPER_CONFIG"`
+
 	Address string `env:"SERVER_ADDRESS" envDefault:"localhost"`
 	Port    string `env:"SERVER_PORT" envDefault:"8080"`
+	// CAPath - is CA certificate server is verified by, empty value keeps server unverified.
+	CAPath string `env:"SECRETKEEPER_CA"`
 
 	SSLCertPath string `env:"SSL_CERT_PATH" envDefault:"cert/localhost.crt"`
 	SSLKeyPath  string `env:"SSL_KEY_PATH" envDefault:"cert/localhost.key"`
 
 	JWTSecret string `env:"JWT_SECRET" envDefault:"supa_secret_key"`
 	JWTExp    string `env:"JWT_EXP" envDefault:"14"`
-}
 
-var cfg Config
+	// SessionPath - is a file where subcommand mode caches session, empty value stands for a file in home directory.
+	SessionPath string `env:"SECRETKEEPER_SESSION"`
+	// Login and Password - authorize subcommand mode without session file, e.g. in CI jobs. Password alone unlocks
+	// cached session.
+	Login    string `env:"SECRETKEEPER_LOGIN"`
+	Password string `env:"SECRETKEEPER_PASSWORD"`
+
+	// Output - is default output format of commands: table, json or yaml.
+	Output string `env:"SECRETKEEPER_OUTPUT" envDefault:"table"`
+
+	// SSHAgentSocket - is Unix socket of ssh-agent serving keys of the vault, empty value stands for a file in home
+	// directory.
+	SSHAgentSocket string `env:"SECRETKEEPER_SSH_AUTH_SOCK"`
 
-// NewConfig - creates client Config.
-func NewConfig() Config {
-	cfg.parse()
+	// ClipboardTimeout - is seconds after which "copy" clears clipboard, zero keeps copied value.
+	ClipboardTimeout int `env:"SECRETKEEPER_CLIPBOARD_TIMEOUT" envDefault:"45"`
 
-	return cfg
+	// SyncInterval - is interval of sync of secrets kept in memory of the prompt.
+	SyncInterval time.Duration `env:"SECRETKEEPER_SYNC_INTERVAL" envDefault:"1m"`
+
+	// LockTimeout - is seconds of inactivity after which the prompt locks the vault, zero never locks it.
+	LockTimeout int `env:"SECRETKEEPER_LOCK_TIMEOUT" envDefault:"900"`
+
+	// BreachSource - is URL of range API or path to local corpus of breached password hashes, empty disables the check.
+	BreachSource string `env:"SECRETKEEPER_BREACH_SOURCE"`
 }
 
-func (c *Config) parse() {
+// NewConfig - creates client Config of profile, empty name stands for SECRETKEEPER_PROFILE or current profile of
+// config file, and no profile at all if neither is set.
+//
+// Environment is read first and profile overrides its server address, CA, output format and sync interval.
+func NewConfig(profile string) (Config, error) {
+	var cfg Config
 	if err := env.Parse(&cfg); err != nil {
-		logger.NewLogger().Error(err.Error())
+		return Config{}, fmt.Errorf("error in parsing environment: %w", err)
+	}
+
+	home, errHome := os.UserHomeDir()
+	if errHome != nil {
+		home = "."
+	}
+
+	if cfg.ConfigPath == "" {
+		cfg.ConfigPath = filepath.Join(home, ".secretkeeper", "config.yaml")
+	}
+
+	if profile == "" {
+		profile = cfg.Profile
+	}
+
+	if profile == "" {
+		file, err := ReadProfiles(cfg.ConfigPath)
+		if err != nil {
+			return Config{}, err
+		}
+
+		profile = file.Current
+	}
+
+	if profile != "" {
+		if err := cfg.apply(profile, home); err != nil {
+			return Config{}, err
+		}
+	}
+
+	if cfg.SyncInterval < time.Second {
+		return Config{}, errors.New("sync interval must be at least 1s")
+	}
+
+	// every profile has its own session and agent, so accounts of different servers don't mix
+	dir := filepath.Join(home, ".secretkeeper")
+	if cfg.Profile != "" {
+		dir = filepath.Join(dir, "profiles", cfg.Profile)
+	}
+
+	if cfg.SessionPath == "" {
+		cfg.SessionPath = filepath.Join(dir, "session.json")
+	}
+
+	if cfg.SSHAgentSocket == "" {
+		cfg.SSHAgentSocket = filepath.Join(dir, "agent.sock")
+	}
+
+	return cfg, nil
+}
+
+// ServerAddress - returns host:port of server the client dials.
+func (c Config) ServerAddress() string {
+	return net.JoinHostPort(c.Address, c.Port)
+}
+
+// apply - overrides Config by profile of config file.
+func (c *Config) apply(name, home string) error {
+	file, err := ReadProfiles(c.ConfigPath)
+	if err != nil {
+		return err
+	}
+
+	p, ok := file.Profiles[name]
+	if !ok {
+		return fmt.Errorf("%w: %s isn't in %s", ErrProfile, name, c.ConfigPath)
+	}
+
+	if !ValidProfileName(name) {
+		return fmt.Errorf("%w: name %q must be letters, digits, - and _", ErrProfile, name)
+	}
+
+	c.Profile = name
+
+	if p.Address != "" {
+		host, port, errSplit := net.SplitHostPort(p.Address)
+		if errSplit != nil {
+			return fmt.Errorf("address of profile %s must be host:port: %w", name, errSplit)
+		}
+
+		c.Address, c.Port = host, port
+	}
+
+	if p.CA != "" {
+		c.CAPath = expandHome(p.CA, home)
+	}
+
+	if p.Output != "" {
+		c.Output = p.Output
 	}
+
+	if p.SyncInterval != 0 {
+		c.SyncInterval = p.SyncInterval
+	}
+
+	return nil
 }
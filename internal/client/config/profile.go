@@ -0,0 +1,129 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrProfile - is returned for profile which isn't in config file or has invalid name.
+var ErrProfile = errors.New("unknown profile")
+
+// profileName - is allowed name of profile, it names directory of its session.
+var profileName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]*$`)
+
+// Profile - is a server and account the client works with, e.g. work and home ones.
+type Profile struct {
+	// Address - is host:port of server.
+	Address string `yaml:"address"`
+	// CA - is CA certificate server is verified by, ~ stands for home directory.
+	CA           string        `yaml:"ca,omitempty"`
+	Output       string        `yaml:"output,omitempty"`
+	SyncInterval time.Duration `yaml:"sync_interval,omitempty"`
+}
+
+// Profiles - is config file with profiles, Current is used unless another profile is chosen.
+type Profiles struct {
+	Current  string             `yaml:"current,omitempty"`
+	Profiles map[string]Profile `yaml:"profiles"`
+}
+
+// ReadProfiles - reads config file, missing file has no profiles.
+func ReadProfiles(path string) (Profiles, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return Profiles{}, nil
+	}
+
+	if err != nil {
+		return Profiles{}, fmt.Errorf("error in reading config file: %w", err)
+	}
+
+	var file Profiles
+	if errYAML := yaml.Unmarshal(data, &file); errYAML != nil {
+		return Profiles{}, fmt.Errorf("error in reading config file %s: %w", path, errYAML)
+	}
+
+	return file, nil
+}
+
+// UseProfile - makes profile current one of config file, the rest of the file is kept as is, comments included.
+func UseProfile(path, name string) error {
+	file, err := ReadProfiles(path)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := file.Profiles[name]; !ok {
+		return fmt.Errorf("%w: %s isn't in %s", ErrProfile, name, path)
+	}
+
+	data, errRead := os.ReadFile(path)
+	if errRead != nil {
+		return fmt.Errorf("error in reading config file: %w", errRead)
+	}
+
+	var doc yaml.Node
+	if errYAML := yaml.Unmarshal(data, &doc); errYAML != nil {
+		return fmt.Errorf("error in reading config file %s: %w", path, errYAML)
+	}
+
+	root := doc.Content[0]
+	current := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: name}
+
+	replaced := false
+
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == "current" {
+			root.Content[i+1] = current
+			replaced = true
+
+			break
+		}
+	}
+
+	if !replaced {
+		key := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "current"}
+		root.Content = append([]*yaml.Node{key, current}, root.Content...)
+	}
+
+	var out bytes.Buffer
+
+	enc := yaml.NewEncoder(&out)
+	enc.SetIndent(2)
+
+	if errEncode := enc.Encode(&doc); errEncode != nil {
+		return fmt.Errorf("error in writing config file: %w", errEncode)
+	}
+
+	if errWrite := os.WriteFile(path, out.Bytes(), 0o600); errWrite != nil {
+		return fmt.Errorf("error in writing config file: %w", errWrite)
+	}
+
+	return nil
+}
+
+// ValidProfileName - reports whether name may be a name of profile.
+func ValidProfileName(name string) bool {
+	return profileName.MatchString(name)
+}
+
+// expandHome - replaces leading ~ of path by home directory.
+func expandHome(path, home string) string {
+	if path == "~" {
+		return home
+	}
+
+	if strings.HasPrefix(path, "~/") {
+		return filepath.Join(home, path[2:])
+	}
+
+	return path
+}
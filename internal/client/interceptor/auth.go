@@ -8,6 +8,9 @@ import (
 	"google.golang.org/grpc/metadata"
 )
 
+// ErrUnauthorized - is returned when protected method is called before login.
+var ErrUnauthorized = errors.New("you have to be authorized via login first")
+
 // AuthInterceptor is a client interceptor for authentication
 type AuthInterceptor struct {
 	protectedMethods map[string]bool
@@ -36,7 +39,7 @@ func (a *AuthInterceptor) Unary() grpc.UnaryClientInterceptor {
 			}
 
 			if len(token) == 0 {
-				return errors.New("you have to be authorized via login first")
+				return ErrUnauthorized
 			}
 
 			return invoker(ctx, method, req, reply, cc, opts...)
@@ -5,4 +5,7 @@ import "context"
 type GlobalContext struct {
 	Ctx    context.Context
 	Cancel context.CancelFunc
+
+	// VaultID - is id of active organization vault, 0 stands for personal secrets.
+	VaultID int
 }
@@ -1,6 +1,9 @@
 package secret
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 type CardSecret struct {
 	Id         int `json:"-"`
@@ -16,3 +19,17 @@ type CardSecret struct {
 func (cs CardSecret) GetUpdateTime() time.Time {
 	return cs.UpdatedAt
 }
+
+// DueTime - returns the moment card stops being valid, which is the start of the month after Due.
+//
+// Due is expected in MM/YY or MM/YYYY format, false is returned for any other value.
+func (cs CardSecret) DueTime() (time.Time, bool) {
+	for _, layout := range []string{"01/06", "01/2006"} {
+		due, err := time.ParseInLocation(layout, strings.TrimSpace(cs.Due), time.Local)
+		if err == nil {
+			return due.AddDate(0, 1, 0), true
+		}
+	}
+
+	return time.Time{}, false
+}
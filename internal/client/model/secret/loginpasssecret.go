@@ -8,6 +8,7 @@ type LoginPassSecret struct {
 	RecordType int
 	Login      string
 	Password   string
+	URL        string    `json:",omitempty"`
 	UpdatedAt  time.Time `json:"-"`
 	IsDelited  bool      `json:"-"`
 }
@@ -8,6 +8,47 @@ type ResSecret struct {
 	Content string
 }
 
+// EmergencySecret - is a decoded personal secret of another user, opened via emergency access.
+type EmergencySecret struct {
+	Id        int
+	Title     string
+	Type      int
+	UpdatedAt time.Time
+
+	Content string
+}
+
+// Expiry - is expiry time, rotation period and auto expire flag of a secret, zero values disable them.
+type Expiry struct {
+	ExpiresAt       *time.Time
+	RotateEveryDays int
+	// AutoExpire - server moves secret to trash once ExpiresAt is passed.
+	AutoExpire bool
+}
+
+// NewSecret - is a secret to be created, Content is marshalled secret of its type.
+type NewSecret struct {
+	Title   string
+	Type    int
+	Content string
+	Expiry  Expiry
+	// CreatedAt and UpdatedAt - are kept by restored secret, zero values mean the current time.
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// StoredSecret - is a decoded secret with its metadata, Content of binary secret is the file.
+type StoredSecret struct {
+	Id        int
+	Title     string
+	Type      int
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Expiry    Expiry
+
+	Content string
+}
+
 type Secret interface {
 	GetUpdateTime() time.Time
 }
@@ -0,0 +1,22 @@
+package secret
+
+import "time"
+
+type SSHSecret struct {
+	Id         int `json:"-"`
+	Title      string
+	RecordType int
+	// PrivateKey - is unencrypted PKCS#8 PEM, the vault encrypts it.
+	PrivateKey string
+	// PublicKey - is in authorized_keys format.
+	PublicKey string
+	Comment   string
+	// Confirm - ssh-agent asks to confirm every use of the key.
+	Confirm   bool
+	UpdatedAt time.Time `json:"-"`
+	IsDelited bool      `json:"-"`
+}
+
+func (ss SSHSecret) GetUpdateTime() time.Time {
+	return ss.UpdatedAt
+}
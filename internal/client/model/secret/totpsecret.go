@@ -0,0 +1,51 @@
+package secret
+
+import (
+	"time"
+
+	"secretKeeper/pkg/totp"
+)
+
+type TOTPSecret struct {
+	Id         int `json:"-"`
+	Title      string
+	RecordType int
+	Seed       string
+	Issuer     string
+	Account    string
+	Algorithm  string
+	Digits     int
+	Period     int
+	UpdatedAt  time.Time `json:"-"`
+	IsDelited  bool      `json:"-"`
+}
+
+// NewTOTPSecret - creates TOTPSecret from parsed totp.Key.
+func NewTOTPSecret(title string, key totp.Key) TOTPSecret {
+	return TOTPSecret{
+		Title:      title,
+		RecordType: 5,
+		Seed:       key.Secret,
+		Issuer:     key.Issuer,
+		Account:    key.Account,
+		Algorithm:  key.Algorithm,
+		Digits:     key.Digits,
+		Period:     key.Period,
+	}
+}
+
+func (ts TOTPSecret) GetUpdateTime() time.Time {
+	return ts.UpdatedAt
+}
+
+// Key - returns totp.Key which generates codes of TOTPSecret.
+func (ts TOTPSecret) Key() totp.Key {
+	return totp.Key{
+		Secret:    ts.Seed,
+		Issuer:    ts.Issuer,
+		Account:   ts.Account,
+		Algorithm: ts.Algorithm,
+		Digits:    ts.Digits,
+		Period:    ts.Period,
+	}
+}
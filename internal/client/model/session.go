@@ -0,0 +1,11 @@
+package model
+
+// Session - is a state of logged user, which is cached between runs of subcommand mode.
+//
+// No keys are cached, PublicKey only checks password which key pair is derived from by each run.
+type Session struct {
+	Login     string `json:"login"`
+	Token     string `json:"token"`
+	PublicKey []byte `json:"public_key"`
+	VaultID   int    `json:"vault_id"`
+}
@@ -0,0 +1,400 @@
+package executor
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// argKind - is a type of positional argument or option value.
+type argKind int
+
+const (
+	kindString argKind = iota
+	kindInt
+	// kindBool - is an option without value, e.g. --force.
+	kindBool
+)
+
+// argSpec - declares positional argument of a command.
+type argSpec struct {
+	// name - is a key of the argument in input and its placeholder in usage.
+	name string
+	// label - is a name of the argument in validation messages.
+	label string
+	kind  argKind
+	// optional - argument may be omitted, only trailing arguments may be optional.
+	optional bool
+	// variadic - argument takes all remaining tokens, it must be the last one.
+	variadic bool
+	// secret - argument omitted from command line or passed as "-" is read through masked prompt or from stdin.
+	secret bool
+	// confirm - secret argument is asked twice, so a typo isn't stored.
+	confirm bool
+	// generated - secret argument is produced by password generator instead of prompt if --generate is set.
+	generated bool
+	// complete - is a source of suggestions for the argument in REPL.
+	complete completion
+	// choices - are fixed values suggested for the argument.
+	choices []string
+}
+
+// flagSpec - declares option of a command.
+type flagSpec struct {
+	name string
+	// short - is one letter alias of the option, e.g. -f for --force.
+	short string
+	kind  argKind
+	// choices - are fixed values suggested for the option.
+	choices []string
+	// group - options of the same group are shown in usage as a single "[group options]" part.
+	group string
+	// repeated - option may be set several times, every value is kept.
+	repeated bool
+}
+
+// globalFlags - are options accepted by every command.
+var globalFlags = []flagSpec{
+	{name: "output", short: "o", kind: kindString, choices: []string{"table", "json", "yaml"}},
+	{name: "stdin", kind: kindBool},
+	{name: "profile", kind: kindString},
+}
+
+// errUnterminatedQuote - is returned by tokenize for a line with unclosed quote.
+var errUnterminatedQuote = errors.New("unterminated quote")
+
+// input - is a command line parsed by argument and option declarations of a command.
+type input struct {
+	args map[string][]string
+	opts map[string]string
+	// repeated - are values of options declared as repeated in order they are set.
+	repeated map[string][]string
+}
+
+// str - returns value of an argument, tokens of variadic argument are joined by space.
+func (in input) str(name string) string {
+	return strings.Join(in.args[name], " ")
+}
+
+// list - returns tokens of an argument.
+func (in input) list(name string) []string {
+	return in.args[name]
+}
+
+// int - returns value of an argument declared as kindInt, or zero if optional argument is omitted.
+func (in input) int(name string) int {
+	value, _ := strconv.Atoi(in.str(name))
+
+	return value
+}
+
+// optionInt - returns value of an option declared as kindInt, or zero if option is not set.
+func (in input) optionInt(name string) int {
+	value, _ := strconv.Atoi(in.opts[name])
+
+	return value
+}
+
+// has - reports whether an argument is provided.
+func (in input) has(name string) bool {
+	return len(in.args[name]) > 0
+}
+
+// flag - reports whether an option declared as kindBool is set.
+func (in input) flag(name string) bool {
+	value, ok := in.opts[name]
+
+	return ok && value != "false"
+}
+
+// option - returns value of an option, or empty string if option is not set.
+func (in input) option(name string) string {
+	return in.opts[name]
+}
+
+// options - returns every value of an option declared as repeated.
+func (in input) options(name string) []string {
+	return in.repeated[name]
+}
+
+// tokenize - splits command line like POSIX shell does.
+//
+// Single quotes keep everything literally, double quotes keep everything except backslash escapes of \, " and $,
+// and unquoted backslash escapes any character.
+func tokenize(line string) ([]string, error) {
+	var (
+		tokens  []string
+		current strings.Builder
+		started bool
+		quote   rune
+		escaped bool
+	)
+
+	for _, r := range line {
+		switch {
+		case escaped:
+			if quote == '"' && r != '\\' && r != '"' && r != '$' {
+				current.WriteRune('\\')
+			}
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped, started = true, true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote, started = r, true
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			if started {
+				tokens = append(tokens, current.String())
+				current.Reset()
+				started = false
+			}
+		default:
+			current.WriteRune(r)
+			started = true
+		}
+	}
+
+	if quote != 0 || escaped {
+		return nil, errUnterminatedQuote
+	}
+
+	if started {
+		tokens = append(tokens, current.String())
+	}
+
+	return tokens, nil
+}
+
+// splitCommand - returns name of a command and its tokens, global options may precede the name.
+func splitCommand(tokens []string) (string, []string) {
+	for i := 0; i < len(tokens); i++ {
+		if !isOption(tokens[i]) {
+			rest := append(append([]string{}, tokens[:i]...), tokens[i+1:]...)
+
+			return tokens[i], rest
+		}
+
+		// value of a global option may follow it as a separate token
+		if spec, ok := lookupFlag(globalFlags, optionName(tokens[i])); ok && spec.kind != kindBool &&
+			!strings.Contains(tokens[i], "=") {
+			i++
+		}
+	}
+
+	return "", tokens
+}
+
+// parseInput - parses tokens by declarations of arguments and options, options may be mixed with arguments
+// and "--" ends options.
+func parseInput(specs []argSpec, flags []flagSpec, tokens []string) (input, error) {
+	in := input{
+		args:     make(map[string][]string, len(specs)),
+		opts:     make(map[string]string),
+		repeated: make(map[string][]string),
+	}
+
+	var positional []string
+
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+
+		if token == "--" {
+			positional = append(positional, tokens[i+1:]...)
+
+			break
+		}
+
+		if !isOption(token) {
+			positional = append(positional, token)
+
+			continue
+		}
+
+		spec, ok := lookupFlag(flags, optionName(token))
+		if !ok {
+			return in, validationError("unknown option %s", token)
+		}
+
+		_, value, hasValue := strings.Cut(token, "=")
+
+		switch {
+		case spec.kind == kindBool && !hasValue:
+			value = "true"
+		case spec.kind != kindBool && !hasValue:
+			if i+1 >= len(tokens) {
+				return in, validationError("option --%s requires a value", spec.name)
+			}
+
+			i++
+			value = tokens[i]
+		}
+
+		if spec.kind == kindInt {
+			if _, err := strconv.Atoi(value); err != nil {
+				return in, validationError("option --%s must be a number", spec.name)
+			}
+		}
+
+		in.opts[spec.name] = value
+
+		if spec.repeated {
+			in.repeated[spec.name] = append(in.repeated[spec.name], value)
+		}
+	}
+
+	if err := bindArgs(in.args, specs, positional); err != nil {
+		return in, err
+	}
+
+	return in, nil
+}
+
+// bindArgs - assigns positional tokens to declared arguments and validates them.
+//
+// If tokens are enough only for arguments which are not secret, all secret arguments are left to be read later.
+func bindArgs(args map[string][]string, specs []argSpec, positional []string) error {
+	var missing []string
+
+	if len(positional) < requiredArgs(specs, true) && len(positional) >= requiredArgs(specs, false) {
+		specs = publicArgs(specs)
+	}
+
+	for i, spec := range specs {
+		switch {
+		case i >= len(positional):
+			if !spec.optional {
+				missing = append(missing, spec.label)
+			}
+
+			continue
+		case spec.variadic:
+			args[spec.name] = positional[i:]
+		default:
+			args[spec.name] = positional[i : i+1]
+		}
+
+		if spec.kind == kindInt {
+			if _, err := strconv.Atoi(positional[i]); err != nil {
+				return validationError("%s must be a number", spec.label)
+			}
+		}
+	}
+
+	if len(missing) > 0 {
+		return validationError("%s is missing", joinLabels(missing))
+	}
+
+	if len(positional) > len(specs) && (len(specs) == 0 || !specs[len(specs)-1].variadic) {
+		return validationError("unexpected argument %s", positional[len(specs)])
+	}
+
+	return nil
+}
+
+// requiredArgs - returns count of arguments which may not be omitted, secret ones are counted if withSecrets is set.
+func requiredArgs(specs []argSpec, withSecrets bool) int {
+	var count int
+
+	for _, spec := range specs {
+		if !spec.optional && (withSecrets || !spec.secret) {
+			count++
+		}
+	}
+
+	return count
+}
+
+// publicArgs - returns arguments which are not secret.
+func publicArgs(specs []argSpec) []argSpec {
+	public := make([]argSpec, 0, len(specs))
+
+	for _, spec := range specs {
+		if !spec.secret {
+			public = append(public, spec)
+		}
+	}
+
+	return public
+}
+
+// usage - returns usage line of a command generated from its declarations.
+func usage(cmd Command) string {
+	parts := []string{cmd.Name}
+
+	for _, spec := range cmd.args {
+		part := "%" + spec.name + "%"
+		if spec.variadic {
+			part += "..."
+		}
+
+		if spec.optional || spec.secret {
+			part = "[" + part + "]"
+		}
+
+		parts = append(parts, part)
+	}
+
+	groups := make(map[string]bool)
+
+	for _, spec := range cmd.flags {
+		if spec.group != "" {
+			if !groups[spec.group] {
+				parts = append(parts, "["+spec.group+" options]")
+				groups[spec.group] = true
+			}
+
+			continue
+		}
+
+		part := "--" + spec.name
+		if spec.kind != kindBool {
+			part += " %" + spec.name + "%"
+		}
+
+		if spec.repeated {
+			part += "..."
+		}
+
+		parts = append(parts, "["+part+"]")
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// joinLabels - joins labels as "A, B and C".
+func joinLabels(labels []string) string {
+	if len(labels) == 1 {
+		return labels[0]
+	}
+
+	return strings.Join(labels[:len(labels)-1], ", ") + " and " + labels[len(labels)-1]
+}
+
+// isOption - reports whether token is an option, single dash is a regular argument.
+func isOption(token string) bool {
+	return len(token) > 1 && strings.HasPrefix(token, "-")
+}
+
+// optionName - returns name of an option without dashes and value.
+func optionName(token string) string {
+	name, _, _ := strings.Cut(strings.TrimLeft(token, "-"), "=")
+
+	return name
+}
+
+// lookupFlag - finds option declaration by its name or short alias.
+func lookupFlag(flags []flagSpec, name string) (flagSpec, bool) {
+	for _, spec := range flags {
+		if spec.name == name || (spec.short != "" && spec.short == name) {
+			return spec, true
+		}
+	}
+
+	return flagSpec{}, false
+}
@@ -0,0 +1,265 @@
+package executor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		name    string
+		line    string
+		want    []string
+		wantErr error
+	}{
+		{
+			name: "spaces and tabs",
+			line: "  get-secret\t12  --field password ",
+			want: []string{"get-secret", "12", "--field", "password"},
+		},
+		{name: "empty line", line: "   "},
+		{name: "single quotes keep everything", line: `say 'a "b" \n $c'`, want: []string{"say", `a "b" \n $c`}},
+		{name: "double quotes", line: `say "a 'b' c"`, want: []string{"say", "a 'b' c"}},
+		{
+			name: "escapes in double quotes",
+			line: `say "a \"b\" \\ \$c \n"`,
+			want: []string{"say", `a "b" \ $c \n`},
+		},
+		{name: "unquoted escapes", line: `say a\ b \'c\'`, want: []string{"say", "a b", "'c'"}},
+		{name: "empty quotes are a token", line: `say "" ''`, want: []string{"say", "", ""}},
+		{name: "quotes join with text", line: `say pre"fix 1"'post'`, want: []string{"say", "prefix 1post"}},
+		{name: "unterminated double quote", line: `say "abc`, wantErr: errUnterminatedQuote},
+		{name: "unterminated single quote", line: `say 'abc`, wantErr: errUnterminatedQuote},
+		{name: "trailing backslash", line: `say abc\`, wantErr: errUnterminatedQuote},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens, err := tokenize(tt.line)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, tokens)
+		})
+	}
+}
+
+func TestSplitCommand(t *testing.T) {
+	tests := []struct {
+		name     string
+		tokens   []string
+		wantName string
+		wantRest []string
+	}{
+		{name: "command first", tokens: []string{"get-secret", "12"}, wantName: "get-secret", wantRest: []string{"12"}},
+		{
+			name:     "global option with separate value",
+			tokens:   []string{"--output", "json", "get-secret", "12"},
+			wantName: "get-secret",
+			wantRest: []string{"--output", "json", "12"},
+		},
+		{
+			name:     "global option with value after equals sign",
+			tokens:   []string{"--output=json", "get-secret"},
+			wantName: "get-secret",
+			wantRest: []string{"--output=json"},
+		},
+		{
+			name:     "short global option",
+			tokens:   []string{"-o", "yaml", "--stdin", "login", "alice"},
+			wantName: "login",
+			wantRest: []string{"-o", "yaml", "--stdin", "alice"},
+		},
+		{name: "options only", tokens: []string{"--stdin"}, wantRest: []string{"--stdin"}},
+		{name: "no tokens"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name, rest := splitCommand(tt.tokens)
+			assert.Equal(t, tt.wantName, name)
+			assert.Equal(t, len(tt.wantRest), len(rest))
+
+			if len(tt.wantRest) > 0 {
+				assert.Equal(t, tt.wantRest, rest)
+			}
+		})
+	}
+}
+
+func TestParseInput(t *testing.T) {
+	specs := []argSpec{
+		{name: "id", label: "ID", kind: kindInt},
+		{name: "title", label: "Title"},
+		{name: "password", label: "Password", secret: true},
+		{name: "notes", label: "Notes", optional: true, variadic: true},
+	}
+	flags := []flagSpec{
+		{name: "force", short: "f", kind: kindBool},
+		{name: "field", kind: kindString},
+		{name: "length", kind: kindInt},
+		{name: "map", kind: kindString, repeated: true},
+	}
+
+	tests := []struct {
+		name     string
+		tokens   []string
+		wantArgs map[string][]string
+		wantOpts map[string]string
+		wantMaps []string
+		wantErr  string
+	}{
+		{
+			name:   "all arguments",
+			tokens: []string{"12", "mail", "p@ss", "first", "second"},
+			wantArgs: map[string][]string{
+				"id": {"12"}, "title": {"mail"}, "password": {"p@ss"}, "notes": {"first", "second"},
+			},
+			wantOpts: map[string]string{},
+		},
+		{
+			name:     "secret argument is left to prompt",
+			tokens:   []string{"12", "mail"},
+			wantArgs: map[string][]string{"id": {"12"}, "title": {"mail"}},
+			wantOpts: map[string]string{},
+		},
+		{
+			name:     "options mixed with arguments",
+			tokens:   []string{"-f", "12", "--field", "login", "mail", "--length=20", "p@ss"},
+			wantArgs: map[string][]string{"id": {"12"}, "title": {"mail"}, "password": {"p@ss"}},
+			wantOpts: map[string]string{"force": "true", "field": "login", "length": "20"},
+		},
+		{
+			name:   "double dash ends options",
+			tokens: []string{"12", "--", "--mail", "-p", "--force"},
+			wantArgs: map[string][]string{
+				"id": {"12"}, "title": {"--mail"}, "password": {"-p"}, "notes": {"--force"},
+			},
+			wantOpts: map[string]string{},
+		},
+		{
+			name:     "single dash is an argument",
+			tokens:   []string{"12", "mail", "-"},
+			wantArgs: map[string][]string{"id": {"12"}, "title": {"mail"}, "password": {"-"}},
+			wantOpts: map[string]string{},
+		},
+		{
+			name:     "bool option with value",
+			tokens:   []string{"12", "mail", "--force=false"},
+			wantArgs: map[string][]string{"id": {"12"}, "title": {"mail"}},
+			wantOpts: map[string]string{"force": "false"},
+		},
+		{
+			name:     "repeated option keeps every value",
+			tokens:   []string{"12", "mail", "--map", "login=User", "--map=url=Site"},
+			wantArgs: map[string][]string{"id": {"12"}, "title": {"mail"}},
+			wantOpts: map[string]string{"map": "url=Site"},
+			wantMaps: []string{"login=User", "url=Site"},
+		},
+		{name: "unknown option", tokens: []string{"12", "mail", "--color"}, wantErr: "unknown option --color"},
+		{
+			name:    "option without value",
+			tokens:  []string{"12", "mail", "--field"},
+			wantErr: "option --field requires a value",
+		},
+		{
+			name:    "option is not a number",
+			tokens:  []string{"12", "mail", "--length", "ten"},
+			wantErr: "option --length must be a number",
+		},
+		{name: "argument is not a number", tokens: []string{"twelve", "mail"}, wantErr: "ID must be a number"},
+		// secret argument is asked for only if public ones are passed
+		{name: "missing argument", tokens: []string{"12"}, wantErr: "Title and Password is missing"},
+		{name: "missing arguments", tokens: nil, wantErr: "ID, Title and Password is missing"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in, err := parseInput(specs, flags, tt.tokens)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Equal(t, "validation error: "+tt.wantErr, err.Error())
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantArgs, in.args)
+			assert.Equal(t, tt.wantOpts, in.opts)
+			assert.Equal(t, tt.wantMaps, in.options("map"))
+		})
+	}
+}
+
+func TestBindArgs(t *testing.T) {
+	tests := []struct {
+		name       string
+		specs      []argSpec
+		positional []string
+		want       map[string][]string
+		wantErr    string
+	}{
+		{
+			name:       "extra argument",
+			specs:      []argSpec{{name: "id", label: "ID"}},
+			positional: []string{"12", "13"},
+			wantErr:    "unexpected argument 13",
+		},
+		{
+			name:       "command without arguments",
+			positional: []string{"12"},
+			wantErr:    "unexpected argument 12",
+		},
+		{
+			name:       "optional argument is omitted",
+			specs:      []argSpec{{name: "id", label: "ID"}, {name: "field", label: "Field", optional: true}},
+			positional: []string{"12"},
+			want:       map[string][]string{"id": {"12"}},
+		},
+		{
+			name: "secret argument is bound when every argument is passed",
+			specs: []argSpec{
+				{name: "login", label: "Login"}, {name: "password", label: "Password", secret: true},
+				{name: "url", label: "URL"},
+			},
+			positional: []string{"alice", "p@ss", "https://example.com"},
+			want:       map[string][]string{"login": {"alice"}, "password": {"p@ss"}, "url": {"https://example.com"}},
+		},
+		{
+			name: "secret argument is skipped when only public ones are passed",
+			specs: []argSpec{
+				{name: "login", label: "Login"}, {name: "password", label: "Password", secret: true},
+				{name: "url", label: "URL"},
+			},
+			positional: []string{"alice", "https://example.com"},
+			want:       map[string][]string{"login": {"alice"}, "url": {"https://example.com"}},
+		},
+		{
+			name: "several arguments are missing",
+			specs: []argSpec{
+				{name: "login", label: "Login"}, {name: "url", label: "URL"}, {name: "title", label: "Title"},
+			},
+			positional: []string{"alice"},
+			wantErr:    "URL and Title is missing",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := make(map[string][]string)
+
+			err := bindArgs(args, tt.specs, tt.positional)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Equal(t, "validation error: "+tt.wantErr, err.Error())
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, args)
+		})
+	}
+}
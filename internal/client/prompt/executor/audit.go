@@ -0,0 +1,296 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"google.golang.org/grpc/codes"
+
+	secretModel "secretKeeper/internal/client/model/secret"
+	"secretKeeper/pkg/passgen"
+)
+
+// defaultMaxAgeDays - is age of password after which it is reported as old, unless --max-age is set.
+const defaultMaxAgeDays = 180
+
+// auditItem - is a row of "audit-passwords" result.
+type auditItem struct {
+	ID       int    `json:"id"`
+	Title    string `json:"title"`
+	Login    string `json:"login"`
+	Score    int    `json:"score"`
+	Strength string `json:"strength"`
+	AgeDays  int    `json:"age_days"`
+	ReusedIn []int  `json:"reused_in,omitempty"`
+	// Breached - is how many times the password is seen in breaches, it is omitted if the check is disabled.
+	Breached *int     `json:"breached,omitempty"`
+	Issues   []string `json:"issues,omitempty"`
+	// Risk - is used to sort the report, the riskiest password goes first.
+	Risk int `json:"risk"`
+}
+
+// auditReport - is a result of "audit-passwords" command.
+type auditReport []auditItem
+
+// WriteTable - writes a row per password with its issues.
+func (a auditReport) WriteTable(w io.Writer) error {
+	if len(a) == 0 {
+		_, err := fmt.Fprintln(w, "no login/pass secrets to audit")
+
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+	fmt.Fprintln(tw, "RISK\tID\tTITLE\tLOGIN\tSTRENGTH\tAGE\tISSUES")
+
+	for _, item := range a {
+		issues := strings.Join(item.Issues, "; ")
+		if issues == "" {
+			issues = "-"
+		}
+
+		fmt.Fprintf(tw, "%d\t%d\t%s\t%s\t%d/4 %s\t%dd\t%s\n",
+			item.Risk, item.ID, item.Title, item.Login, item.Score, item.Strength, item.AgeDays, issues)
+	}
+
+	return tw.Flush()
+}
+
+// auditPasswords - is executor for "audit-passwords" case in Execute method.
+//
+// Login/pass secrets of active vault are checked locally, only 5 characters of SHA-1 hashes of passwords leave the
+// client if breach corpus is served by range API.
+func (e *Executor) auditPasswords(in input) (auditReport, error) {
+	maxAge := defaultMaxAgeDays
+	if in.option("max-age") != "" {
+		maxAge = in.optionInt("max-age")
+	}
+
+	if maxAge <= 0 {
+		return nil, validationError("--max-age must be a positive number of days")
+	}
+
+	secrets, err := e.loginPassSecrets()
+	if err != nil {
+		return nil, err
+	}
+
+	byPassword := make(map[string][]int)
+	report := make(auditReport, 0)
+	passwords := make(map[int]string)
+
+	for _, secret := range secrets {
+		estimate := passgen.EstimateStrength(secret.Password)
+
+		item := auditItem{
+			ID:       secret.Id,
+			Title:    secret.Title,
+			Login:    secret.Login,
+			Score:    estimate.Score,
+			Strength: estimate.Label(),
+			AgeDays:  int(time.Since(secret.UpdatedAt).Hours() / 24),
+		}
+
+		switch {
+		case secret.Password == "":
+			item.Issues = append(item.Issues, "empty password")
+		case estimate.Score < 3:
+			issue := "weak"
+			if len(estimate.Warnings) > 0 {
+				issue += ": " + strings.Join(estimate.Warnings, ", ")
+			}
+
+			item.Issues = append(item.Issues, issue)
+		}
+
+		if item.AgeDays > maxAge {
+			item.Issues = append(item.Issues, fmt.Sprintf("not changed for %d days", item.AgeDays))
+		}
+
+		if secret.Password != "" {
+			byPassword[secret.Password] = append(byPassword[secret.Password], secret.Id)
+			passwords[secret.Id] = secret.Password
+		}
+
+		report = append(report, item)
+	}
+
+	breached, errBreach := e.breachCounts(in, byPassword)
+	if errBreach != nil {
+		return nil, errBreach
+	}
+
+	for i := range report {
+		item := &report[i]
+
+		if count, ok := breached[item.ID]; ok {
+			item.Breached = &count
+			if count > 0 {
+				item.Issues = append(item.Issues, fmt.Sprintf("seen %d times in breaches", count))
+			}
+		}
+
+		for _, id := range byPassword[passwords[item.ID]] {
+			if id != item.ID {
+				item.ReusedIn = append(item.ReusedIn, id)
+			}
+		}
+
+		if len(item.ReusedIn) > 0 {
+			sort.Ints(item.ReusedIn)
+			item.Issues = append(item.Issues, "reused in "+joinInts(item.ReusedIn))
+		}
+
+		item.Risk = auditRisk(*item, maxAge)
+	}
+
+	sort.SliceStable(report, func(i, j int) bool {
+		if report[i].Risk != report[j].Risk {
+			return report[i].Risk > report[j].Risk
+		}
+
+		return report[i].ID < report[j].ID
+	})
+
+	return report, nil
+}
+
+// loginPassSecrets - returns login/pass secrets of active vault which are not deleted. Personal secrets are re-synced
+// and read from memory storage, secrets of organization vault are requested and decrypted one by one.
+func (e *Executor) loginPassSecrets() ([]secretModel.LoginPassSecret, error) {
+	if e.app.SecretService.VaultID() != 0 {
+		return e.vaultLoginPassSecrets()
+	}
+
+	if err := e.app.Syncer.SyncPassLoginData(); err != nil {
+		return nil, err
+	}
+
+	var secrets []secretModel.LoginPassSecret
+
+	for _, listed := range e.app.Storage.GetSecretList(1) {
+		secret, ok, _ := e.app.Storage.GetLoginPassSecret(int(listed.Id))
+		// sync skips secrets deleted on server, and deleted by this process are zeroed in memory storage
+		if !ok || secret.Id == 0 || secret.IsDelited {
+			continue
+		}
+
+		secrets = append(secrets, secret)
+	}
+
+	return secrets, nil
+}
+
+// vaultLoginPassSecrets - requests and decrypts login/pass secrets of active organization vault, which aren't kept in
+// memory storage.
+func (e *Executor) vaultLoginPassSecrets() ([]secretModel.LoginPassSecret, error) {
+	list, err := e.app.SecretService.GetListOfSecretes(typeLoginPass)
+	if err != nil {
+		return nil, err
+	}
+
+	var secrets []secretModel.LoginPassSecret
+
+	for _, listed := range list {
+		if listed.IsDelited {
+			continue
+		}
+
+		stored, errFetch := e.fetchSecret(int(listed.Id))
+		if errFetch != nil {
+			return nil, errFetch
+		}
+
+		var secret secretModel.LoginPassSecret
+		if errUnmarshal := json.Unmarshal([]byte(stored.Content), &secret); errUnmarshal != nil {
+			return nil, fmt.Errorf("error in decoding secret %d: %w", listed.Id, errUnmarshal)
+		}
+
+		secret.Id, secret.UpdatedAt = int(listed.Id), stored.UpdatedAt
+		secrets = append(secrets, secret)
+	}
+
+	return secrets, nil
+}
+
+// auditRisk - weights issues of a password, breached and reused passwords weigh the most as they are exposed
+// regardless of their strength.
+func auditRisk(item auditItem, maxAge int) int {
+	risk := (4 - item.Score) * 10
+
+	if item.Breached != nil && *item.Breached > 0 {
+		risk += 50
+	}
+
+	if len(item.ReusedIn) > 0 {
+		risk += 40
+	}
+
+	if item.AgeDays > maxAge {
+		risk += 20
+	}
+
+	return risk
+}
+
+// joinInts - joins numbers by comma.
+func joinInts(values []int) string {
+	parts := make([]string, 0, len(values))
+	for _, value := range values {
+		parts = append(parts, fmt.Sprint(value))
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// breachCounts - checks every distinct password against breach corpus once and returns counts by secret ID.
+//
+// Nothing is checked if corpus isn't configured or --no-breach is set.
+func (e *Executor) breachCounts(in input, byPassword map[string][]int) (map[int]int, error) {
+	counts := make(map[int]int)
+
+	if e.app.Breach == nil || in.flag("no-breach") {
+		return counts, nil
+	}
+
+	for password, ids := range byPassword {
+		count, err := e.app.Breach.Count(context.Background(), password)
+		if err != nil {
+			return nil, &commandError{
+				msg:  fmt.Sprintf("error: breach check failed: %v, pass --no-breach to skip it", err),
+				code: codes.Unavailable,
+			}
+		}
+
+		for _, id := range ids {
+			counts[id] = count
+		}
+	}
+
+	return counts, nil
+}
+
+// breachWarning - returns warning about password seen in breaches, generated passwords aren't checked.
+func (e *Executor) breachWarning(password string) string {
+	if e.app.Breach == nil || e.generated != nil {
+		return ""
+	}
+
+	count, err := e.app.Breach.Count(context.Background(), password)
+
+	switch {
+	case err != nil:
+		return fmt.Sprintf("warning: password isn't checked against breaches: %v", err)
+	case count > 0:
+		return fmt.Sprintf("warning: password is seen %d times in breaches, consider --generate", count)
+	default:
+		return ""
+	}
+}
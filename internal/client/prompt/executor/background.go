@@ -0,0 +1,76 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"google.golang.org/grpc/codes"
+)
+
+// backgroundJob - is a job served in background of REPL, done is closed when it returns.
+type backgroundJob struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// startBackground - runs job in background of REPL until it returns or REPL exits, a job of the same name runs once.
+func (e *Executor) startBackground(name string, run func(ctx context.Context) error) error {
+	e.jobsMu.Lock()
+	defer e.jobsMu.Unlock()
+
+	if _, ok := e.jobs[name]; ok {
+		return &commandError{msg: fmt.Sprintf("error: %s is already running", name), code: codes.AlreadyExists}
+	}
+
+	if e.jobs == nil {
+		e.jobs = make(map[string]*backgroundJob)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	job := &backgroundJob{cancel: cancel, done: make(chan struct{})}
+	e.jobs[name] = job
+
+	go func() {
+		defer close(job.done)
+
+		if err := run(ctx); err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", name, err)
+		}
+
+		e.jobsMu.Lock()
+		delete(e.jobs, name)
+		e.jobsMu.Unlock()
+	}()
+
+	return nil
+}
+
+// stopBackground - stops every background job and waits until they return, e.g. until socket of ssh-agent is removed.
+func (e *Executor) stopBackground() {
+	e.jobsMu.Lock()
+	jobs := make([]*backgroundJob, 0, len(e.jobs))
+	for _, job := range e.jobs {
+		jobs = append(jobs, job)
+	}
+	e.jobsMu.Unlock()
+
+	for _, job := range jobs {
+		job.cancel()
+		<-job.done
+	}
+}
+
+// stopJob - stops background job of name, if it runs, and waits until it returns.
+func (e *Executor) stopJob(name string) {
+	e.jobsMu.Lock()
+	job, ok := e.jobs[name]
+	e.jobsMu.Unlock()
+
+	if !ok {
+		return
+	}
+
+	job.cancel()
+	<-job.done
+}
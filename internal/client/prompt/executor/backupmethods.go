@@ -0,0 +1,204 @@
+package executor
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+
+	secretModel "secretKeeper/internal/client/model/secret"
+	"secretKeeper/pkg/backup"
+)
+
+// backupReport - is a result of "export", "export-as" and "import-backup" commands.
+type backupReport struct {
+	Message   string    `json:"message"`
+	CreatedAt time.Time `json:"created_at"`
+	// Types - is number of secrets by type name.
+	Types map[string]int `json:"types"`
+}
+
+// WriteTable - writes message and number of secrets of every type.
+func (r backupReport) WriteTable(w io.Writer) error {
+	names := make([]string, 0, len(r.Types))
+	for name := range r.Types {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	counts := make([]string, 0, len(names))
+	for _, name := range names {
+		counts = append(counts, fmt.Sprintf("%s: %d", name, r.Types[name]))
+	}
+
+	_, err := fmt.Fprintf(w, "%s\nbackup of %s, %s\n", r.Message, r.CreatedAt.Local().Format(time.RFC1123),
+		strings.Join(counts, ", "))
+
+	return err
+}
+
+// exportBackup - is executor for "export" case in Execute method.
+//
+// Every secret of active vault, or every personal secret, is written with its metadata, deleted secrets are skipped.
+func (e *Executor) exportBackup(in input) (backupReport, error) {
+	secrets, report, err := e.storedSecrets()
+	if err != nil {
+		return backupReport{}, err
+	}
+
+	b := secretsBackup(secrets)
+
+	data, errEncrypt := backup.Encrypt(b, in.str("passphrase"))
+	if errEncrypt != nil {
+		return backupReport{}, errEncrypt
+	}
+
+	// backup is readable only by its owner, though it is encrypted
+	if errWrite := writeFileAtomic(in.str("path"), data, 0o600); errWrite != nil {
+		return backupReport{}, errWrite
+	}
+
+	report.Message = fmt.Sprintf("%d secrets are exported to %s", len(b.Secrets), in.str("path"))
+	report.CreatedAt = b.CreatedAt
+
+	return report, nil
+}
+
+// storedSecrets - returns every secret of active vault, or every personal secret, with report counting them by type,
+// deleted secrets are skipped.
+func (e *Executor) storedSecrets() ([]secretModel.StoredSecret, backupReport, error) {
+	types, err := e.cachedTypes()
+	if err != nil {
+		return nil, backupReport{}, err
+	}
+
+	var secrets []secretModel.StoredSecret
+
+	report := backupReport{Types: make(map[string]int)}
+
+	for _, t := range types {
+		list, errList := e.app.SecretService.GetListOfSecretes(t.ID)
+		if errList != nil {
+			return nil, backupReport{}, errList
+		}
+
+		for _, item := range list {
+			// deleted secrets are skipped by sync, zeroed in memory storage and flagged by server
+			if item.Id == 0 || item.IsDelited {
+				continue
+			}
+
+			secret, errSecret := e.app.SecretService.StoredSecret(int(item.Id))
+			if errSecret != nil {
+				return nil, backupReport{}, fmt.Errorf("error in exporting secret %d: %w", item.Id, errSecret)
+			}
+
+			secrets = append(secrets, secret)
+			report.Types[t.Title]++
+		}
+	}
+
+	return secrets, report, nil
+}
+
+// secretsBackup - returns backup of secrets created now.
+func secretsBackup(secrets []secretModel.StoredSecret) backup.Backup {
+	b := backup.Backup{CreatedAt: time.Now().UTC(), Secrets: make([]backup.Secret, 0, len(secrets))}
+
+	for _, secret := range secrets {
+		b.Secrets = append(b.Secrets, backup.Secret{
+			Title:           secret.Title,
+			Type:            secret.Type,
+			Content:         []byte(secret.Content),
+			CreatedAt:       secret.CreatedAt,
+			UpdatedAt:       secret.UpdatedAt,
+			ExpiresAt:       secret.Expiry.ExpiresAt,
+			RotateEveryDays: secret.Expiry.RotateEveryDays,
+			AutoExpire:      secret.Expiry.AutoExpire,
+		})
+	}
+
+	return b
+}
+
+// importBackup - is executor for "import-backup" case in Execute method.
+//
+// Secrets are restored to active vault, or as personal ones, with their titles, types, timestamps and expiry, so the
+// backup may be restored to another account. With --verify backup is only decrypted and checked.
+func (e *Executor) importBackup(in input) (backupReport, error) {
+	data, err := os.ReadFile(in.str("path"))
+	if err != nil {
+		return backupReport{}, &commandError{msg: fmt.Sprintf("error: %v", err), code: codes.NotFound}
+	}
+
+	b, errDecrypt := backup.Decrypt(data, in.str("passphrase"))
+	if errDecrypt != nil {
+		return backupReport{}, validationError("%v", errDecrypt)
+	}
+
+	types, errTypes := e.cachedTypes()
+	if errTypes != nil {
+		return backupReport{}, errTypes
+	}
+
+	names := make(map[int]string, len(types))
+	for _, t := range types {
+		names[t.ID] = t.Title
+	}
+
+	report := backupReport{CreatedAt: b.CreatedAt, Types: make(map[string]int)}
+	secrets := make([]secretModel.NewSecret, 0, len(b.Secrets))
+
+	for i, s := range b.Secrets {
+		name, ok := names[s.Type]
+		if !ok {
+			return backupReport{}, validationError("secret %d of backup has unknown type %d", i+1, s.Type)
+		}
+
+		if s.Title == "" || s.CreatedAt.IsZero() || s.UpdatedAt.Before(s.CreatedAt) {
+			return backupReport{}, validationError("secret %d of backup is malformed", i+1)
+		}
+
+		report.Types[name]++
+		secrets = append(secrets, secretModel.NewSecret{
+			Title:   s.Title,
+			Type:    s.Type,
+			Content: string(s.Content),
+			Expiry: secretModel.Expiry{
+				ExpiresAt:       s.ExpiresAt,
+				RotateEveryDays: s.RotateEveryDays,
+				AutoExpire:      s.AutoExpire,
+			},
+			CreatedAt: s.CreatedAt,
+			UpdatedAt: s.UpdatedAt,
+		})
+	}
+
+	if in.flag("verify") {
+		report.Message = fmt.Sprintf("backup is intact, %d secrets can be restored", len(secrets))
+
+		return report, nil
+	}
+
+	if len(secrets) == 0 {
+		return backupReport{}, validationError("backup has no secrets")
+	}
+
+	restored, errCreate := e.app.SecretService.CreateSecrets(secrets)
+	if errCreate != nil {
+		if restored > 0 {
+			fmt.Fprintf(os.Stderr, "%d of %d secrets are restored before error\n", restored, len(secrets))
+		}
+
+		return backupReport{}, errCreate
+	}
+
+	report.Message = fmt.Sprintf("%d secrets are restored", restored)
+
+	return report, nil
+}
@@ -0,0 +1,190 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"golang.org/x/term"
+	"google.golang.org/grpc/codes"
+
+	"secretKeeper/internal/client/prompt/output"
+	"secretKeeper/pkg/osc52"
+)
+
+// clipboardJob - is name of background job clearing clipboard in REPL.
+const clipboardJob = "clipboard"
+
+// copiedFields - are fields copied when "copy" names none, in order of preference.
+var copiedFields = []string{"Password", "Code", "CardNumber", "Text", "PrivateKey"}
+
+// maskedFields - are fields "get-secret" masks without --reveal, secrets themselves rather than their labels.
+var maskedFields = map[string]bool{
+	"Password": true, "CVV": true, "CardNumber": true, "Text": true, "Seed": true, "PrivateKey": true,
+}
+
+// mask - replaces masked value, its length isn't revealed either.
+const mask = "********"
+
+// copySecret - is executor for "copy" case in Execute method.
+//
+// Value is put on clipboard of terminal by OSC 52 and clipboard is cleared after --timeout seconds, or
+// SECRETKEEPER_CLIPBOARD_TIMEOUT ones. REPL clears it in background, subcommand mode waits until it's cleared, Ctrl+C
+// clears it at once.
+func (e *Executor) copySecret(in input) (output.Message, error) {
+	timeout := e.app.Config.ClipboardTimeout
+	if in.option("timeout") != "" {
+		timeout = in.optionInt("timeout")
+	}
+
+	if timeout < 0 {
+		return output.Message{}, validationError("--timeout must not be negative")
+	}
+
+	secret, err := e.secretResult(in.int("id"))
+	if err != nil {
+		return output.Message{}, err
+	}
+
+	name, value, errField := copiedField(secret, in.str("field"))
+	if errField != nil {
+		return output.Message{}, errField
+	}
+
+	// empty value would clear clipboard instead
+	if value == "" {
+		return output.Message{}, validationError("%s of secret %d is empty", name, in.int("id"))
+	}
+
+	// previous value is cleared by its job, so the job doesn't clear the new one later
+	e.stopJob(clipboardJob)
+
+	if errCopy := writeClipboard([]byte(value)); errCopy != nil {
+		return output.Message{}, errCopy
+	}
+
+	copied := fmt.Sprintf("%s of secret %d is copied to clipboard", name, in.int("id"))
+
+	switch {
+	case timeout == 0:
+		return output.Message{Message: copied}, nil
+	case e.interactive:
+		errStart := e.startBackground(clipboardJob, func(ctx context.Context) error {
+			// exit of REPL clears clipboard at once
+			select {
+			case <-ctx.Done():
+			case <-time.After(time.Duration(timeout) * time.Second):
+			}
+
+			return writeClipboard(nil)
+		})
+		if errStart != nil {
+			return output.Message{}, errStart
+		}
+
+		return output.Message{Message: fmt.Sprintf("%s, it is cleared in %ds", copied, timeout)}, nil
+	default:
+		fmt.Fprintf(os.Stderr, "%s, it is cleared in %ds, press Ctrl+C to clear it now\n", copied, timeout)
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(time.Duration(timeout) * time.Second):
+		}
+
+		if errClear := writeClipboard(nil); errClear != nil {
+			return output.Message{}, errClear
+		}
+
+		return output.Message{Message: "clipboard is cleared"}, nil
+	}
+}
+
+// copiedField - returns name and value of field of secret, or of the first of copiedFields it has, content of
+// secret without fields is copied as is.
+func copiedField(secret secretResult, field string) (string, string, error) {
+	if field != "" {
+		f, err := secretField(secret, field)
+
+		return f.Field, f.Value, err
+	}
+
+	if secret.Fields == nil {
+		return "content", secret.Content, nil
+	}
+
+	for _, name := range copiedFields {
+		if value, ok := secret.Fields[name]; ok {
+			return name, fmt.Sprint(value), nil
+		}
+	}
+
+	return "", "", validationError("secret has no field copied by default, name one of its fields")
+}
+
+// writeClipboard - sets clipboard of controlling terminal to value, empty value clears it.
+func writeClipboard(value []byte) error {
+	tty, err := os.OpenFile("/dev/tty", os.O_WRONLY, 0)
+	if err != nil {
+		// stdout is the terminal if there is no controlling one, e.g. on Windows
+		if !term.IsTerminal(int(os.Stdout.Fd())) {
+			return &commandError{msg: "error: copy needs a terminal", code: codes.FailedPrecondition}
+		}
+
+		return clipboardSequence(os.Stdout, value)
+	}
+	defer tty.Close()
+
+	return clipboardSequence(tty, value)
+}
+
+// clipboardSequence - writes OSC 52 sequence of value to terminal w.
+func clipboardSequence(w io.Writer, value []byte) error {
+	var err error
+	if len(value) == 0 {
+		err = osc52.Clear(w, os.Getenv)
+	} else {
+		err = osc52.Copy(w, value, os.Getenv)
+	}
+
+	if errors.Is(err, osc52.ErrTooLarge) {
+		return validationError("%v, its limit is %d bytes", err, osc52.MaxSize)
+	}
+
+	return err
+}
+
+// masked - returns secret with maskedFields replaced by mask, so get-secret leaves no secrets in scrollback.
+//
+// Content of secrets without fields, e.g. binary ones, is masked as a whole.
+func (s secretResult) masked() secretResult {
+	if s.Fields == nil {
+		if strings.TrimSpace(s.Content) != "" {
+			s.Content = mask
+		}
+
+		return s
+	}
+
+	fields := make(map[string]interface{}, len(s.Fields))
+
+	for name, value := range s.Fields {
+		if maskedFields[name] && strings.TrimSpace(fmt.Sprint(value)) != "" {
+			value = mask
+		}
+
+		fields[name] = value
+	}
+
+	s.Fields = fields
+
+	return s
+}
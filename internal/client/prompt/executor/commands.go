@@ -0,0 +1,681 @@
+package executor
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+
+	"secretKeeper/internal/client/prompt/output"
+	"secretKeeper/pkg/exporter"
+	"secretKeeper/pkg/importer"
+)
+
+// authMode - describes whether a command needs logged user.
+type authMode int
+
+const (
+	// authNone - command works without logged user.
+	authNone authMode = iota
+	// authOptional - command uses logged user if session is available.
+	authOptional
+	// authRequired - command fails in subcommand mode if session can't be restored.
+	authRequired
+)
+
+// Command - is a command of the client, which is shared by go-prompt REPL and subcommand mode.
+type Command struct {
+	Name        string
+	Description string
+
+	auth authMode
+	// args - are positional arguments of the command, validation messages are generated from them.
+	args []argSpec
+	// flags - are options of the command in addition to globalFlags.
+	flags []flagSpec
+	// sensitive - command line may carry secrets, so it isn't recorded in history of the prompt.
+	sensitive bool
+	// whileLocked - command needs no keys, so it runs while the vault is locked.
+	whileLocked bool
+	// session - command logs in or out, switches vault or profile, so background jobs wait until it's done.
+	session bool
+	run     func(e *Executor, in input) (interface{}, error)
+}
+
+// commands - is registry of all commands in order they are suggested.
+var commands []Command
+
+// init - fills registry of commands, it is done on init as help command lists the registry itself.
+func init() {
+	commands = []Command{
+		{
+			Name: "login", Description: "Authenticate user", auth: authNone, sensitive: true, session: true,
+			args: []argSpec{{name: "login", label: "Login"}, {name: "password", label: "Password", secret: true}},
+			run: func(e *Executor, in input) (interface{}, error) {
+				if err := e.login(in); err != nil {
+					return nil, err
+				}
+
+				result := loginResult{Message: "successfully authorized"}
+
+				// reminders are best effort, login is already done
+				if events, err := e.due(); err == nil {
+					result.Due = events
+				}
+
+				return result, nil
+			},
+		},
+		{
+			Name: "logout", Description: "Logout authenticated user", auth: authOptional, session: true,
+			run: func(e *Executor, in input) (interface{}, error) {
+				if err := e.logout(); err != nil {
+					return nil, err
+				}
+
+				return output.Message{Message: "you successfully logged out"}, nil
+			},
+		},
+		{
+			Name: "lock", Description: "Lock the vault, keys and decrypted secrets are dropped from memory",
+			auth: authRequired, whileLocked: true,
+			run: func(e *Executor, in input) (interface{}, error) {
+				if err := e.lock(); err != nil {
+					return nil, err
+				}
+
+				return output.Message{Message: "vault is locked"}, nil
+			},
+		},
+		{
+			Name: "unlock", Description: "Unlock the vault by password without login", auth: authRequired,
+			whileLocked: true, sensitive: true,
+			args: []argSpec{{name: "password", label: "Password", secret: true}},
+			run: func(e *Executor, in input) (interface{}, error) {
+				if err := e.unlock(in); err != nil {
+					return nil, err
+				}
+
+				return output.Message{Message: "vault is unlocked"}, nil
+			},
+		},
+		{
+			Name: "register", Description: "Register new user", auth: authNone, sensitive: true, session: true,
+			args: []argSpec{
+				{name: "login", label: "Login"},
+				{name: "password", label: "Password", secret: true, confirm: true},
+			},
+			run: func(e *Executor, in input) (interface{}, error) {
+				if err := e.register(in); err != nil {
+					return nil, err
+				}
+
+				return output.Message{Message: "User successfully created. You are logged in."}, nil
+			},
+		},
+		{
+			Name: "delete-user", Description: "Delete logged user", auth: authRequired, session: true,
+			run: func(e *Executor, in input) (interface{}, error) {
+				if err := e.deleteUser(); err != nil {
+					return nil, err
+				}
+
+				return output.Message{Message: "you successfully deleted account and logged out"}, nil
+			},
+		},
+		{
+			Name: "recovery", Description: "Split recovery key into shares or restore access with them", auth: authOptional,
+			sensitive: true, session: true,
+			args: []argSpec{
+				{name: "action", label: "Subcommand split or restore", choices: []string{"split", "restore"}},
+				{name: "args", label: "arguments of subcommand", optional: true, variadic: true},
+			},
+			run: func(e *Executor, in input) (interface{}, error) {
+				return e.recovery(in)
+			},
+		},
+		{
+			Name: "types", Description: "Get list of secret types available to be stored", auth: authRequired,
+			run: func(e *Executor, in input) (interface{}, error) {
+				return e.types()
+			},
+		},
+		{
+			Name: "create-auth", Description: "Create new login/pass secret", auth: authRequired, sensitive: true,
+			args: []argSpec{
+				{name: "title", label: "Title"},
+				{name: "login", label: "Login"},
+				{name: "password", label: "Password", secret: true, confirm: true, generated: true},
+			},
+			flags: append([]flagSpec{{name: "url", kind: kindString}, generateFlag}, generatorFlags...),
+			run: func(e *Executor, in input) (interface{}, error) {
+				id, err := e.createAuth(in)
+				if err != nil {
+					return nil, err
+				}
+
+				return warnedMessage{
+					ID:      id,
+					Message: e.generatedMessage(fmt.Sprint("created new secret with ID: ", id)),
+					Warning: e.breachWarning(in.str("password")),
+				}, nil
+			},
+		},
+		{
+			Name: "create-text", Description: "Create new text secret", auth: authRequired, sensitive: true,
+			args: []argSpec{{name: "title", label: "Title"}, {name: "text", label: "Text", variadic: true}},
+			run: func(e *Executor, in input) (interface{}, error) {
+				return secretCreated(e.createText(in))
+			},
+		},
+		{
+			Name: "create-binary", Description: "Create new binary secret", auth: authRequired,
+			args: []argSpec{{name: "title", label: "Title"}, {name: "path", label: "Filepath", complete: completePath}},
+			run: func(e *Executor, in input) (interface{}, error) {
+				return secretCreated(e.createBinary(in))
+			},
+		},
+		{
+			Name: "create-card", Description: "Create new card secret", auth: authRequired, sensitive: true,
+			args: []argSpec{
+				{name: "title", label: "Title"},
+				{name: "number", label: "Card number"},
+				{name: "cvv", label: "CVV", secret: true, confirm: true},
+				{name: "due", label: "Due date"},
+			},
+			run: func(e *Executor, in input) (interface{}, error) {
+				return secretCreated(e.createCard(in))
+			},
+		},
+		{
+			Name: "generate", Description: "Generate password or passphrase, optionally saving it to login/pass secret",
+			auth: authOptional,
+			flags: append([]flagSpec{
+				{name: "save", kind: kindInt},
+				{name: "create", kind: kindString},
+				{name: "login", kind: kindString},
+				{name: "url", kind: kindString},
+			}, generatorFlags...),
+			run: func(e *Executor, in input) (interface{}, error) {
+				return e.generate(in)
+			},
+		},
+		{
+			Name: "create-totp", Description: "Create new TOTP secret from base32 seed or otpauth URI", auth: authRequired,
+			sensitive: true,
+			args:      []argSpec{{name: "title", label: "Title"}, {name: "seed", label: "Seed or otpauth URI", secret: true}},
+			flags: []flagSpec{
+				{name: "digits", kind: kindInt},
+				{name: "period", kind: kindInt},
+				{name: "algorithm", kind: kindString, choices: []string{"SHA1", "SHA256", "SHA512"}},
+			},
+			run: func(e *Executor, in input) (interface{}, error) {
+				return secretCreated(e.createTOTP(in))
+			},
+		},
+		{
+			Name: "generate-ssh-key", Description: "Generate SSH key and store it as secret", auth: authRequired,
+			args: []argSpec{{name: "title", label: "Title"}},
+			flags: []flagSpec{
+				{name: "type", kind: kindString, choices: []string{"ed25519", "rsa", "ecdsa"}},
+				{name: "bits", kind: kindInt},
+				{name: "comment", kind: kindString},
+				{name: "confirm", kind: kindBool},
+			},
+			run: func(e *Executor, in input) (interface{}, error) {
+				return e.generateSSHKey(in)
+			},
+		},
+		{
+			Name: "import-ssh-key", Description: "Import SSH private key file, encrypted keys ask for passphrase",
+			auth: authRequired,
+			args: []argSpec{{name: "title", label: "Title"}, {name: "path", label: "Filepath", complete: completePath}},
+			flags: []flagSpec{
+				{name: "comment", kind: kindString},
+				{name: "confirm", kind: kindBool},
+			},
+			run: func(e *Executor, in input) (interface{}, error) {
+				return e.importSSHKey(in)
+			},
+		},
+		{
+			Name: "ssh-agent", Description: "Serve SSH keys of the vault over ssh-agent socket", auth: authRequired,
+			flags: []flagSpec{
+				{name: "socket", kind: kindString},
+				{name: "confirm", kind: kindBool},
+			},
+			run: func(e *Executor, in input) (interface{}, error) {
+				return e.sshAgent(in)
+			},
+		},
+		{
+			Name: "get-secret", Description: "Retrieve stored secret", auth: authRequired,
+			args:  []argSpec{{name: "id", label: "Secret ID", kind: kindInt, complete: completeSecret}},
+			flags: []flagSpec{{name: "field", kind: kindString}, {name: "reveal", kind: kindBool}},
+			run: func(e *Executor, in input) (interface{}, error) {
+				secret, err := e.getSecret(in)
+				if err != nil {
+					return nil, err
+				}
+
+				// the field is asked for explicitly, so it's printed as is for piping
+				if field := in.option("field"); field != "" {
+					return secretField(secret, field)
+				}
+
+				if !in.flag("reveal") {
+					return secret.masked(), nil
+				}
+
+				return secret, nil
+			},
+		},
+		{
+			Name: "copy", Description: "Copy field of secret to clipboard of terminal and clear it after timeout",
+			auth: authRequired,
+			args: []argSpec{
+				{name: "id", label: "Secret ID", kind: kindInt, complete: completeSecret},
+				{name: "field", label: "Field", optional: true},
+			},
+			flags: []flagSpec{{name: "timeout", kind: kindInt}},
+			run: func(e *Executor, in input) (interface{}, error) {
+				return e.copySecret(in)
+			},
+		},
+		{
+			Name: "run", Description: "Run command with secrets injected as environment variables", auth: authRequired,
+			args:  []argSpec{{name: "command", label: "Command", variadic: true, complete: completePath}},
+			flags: []flagSpec{{name: "env", short: "e", kind: kindString, repeated: true}},
+			run: func(e *Executor, in input) (interface{}, error) {
+				return nil, e.runCommand(in)
+			},
+		},
+		{
+			Name: "inject", Description: "Render template with secret references to file", auth: authRequired,
+			args: []argSpec{
+				{name: "template", label: "Template path", complete: completePath},
+				{name: "out", label: "Output path", complete: completePath},
+			},
+			flags: []flagSpec{{name: "mode", kind: kindString}, {name: "watch", short: "w", kind: kindBool}},
+			run: func(e *Executor, in input) (interface{}, error) {
+				return e.inject(in)
+			},
+		},
+		{
+			Name: "git-credential", Description: "Git credential helper backed by login/pass secrets", auth: authRequired,
+			args: []argSpec{{name: "action", label: "Action", choices: []string{"get", "store", "erase"}}},
+			run: func(e *Executor, in input) (interface{}, error) {
+				return e.gitCredentialHelper(in)
+			},
+		},
+		{
+			Name: "import", Description: "Import secrets from export of Bitwarden, KeePass, 1Password or CSV file",
+			auth: authRequired,
+			args: []argSpec{
+				{name: "format", label: "Format", choices: importer.Formats},
+				{name: "path", label: "Export path", complete: completePath},
+			},
+			flags: []flagSpec{
+				{name: "dry-run", kind: kindBool},
+				{name: "map", kind: kindString, repeated: true},
+				{name: "key-file", kind: kindString},
+			},
+			run: func(e *Executor, in input) (interface{}, error) {
+				return e.importSecrets(in)
+			},
+		},
+		{
+			Name: "export", Description: "Export every secret to backup encrypted by export passphrase",
+			auth: authRequired, sensitive: true,
+			args: []argSpec{
+				{name: "path", label: "Backup path", complete: completePath},
+				{name: "passphrase", label: "Export passphrase", secret: true, confirm: true},
+			},
+			run: func(e *Executor, in input) (interface{}, error) {
+				return e.exportBackup(in)
+			},
+		},
+		{
+			Name: "export-as", Description: "Export every secret to age, Bitwarden JSON or KeePass XML file",
+			auth: authRequired, sensitive: true,
+			args: []argSpec{
+				{name: "format", label: "Format", choices: exporter.Formats},
+				{name: "path", label: "Export path", complete: completePath},
+			},
+			flags: []flagSpec{
+				{name: "recipient", short: "r", kind: kindString, repeated: true},
+				{name: "armor", short: "a", kind: kindBool},
+				{name: "i-understand", kind: kindBool},
+			},
+			run: func(e *Executor, in input) (interface{}, error) {
+				return e.exportAs(in)
+			},
+		},
+		{
+			Name: "import-backup", Description: "Restore secrets from backup, --verify only checks it",
+			auth: authRequired, sensitive: true,
+			args: []argSpec{
+				{name: "path", label: "Backup path", complete: completePath},
+				{name: "passphrase", label: "Export passphrase", secret: true},
+			},
+			flags: []flagSpec{{name: "verify", kind: kindBool}},
+			run: func(e *Executor, in input) (interface{}, error) {
+				return e.importBackup(in)
+			},
+		},
+		{
+			Name: "get-secret-binary", Description: "Retrieve stored binary secret", auth: authRequired,
+			args: []argSpec{
+				{name: "id", label: "Secret ID", kind: kindInt, complete: completeSecret},
+				{name: "path", label: "Path", complete: completePath},
+			},
+			run: func(e *Executor, in input) (interface{}, error) {
+				if err := e.getSecretBinary(in); err != nil {
+					return nil, err
+				}
+
+				return output.Message{Message: "binary secret is saved to " + in.str("path")}, nil
+			},
+		},
+		{
+			Name: "delete-secret", Description: "Delete stored secret", auth: authRequired,
+			args: []argSpec{{name: "id", label: "Secret ID", kind: kindInt, complete: completeSecret}},
+			run: func(e *Executor, in input) (interface{}, error) {
+				if err := e.deleteSecret(in); err != nil {
+					return nil, err
+				}
+
+				return output.Message{Message: "secret is deleted"}, nil
+			},
+		},
+		{
+			Name: "edit-secret", Description: "Edit stored secret", auth: authRequired, sensitive: true,
+			args: []argSpec{
+				{name: "id", label: "Secret ID", kind: kindInt, complete: completeSecret},
+				{name: "title", label: "Title"},
+				{name: "type", label: "Secret Type ID", kind: kindInt},
+				{name: "fields", label: "secret fields", variadic: true},
+			},
+			flags: append([]flagSpec{
+				{name: "force", short: "f", kind: kindBool},
+				{name: "url", kind: kindString},
+				generateFlag,
+			}, generatorFlags...),
+			run: func(e *Executor, in input) (interface{}, error) {
+				if err := e.editSecret(in, in.flag("force")); err != nil {
+					return nil, err
+				}
+
+				return output.Message{Message: e.generatedMessage("secret is updated")}, nil
+			},
+		},
+		{
+			Name: "set-expiry", Description: "Set expiry date and rotation period of a secret", auth: authRequired,
+			args: []argSpec{
+				{name: "id", label: "Secret ID", kind: kindInt, complete: completeSecret},
+				{name: "date", label: "Expiry date"},
+				{name: "days", label: "Rotation period", kind: kindInt, optional: true},
+			},
+			flags: []flagSpec{{name: "auto-expire", kind: kindBool}},
+			run: func(e *Executor, in input) (interface{}, error) {
+				if err := e.setExpiry(in, in.flag("auto-expire")); err != nil {
+					return nil, err
+				}
+
+				return output.Message{Message: "expiry of secret is updated"}, nil
+			},
+		},
+		{
+			Name: "due", Description: "List secrets which expire soon or need rotation", auth: authRequired,
+			run: func(e *Executor, in input) (interface{}, error) {
+				return e.due()
+			},
+		},
+		{
+			Name: "audit-passwords", Description: "Report weak, reused and old passwords of login/pass secrets",
+			auth:  authRequired,
+			flags: []flagSpec{{name: "max-age", kind: kindInt}, {name: "no-breach", kind: kindBool}},
+			run: func(e *Executor, in input) (interface{}, error) {
+				return e.auditPasswords(in)
+			},
+		},
+		{
+			Name: "send", Description: "Share text with anyone via one-time token", auth: authRequired, sensitive: true,
+			args: []argSpec{
+				{name: "ttl", label: "Time to live in minutes", kind: kindInt},
+				{name: "views", label: "Views", kind: kindInt},
+				{name: "text", label: "Text", variadic: true},
+			},
+			run: func(e *Executor, in input) (interface{}, error) {
+				return e.send(in)
+			},
+		},
+		{
+			Name: "open-send", Description: "Open one-time token, no login required", auth: authNone, sensitive: true,
+			args: []argSpec{{name: "token", label: "Token"}},
+			run: func(e *Executor, in input) (interface{}, error) {
+				return e.openSend(in)
+			},
+		},
+		{
+			Name: "get-secrets-by-type", Description: "Retrieves list of secretes by their type", auth: authRequired,
+			args: []argSpec{{name: "type", label: "Secret type", complete: completeSecretType}},
+			run: func(e *Executor, in input) (interface{}, error) {
+				return e.getSecretsByTypeId(in)
+			},
+		},
+		{
+			Name: "create-org", Description: "Create new organization", auth: authRequired,
+			args: []argSpec{{name: "title", label: "Title"}},
+			run: func(e *Executor, in input) (interface{}, error) {
+				return e.createOrg(in)
+			},
+		},
+		{
+			Name: "orgs", Description: "Get list of your organizations", auth: authRequired,
+			run: func(e *Executor, in input) (interface{}, error) {
+				return e.orgs()
+			},
+		},
+		{
+			Name: "org-members", Description: "Get list of organization members", auth: authRequired,
+			args: []argSpec{{name: "org", label: "Organization ID", kind: kindInt}},
+			run: func(e *Executor, in input) (interface{}, error) {
+				return e.orgMembers(in)
+			},
+		},
+		{
+			Name: "add-member", Description: "Add user to organization and rotate vault keys", auth: authRequired,
+			args: []argSpec{
+				{name: "org", label: "Organization ID", kind: kindInt},
+				{name: "login", label: "Login"},
+				{name: "role", label: "Role"},
+			},
+			run: func(e *Executor, in input) (interface{}, error) {
+				if err := e.addMember(in); err != nil {
+					return nil, err
+				}
+
+				return output.Message{Message: "member added, vault keys rotated"}, nil
+			},
+		},
+		{
+			Name: "remove-member", Description: "Remove user from organization and rotate vault keys", auth: authRequired,
+			args: []argSpec{{name: "org", label: "Organization ID", kind: kindInt}, {name: "login", label: "Login"}},
+			run: func(e *Executor, in input) (interface{}, error) {
+				if err := e.removeMember(in); err != nil {
+					return nil, err
+				}
+
+				return output.Message{Message: "member removed, vault keys rotated"}, nil
+			},
+		},
+		{
+			Name: "create-vault", Description: "Create new organization vault", auth: authRequired,
+			args: []argSpec{{name: "org", label: "Organization ID", kind: kindInt}, {name: "title", label: "Title"}},
+			run: func(e *Executor, in input) (interface{}, error) {
+				return e.createVault(in)
+			},
+		},
+		{
+			Name: "vaults", Description: "Get list of organization vaults", auth: authRequired,
+			args: []argSpec{{name: "org", label: "Organization ID", kind: kindInt}},
+			run: func(e *Executor, in input) (interface{}, error) {
+				return e.vaults(in)
+			},
+		},
+		{
+			Name: "use-vault", Description: "Switch secret commands to vault, 0 switches to personal secrets",
+			auth: authRequired, session: true,
+			args: []argSpec{{name: "vault", label: "Vault ID", kind: kindInt}},
+			run: func(e *Executor, in input) (interface{}, error) {
+				return e.useVault(in)
+			},
+		},
+		{
+			Name: "add-contact", Description: "Designate trusted contact for emergency access", auth: authRequired,
+			args: []argSpec{
+				{name: "login", label: "Login"},
+				{name: "hours", label: "Waiting period in hours", kind: kindInt},
+			},
+			run: func(e *Executor, in input) (interface{}, error) {
+				return e.addContact(in)
+			},
+		},
+		{
+			Name: "remove-contact", Description: "Remove trusted contact", auth: authRequired,
+			args: []argSpec{{name: "login", label: "Login"}},
+			run: func(e *Executor, in input) (interface{}, error) {
+				if err := e.removeContact(in); err != nil {
+					return nil, err
+				}
+
+				return output.Message{Message: "trusted contact removed"}, nil
+			},
+		},
+		{
+			Name: "contacts", Description: "Get list of your trusted contacts", auth: authRequired,
+			run: func(e *Executor, in input) (interface{}, error) {
+				return e.contacts()
+			},
+		},
+		{
+			Name: "grantors", Description: "Get list of users who trust you", auth: authRequired,
+			run: func(e *Executor, in input) (interface{}, error) {
+				return e.grantors()
+			},
+		},
+		{
+			Name: "request-access", Description: "Request emergency access to secrets of another user", auth: authRequired,
+			args: []argSpec{{name: "owner", label: "Owner login"}},
+			run: func(e *Executor, in input) (interface{}, error) {
+				return e.requestAccess(in)
+			},
+		},
+		{
+			Name: "deny-access", Description: "Deny emergency access requested by your trusted contact", auth: authRequired,
+			args: []argSpec{{name: "login", label: "Login"}},
+			run: func(e *Executor, in input) (interface{}, error) {
+				if err := e.denyAccess(in); err != nil {
+					return nil, err
+				}
+
+				return output.Message{Message: "emergency access denied"}, nil
+			},
+		},
+		{
+			Name: "emergency-secrets", Description: "Retrieve secrets of another user after access is granted",
+			auth: authRequired,
+			args: []argSpec{{name: "owner", label: "Owner login"}},
+			run: func(e *Executor, in input) (interface{}, error) {
+				return e.emergencySecrets(in)
+			},
+		},
+		{
+			Name: "profile", Description: "List profiles of config file or switch current one", auth: authNone,
+			session: true,
+			args: []argSpec{
+				{name: "action", label: "Subcommand list or use", choices: []string{"list", "use"}},
+				{name: "name", label: "Profile", optional: true},
+			},
+			run: func(e *Executor, in input) (interface{}, error) {
+				result, err := e.profile(in)
+				if err != nil || result != nil {
+					return result, err
+				}
+
+				if e.interactive {
+					return output.Message{Message: "profile " + in.str("name") + " is in use, please login"}, nil
+				}
+
+				return output.Message{Message: "profile " + in.str("name") + " is current"}, nil
+			},
+		},
+		{
+			Name: "help", Description: "Show available commands", auth: authNone,
+			run: func(e *Executor, in input) (interface{}, error) {
+				items := make([]commandItem, 0, len(commands))
+				for _, cmd := range Commands() {
+					items = append(items, commandItem{Name: cmd.Name, Usage: usage(cmd), Description: cmd.Description})
+				}
+
+				return items, nil
+			},
+		},
+		{
+			Name: "exit", Description: "Exit program", auth: authNone,
+			run: func(e *Executor, in input) (interface{}, error) {
+				e.write(output.Message{Message: "bye bye...application is closing"}, nil)
+
+				e.stopBackground()
+				e.app.Cancel()
+				e.app.Cron.Stop()
+
+				os.Exit(ExitOK)
+
+				return nil, nil
+			},
+		},
+	}
+}
+
+// Commands - returns registry of all commands in order they are suggested.
+func Commands() []Command {
+	return commands
+}
+
+// lookupCommand - finds command in registry by name.
+func lookupCommand(name string) (Command, bool) {
+	for _, cmd := range commands {
+		if cmd.Name == name {
+			return cmd, true
+		}
+	}
+
+	return Command{}, false
+}
+
+// secretCreated - returns result of commands which create a secret.
+func secretCreated(id uint32, err error) (interface{}, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	return createdResult{ID: id, Message: fmt.Sprint("created new secret with ID: ", id)}, nil
+}
+
+// secretField - returns value of a field of decoded secret, field name is case-insensitive.
+func secretField(secret secretResult, field string) (fieldResult, error) {
+	if secret.Fields == nil {
+		return fieldResult{}, validationError("secret has no fields, omit --field")
+	}
+
+	for name, value := range secret.Fields {
+		if strings.EqualFold(name, field) {
+			return fieldResult{Field: name, Value: fmt.Sprint(value)}, nil
+		}
+	}
+
+	return fieldResult{}, &commandError{msg: fmt.Sprintf("error: secret has no field %s", field), code: codes.NotFound}
+}
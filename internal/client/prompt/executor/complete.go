@@ -0,0 +1,270 @@
+package executor
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// completion - is a source of suggestions for an argument.
+type completion int
+
+const (
+	completeNone completion = iota
+	// completeSecret - suggests IDs of secrets from memory storage annotated with their titles.
+	completeSecret
+	// completeSecretType - suggests names of secret types.
+	completeSecretType
+	// completePath - suggests files and directories.
+	completePath
+)
+
+// Suggestion - is a candidate for the word being typed in REPL.
+type Suggestion struct {
+	Text        string
+	Description string
+}
+
+// Suggest - returns suggestions for the last word of command line typed in REPL.
+//
+// The first word is completed with names of commands, words starting with a dash with options of the command, and
+// other words by declaration of the argument they are bound to.
+func (e *Executor) Suggest(line string) []Suggestion {
+	tokens, err := tokenize(line)
+	if err != nil {
+		return nil
+	}
+
+	var word string
+	if len(tokens) > 0 && !strings.HasSuffix(line, " ") && !strings.HasSuffix(line, "\t") {
+		word, tokens = tokens[len(tokens)-1], tokens[:len(tokens)-1]
+	}
+
+	name, rest := splitCommand(tokens)
+
+	flags := globalFlags
+	cmd, ok := lookupCommand(name)
+	if ok {
+		flags = append(append([]flagSpec{}, globalFlags...), cmd.flags...)
+	}
+
+	if len(rest) > 0 {
+		last := rest[len(rest)-1]
+		if spec, found := lookupFlag(flags, optionName(last)); found && isOption(last) && spec.kind != kindBool &&
+			!strings.Contains(last, "=") {
+			return filterSuggestions(choiceSuggestions(spec.choices), word)
+		}
+	}
+
+	switch {
+	case name == "" && !strings.HasPrefix(word, "-"):
+		return filterSuggestions(commandSuggestions(), word)
+	case strings.HasPrefix(word, "-"):
+		return filterSuggestions(flagSuggestions(flags, rest), word)
+	case !ok:
+		return nil
+	}
+
+	spec, found := argAt(cmd.args, positionalCount(flags, rest))
+	if !found {
+		return nil
+	}
+
+	switch spec.complete {
+	case completeSecret:
+		return e.secretSuggestions(word)
+	case completeSecretType:
+		return filterSuggestions(e.secretTypeSuggestions(), word)
+	case completePath:
+		return pathSuggestions(word)
+	default:
+		return filterSuggestions(choiceSuggestions(spec.choices), word)
+	}
+}
+
+// positionalCount - returns count of positional arguments among tokens.
+func positionalCount(flags []flagSpec, tokens []string) int {
+	var count int
+
+	for i := 0; i < len(tokens); i++ {
+		if tokens[i] == "--" {
+			return count + len(tokens) - i - 1
+		}
+
+		if !isOption(tokens[i]) {
+			count++
+
+			continue
+		}
+
+		if spec, ok := lookupFlag(flags, optionName(tokens[i])); ok && spec.kind != kindBool &&
+			!strings.Contains(tokens[i], "=") {
+			i++
+		}
+	}
+
+	return count
+}
+
+// argAt - returns declaration of positional argument at index, variadic argument takes all remaining indexes.
+func argAt(specs []argSpec, index int) (argSpec, bool) {
+	if index < len(specs) {
+		return specs[index], true
+	}
+
+	if len(specs) > 0 && specs[len(specs)-1].variadic {
+		return specs[len(specs)-1], true
+	}
+
+	return argSpec{}, false
+}
+
+// commandSuggestions - returns names of all commands.
+func commandSuggestions() []Suggestion {
+	suggestions := make([]Suggestion, 0, len(commands))
+	for _, cmd := range commands {
+		suggestions = append(suggestions, Suggestion{Text: cmd.Name, Description: cmd.Description})
+	}
+
+	return suggestions
+}
+
+// flagSuggestions - returns options which are not set among tokens yet.
+func flagSuggestions(flags []flagSpec, tokens []string) []Suggestion {
+	used := make(map[string]bool, len(tokens))
+	for _, token := range tokens {
+		if spec, ok := lookupFlag(flags, optionName(token)); ok && isOption(token) {
+			used[spec.name] = true
+		}
+	}
+
+	suggestions := make([]Suggestion, 0, len(flags))
+	for _, spec := range flags {
+		if used[spec.name] {
+			continue
+		}
+
+		var description string
+		if spec.short != "" {
+			description = "-" + spec.short
+		}
+
+		suggestions = append(suggestions, Suggestion{Text: "--" + spec.name, Description: description})
+	}
+
+	return suggestions
+}
+
+// choiceSuggestions - returns fixed values of an argument or option.
+func choiceSuggestions(choices []string) []Suggestion {
+	suggestions := make([]Suggestion, 0, len(choices))
+	for _, choice := range choices {
+		suggestions = append(suggestions, Suggestion{Text: choice})
+	}
+
+	return suggestions
+}
+
+// secretSuggestions - returns IDs of secrets from memory storage, word is matched against ID or title.
+func (e *Executor) secretSuggestions(word string) []Suggestion {
+	var suggestions []Suggestion
+
+	lower := strings.ToLower(word)
+
+	// only these types are kept in memory storage
+	for _, typeID := range []int{1, 2, 4} {
+		for _, secret := range e.app.Storage.GetSecretList(typeID) {
+			// deleted secrets are zeroed in memory storage
+			if secret.Id == 0 {
+				continue
+			}
+
+			id := strconv.Itoa(int(secret.Id))
+			if !strings.HasPrefix(id, word) && !strings.Contains(strings.ToLower(secret.Title), lower) {
+				continue
+			}
+
+			suggestions = append(suggestions, Suggestion{Text: id, Description: secret.Title})
+		}
+	}
+
+	sort.Slice(suggestions, func(i, j int) bool {
+		a, _ := strconv.Atoi(suggestions[i].Text)
+		b, _ := strconv.Atoi(suggestions[j].Text)
+
+		return a < b
+	})
+
+	return suggestions
+}
+
+// secretTypeSuggestions - returns names of secret types, nothing is suggested until user is logged in.
+func (e *Executor) secretTypeSuggestions() []Suggestion {
+	if !e.app.UserService.IsLogged() {
+		return nil
+	}
+
+	types, err := e.cachedTypes()
+	if err != nil {
+		return nil
+	}
+
+	suggestions := make([]Suggestion, 0, len(types))
+	for _, t := range types {
+		suggestions = append(suggestions, Suggestion{Text: t.Title, Description: "type " + strconv.Itoa(t.ID)})
+	}
+
+	return suggestions
+}
+
+// pathSuggestions - returns entries of directory typed in word, directories end with separator.
+//
+// Hidden entries are suggested only if their name is started in word.
+func pathSuggestions(word string) []Suggestion {
+	dir, base := filepath.Split(word)
+
+	readDir := dir
+	if readDir == "" {
+		readDir = "."
+	}
+
+	entries, err := os.ReadDir(readDir)
+	if err != nil {
+		return nil
+	}
+
+	var suggestions []Suggestion
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if !strings.HasPrefix(name, base) || (strings.HasPrefix(name, ".") && !strings.HasPrefix(base, ".")) {
+			continue
+		}
+
+		suggestion := Suggestion{Text: dir + name}
+		if entry.IsDir() {
+			suggestion.Text += string(filepath.Separator)
+			suggestion.Description = "directory"
+		}
+
+		suggestions = append(suggestions, suggestion)
+	}
+
+	return suggestions
+}
+
+// filterSuggestions - returns suggestions which start with word, case is ignored.
+func filterSuggestions(suggestions []Suggestion, word string) []Suggestion {
+	lower := strings.ToLower(word)
+
+	filtered := make([]Suggestion, 0, len(suggestions))
+	for _, suggestion := range suggestions {
+		if strings.HasPrefix(strings.ToLower(suggestion.Text), lower) {
+			filtered = append(filtered, suggestion)
+		}
+	}
+
+	return filtered
+}
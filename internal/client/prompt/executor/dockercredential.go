@@ -0,0 +1,153 @@
+package executor
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	secretModel "secretKeeper/internal/client/model/secret"
+)
+
+// errDockerNotFound - is message docker expects from helper for missing credentials, it isn't a failure.
+var errDockerNotFound = errors.New("credentials not found in native keychain")
+
+// dockerCredential - is a credential of docker credential helper protocol.
+type dockerCredential struct {
+	ServerURL string
+	Username  string
+	Secret    string
+}
+
+// DockerCredentialHelper - serves action of docker credential helper protocol, request is read from r and response
+// is written to w.
+//
+// Registry credentials are login/pass secrets of active vault whose URL is server URL of the registry, docker keeps one
+// credential per server URL.
+func (e *Executor) DockerCredentialHelper(action string, r io.Reader, w io.Writer) error {
+	if err := e.loadApp(""); err != nil {
+		return err
+	}
+
+	if err := e.restoreSession(); err != nil {
+		return err
+	}
+
+	if err := e.unlockSession(); err != nil {
+		return err
+	}
+
+	switch action {
+	case "get":
+		serverURL, err := readServerURL(r)
+		if err != nil {
+			return err
+		}
+
+		secret, found, errFind := e.dockerSecret(serverURL)
+		if errFind != nil {
+			return errFind
+		}
+
+		if !found {
+			return errDockerNotFound
+		}
+
+		return json.NewEncoder(w).Encode(dockerCredential{
+			ServerURL: serverURL,
+			Username:  secret.Login,
+			Secret:    secret.Password,
+		})
+	case "store":
+		var credential dockerCredential
+		if err := json.NewDecoder(r).Decode(&credential); err != nil {
+			return fmt.Errorf("error in decoding credential: %w", err)
+		}
+
+		if credential.ServerURL == "" {
+			return errors.New("no credentials server URL")
+		}
+
+		secret, found, errFind := e.dockerSecret(credential.ServerURL)
+		if errFind != nil {
+			return errFind
+		}
+
+		if !found {
+			_, errCreate := e.createLoginPass(credential.ServerURL, credential.Username, credential.Secret, credential.ServerURL)
+
+			return errCreate
+		}
+
+		if secret.Login == credential.Username && secret.Password == credential.Secret {
+			return nil
+		}
+
+		return e.saveCredential(secret.Id, credential.Username, credential.Secret)
+	case "erase":
+		serverURL, err := readServerURL(r)
+		if err != nil {
+			return err
+		}
+
+		secret, found, errFind := e.dockerSecret(serverURL)
+		if errFind != nil || !found {
+			return errFind
+		}
+
+		return e.app.SecretService.DeleteSecret(secret.Id)
+	case "list":
+		secrets, err := e.loginPassSecrets()
+		if err != nil {
+			return err
+		}
+
+		registries := make(map[string]string)
+		for _, secret := range secrets {
+			if secret.URL != "" {
+				registries[secret.URL] = secret.Login
+			}
+		}
+
+		return json.NewEncoder(w).Encode(registries)
+	default:
+		return fmt.Errorf("unknown credential action %q, must be get, store, erase or list", action)
+	}
+}
+
+// readServerURL - reads server URL docker passes as plain text.
+func readServerURL(r io.Reader) (string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return "", fmt.Errorf("error in reading server URL: %w", err)
+	}
+
+	serverURL := strings.TrimSpace(string(data))
+	if serverURL == "" {
+		return "", errors.New("no credentials server URL")
+	}
+
+	return serverURL, nil
+}
+
+// dockerSecret - finds login/pass secret of registry by its server URL, the oldest secret wins if there are several.
+func (e *Executor) dockerSecret(serverURL string) (secretModel.LoginPassSecret, bool, error) {
+	secrets, err := e.loginPassSecrets()
+	if err != nil {
+		return secretModel.LoginPassSecret{}, false, err
+	}
+
+	var (
+		found secretModel.LoginPassSecret
+		ok    bool
+	)
+
+	for _, secret := range secrets {
+		if secret.URL == serverURL && (!ok || secret.Id < found.Id) {
+			found, ok = secret, true
+		}
+	}
+
+	return found, ok, nil
+}
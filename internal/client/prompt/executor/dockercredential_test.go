@@ -0,0 +1,41 @@
+package executor
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDockerCredentialHelper_EraseThenList(t *testing.T) {
+	server := newFakeServer()
+
+	// docker runs a new process of the helper for every action
+	helper := func(action, input string) (string, error) {
+		var out bytes.Buffer
+		err := newTestExecutor(t, server).DockerCredentialHelper(action, strings.NewReader(input), &out)
+
+		return out.String(), err
+	}
+
+	const registry = "https://registry.example.com"
+
+	_, err := helper("store", `{"ServerURL":"`+registry+`","Username":"alice","Secret":"token"}`)
+	require.NoError(t, err)
+
+	listed, err := helper("list", "")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"`+registry+`":"alice"}`, listed)
+
+	_, err = helper("erase", registry)
+	require.NoError(t, err)
+
+	listed, err = helper("list", "")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{}`, listed, "erased credential must not be listed")
+
+	_, err = helper("get", registry)
+	assert.ErrorIs(t, err, errDockerNotFound)
+}
@@ -0,0 +1,100 @@
+package executor
+
+import (
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"secretKeeper/internal/client/prompt/output"
+)
+
+// addContact - is executor for "add-contact" case in Execute method.
+func (e *Executor) addContact(in input) (output.Message, error) {
+	hours := in.int("hours")
+	if hours <= 0 {
+		return output.Message{}, validationError("Waiting period must be a positive number of hours")
+	}
+
+	contact, err := e.app.EmergencyService.AddContact(in.str("login"), hours)
+	if err != nil {
+		return output.Message{}, emergencyError(err)
+	}
+
+	return output.Message{
+		Message: fmt.Sprintf("%v is your trusted contact now, access is granted %v hours after request", contact.Login, hours),
+	}, nil
+}
+
+// removeContact - is executor for "remove-contact" case in Execute method.
+func (e *Executor) removeContact(in input) error {
+	if err := e.app.EmergencyService.RemoveContact(in.str("login")); err != nil {
+		return emergencyError(err)
+	}
+
+	return nil
+}
+
+// contacts - is executor for "contacts" case in Execute method.
+func (e *Executor) contacts() ([]contactItem, error) {
+	contacts, err := e.app.EmergencyService.ListContacts()
+	if err != nil {
+		return nil, err
+	}
+
+	return newContactItems(contacts), nil
+}
+
+// grantors - is executor for "grantors" case in Execute method.
+func (e *Executor) grantors() ([]contactItem, error) {
+	grantors, err := e.app.EmergencyService.ListGrantors()
+	if err != nil {
+		return nil, err
+	}
+
+	return newContactItems(grantors), nil
+}
+
+// requestAccess - is executor for "request-access" case in Execute method.
+func (e *Executor) requestAccess(in input) (contactItem, error) {
+	grantor, err := e.app.EmergencyService.RequestAccess(in.str("owner"))
+	if err != nil {
+		return contactItem{}, emergencyError(err)
+	}
+
+	return newContactItem(grantor), nil
+}
+
+// denyAccess - is executor for "deny-access" case in Execute method.
+func (e *Executor) denyAccess(in input) error {
+	if err := e.app.EmergencyService.DenyAccess(in.str("login")); err != nil {
+		return emergencyError(err)
+	}
+
+	return nil
+}
+
+// emergencySecrets - is executor for "emergency-secrets" case in Execute method.
+func (e *Executor) emergencySecrets(in input) ([]emergencySecretItem, error) {
+	secrets, err := e.app.EmergencyService.OpenAccess(in.str("owner"))
+	if err != nil {
+		return nil, emergencyError(err)
+	}
+
+	return newEmergencySecretItems(secrets), nil
+}
+
+// emergencyError - converts gRPC errors of emergency methods to human-readable errors.
+func emergencyError(err error) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	switch st.Code() {
+	case codes.PermissionDenied, codes.NotFound, codes.InvalidArgument:
+		return statusError(st)
+	default:
+		return err
+	}
+}
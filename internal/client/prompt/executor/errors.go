@@ -0,0 +1,120 @@
+package executor
+
+import (
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"secretKeeper/internal/client/interceptor"
+	"secretKeeper/internal/client/prompt/output"
+	"secretKeeper/internal/client/storage"
+	"secretKeeper/pkg/apperr"
+)
+
+// Exit codes of subcommand mode.
+const (
+	ExitOK       = 0
+	ExitFailure  = 1
+	ExitUsage    = 2
+	ExitAuth     = 3
+	ExitNotFound = 4
+	ExitDenied   = 5
+	ExitConflict = 6
+)
+
+// commandError - is an error of a command which knows its gRPC code, so exit code and structured output match it.
+type commandError struct {
+	msg  string
+	code codes.Code
+}
+
+// Error - returns message of commandError.
+func (c *commandError) Error() string {
+	return c.msg
+}
+
+// childExitError - is a non-zero exit status of a child process, subcommand mode exits with the same status.
+type childExitError struct {
+	code int
+}
+
+// Error - returns message of childExitError.
+func (c *childExitError) Error() string {
+	return fmt.Sprintf("error: command exited with status %d", c.code)
+}
+
+// validationError - returns error about invalid arguments of a command.
+func validationError(format string, a ...interface{}) error {
+	return &commandError{msg: "validation error: " + fmt.Sprintf(format, a...), code: codes.InvalidArgument}
+}
+
+// statusError - returns error with message of grpc status, which keeps status code.
+func statusError(st *status.Status) error {
+	return &commandError{msg: "error: " + st.Message(), code: st.Code()}
+}
+
+// ExitCode - returns exit code of subcommand mode matching the error.
+func ExitCode(err error) int {
+	var childErr *childExitError
+	if errors.As(err, &childErr) {
+		return childErr.code
+	}
+
+	return exitCodeOf(errorCode(err))
+}
+
+// errorCode - returns gRPC code matching the error, errors of the client itself get the closest code.
+func errorCode(err error) codes.Code {
+	if err == nil {
+		return codes.OK
+	}
+
+	var cmdErr *commandError
+	if errors.As(err, &cmdErr) {
+		return cmdErr.code
+	}
+
+	if errors.Is(err, apperr.ErrSecretNotFound) {
+		return codes.NotFound
+	}
+
+	if errors.Is(err, interceptor.ErrUnauthorized) || errors.Is(err, storage.ErrNoKeyPair) ||
+		errors.Is(err, storage.ErrNoSession) || errors.Is(err, storage.ErrLocked) {
+		return codes.Unauthenticated
+	}
+
+	if st, ok := status.FromError(err); ok {
+		return st.Code()
+	}
+
+	return codes.Unknown
+}
+
+// structuredError - returns representation of the error for structured output.
+func structuredError(err error) output.Error {
+	code := errorCode(err)
+
+	return output.Error{Code: code.String(), Message: err.Error(), ExitCode: exitCodeOf(code)}
+}
+
+// exitCodeOf - maps grpc status code to exit code.
+func exitCodeOf(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return ExitOK
+	case codes.InvalidArgument:
+		return ExitUsage
+	case codes.Unauthenticated:
+		return ExitAuth
+	case codes.NotFound:
+		return ExitNotFound
+	case codes.PermissionDenied:
+		return ExitDenied
+	case codes.FailedPrecondition, codes.AlreadyExists, codes.Aborted:
+		return ExitConflict
+	default:
+		return ExitFailure
+	}
+}
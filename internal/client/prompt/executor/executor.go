@@ -1,173 +1,304 @@
 package executor
 
 import (
+	"bufio"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"secretKeeper/internal/client/app"
 	"secretKeeper/internal/client/model"
+	"secretKeeper/internal/client/prompt/output"
+	"secretKeeper/internal/client/storage"
+	"secretKeeper/pkg/passgen"
 )
 
 type Executor struct {
 	app *app.App
+
+	// interactive - is true for go-prompt REPL and false for subcommand mode.
+	interactive bool
+	// envAuth - is true when subcommand mode is authorized by credentials from environment.
+	envAuth bool
+	// format - is output format of the command being executed.
+	format output.Format
+	// stdin - secret arguments of the command being executed are read from stdin instead of terminal prompt.
+	stdin       bool
+	stdinReader *bufio.Reader
+	// generated - is password generated for the command being executed, its strength is reported.
+	generated *passgen.Result
+	// secretTypes - are cached secret types, which are fixed on server, used for completion and name lookup.
+	secretTypes []secretTypeItem
+	// jobs - are jobs served in background of REPL until exit, e.g. ssh-agent, by name.
+	jobs   map[string]*backgroundJob
+	jobsMu sync.Mutex
+	// lastActivity and running - are watched by auto-lock job of REPL, it locks the vault when it's idle.
+	lastActivity time.Time
+	running      bool
+	idleMu       sync.Mutex
+	// sessionMu - guards app and its session read by background jobs serving requests, e.g. ssh-agent, against
+	// commands replacing them.
+	sessionMu sync.RWMutex
 }
 
+// NewExecutor - creates Executor of go-prompt REPL.
 func NewExecutor() *Executor {
-	appL, err := app.NewApp()
+	appL, err := app.NewApp("")
 	if err != nil {
 		panic(err)
 	}
 
-	return &Executor{app: appL}
+	return &Executor{app: appL, interactive: true}
+}
+
+// NewSubcommandExecutor - creates Executor of subcommand mode, which restores session before every command.
+//
+// App is created by the command, as --profile of the command chooses it.
+func NewSubcommandExecutor() *Executor {
+	return &Executor{}
 }
 
+// Execute - runs command line typed in REPL and prints its result or error.
 func (e *Executor) Execute(s string) {
-	var isForce bool
+	tokens, err := tokenize(s)
+	if err != nil {
+		e.write(nil, validationError("%v", err))
 
-	setCommand, options := getCommandArgsAndOptions(s)
-	if options["force"] || options["f"] {
-		isForce = true
+		return
 	}
 
-	switch setCommand[0] {
-	case "login":
-		if err := e.login(setCommand); err != nil {
-			fmt.Println(err)
-			return
-		}
+	e.touch(true)
+	defer e.touch(false)
 
-		fmt.Println("successfully authorized")
-		return
-	case "register":
-		if err := e.register(setCommand); err != nil {
-			fmt.Println(err)
-			return
-		}
+	result, errExec := e.execute(tokens)
 
-		fmt.Println("User successfully created. You are logged in.")
+	e.write(result, errExec)
+}
 
-		return
-	case "delete-user":
-		if err := e.deleteUser(); err != nil {
-			fmt.Println(err)
-			return
-		}
+// Run - runs command of subcommand mode, prints result to stdout or error to stderr and returns exit code.
+//
+// Exit status of a child process is returned as is, the child has reported its failure itself.
+func (e *Executor) Run(args []string) int {
+	result, err := e.execute(args)
 
-		fmt.Println("you successfully deleted account and logged out")
+	var childErr *childExitError
+	if !errors.As(err, &childErr) {
+		e.write(result, err)
+	}
 
-		return
-	case "logout":
-		if err := e.logout(); err != nil {
-			fmt.Println(err)
-			return
-		}
+	return ExitCode(err)
+}
 
-		fmt.Println("you successfully logged out")
-		return
-	case "types":
-		types, err := e.types()
-		if err != nil {
-			fmt.Println(err)
-			return
-		}
+// write - renders result or error of a command in output format, errors go to stderr in subcommand mode.
+func (e *Executor) write(result interface{}, err error) {
+	var errOut io.Writer = os.Stderr
+	if e.interactive {
+		errOut = os.Stdout
+	}
 
-		for _, t := range types {
-			fmt.Printf("%+v\n", t)
+	if err != nil {
+		if errWrite := output.WriteError(errOut, e.format, structuredError(err)); errWrite != nil {
+			fmt.Fprintln(errOut, err)
 		}
 
 		return
-	case "create-auth":
-		if err := e.createAuth(setCommand); err != nil {
-			fmt.Println(err)
-			return
-		}
-		return
-	case "create-text":
-		if err := e.createText(setCommand); err != nil {
-			fmt.Println(err)
-			return
-		}
+	}
 
+	if result == nil {
 		return
-	case "create-binary":
-		if err := e.createBinary(setCommand); err != nil {
-			fmt.Println(err)
-			return
-		}
+	}
 
-		return
-	case "create-card":
-		if err := e.createCard(setCommand); err != nil {
-			fmt.Println(err)
-			return
-		}
+	if errWrite := output.Write(os.Stdout, e.format, result); errWrite != nil {
+		fmt.Fprintln(errOut, errWrite)
+	}
+}
 
-		return
-	case "delete-secret":
-		if err := e.deleteSecret(setCommand); err != nil {
-			fmt.Println(err)
-			return
+// execute - finds command in registry, restores session in subcommand mode and runs the command.
+//
+// Global options, e.g. --output json, may precede the command.
+func (e *Executor) execute(tokens []string) (interface{}, error) {
+	e.format, e.stdin, e.generated = output.FormatTable, false, nil
+
+	name, rest := splitCommand(tokens)
+	if name == "" {
+		if len(rest) == 0 {
+			return nil, nil
 		}
 
-		return
-	case "get-secrets-by-type":
-		list, err := e.getSecretsByTypeId(setCommand)
-		if err != nil {
-			fmt.Println(err)
-			return
+		return nil, validationError("command is missing, see help")
+	}
+
+	cmd, ok := lookupCommand(name)
+	if !ok {
+		return nil, &commandError{msg: fmt.Sprintf("unknown command %s, see help", name), code: codes.InvalidArgument}
+	}
+
+	if cmd.session {
+		e.sessionMu.Lock()
+		defer e.sessionMu.Unlock()
+	}
+
+	in, errParse := parseInput(cmd.args, append(append([]flagSpec{}, globalFlags...), cmd.flags...), rest)
+
+	if err := e.loadApp(in.option("profile")); err != nil {
+		return nil, err
+	}
+
+	format, errFormat := output.ParseFormat(e.app.Config.Output)
+	if value := in.option("output"); value != "" {
+		format, errFormat = output.ParseFormat(value)
+	}
+
+	if errFormat != nil {
+		return nil, validationError("%v", errFormat)
+	}
+
+	e.format = format
+
+	if errParse != nil {
+		return nil, &commandError{msg: errParse.Error() + ", usage: " + usage(cmd), code: codes.InvalidArgument}
+	}
+
+	e.stdin = in.flag("stdin")
+
+	if cmd.auth == authRequired && !cmd.whileLocked && e.app.UserService.IsLocked() {
+		return nil, errLocked
+	}
+
+	if err := e.readSecrets(cmd.args, in); err != nil {
+		return nil, err
+	}
+
+	if !e.interactive && cmd.auth != authNone {
+		if err := e.restoreSession(); err != nil && cmd.auth == authRequired {
+			return nil, err
 		}
 
-		for _, secret := range list {
-			fmt.Printf("ID:%v Title: %v\n", secret.Id, secret.Title)
+		if cmd.auth == authRequired && !cmd.whileLocked {
+			if err := e.unlockSession(); err != nil {
+				return nil, err
+			}
 		}
+	}
 
-		return
-	case "get-secret":
-		secret, err := e.getSecret(setCommand)
+	return cmd.run(e, in)
+}
+
+// Sensitive - reports whether command line may carry secrets, so it must not be recorded in history.
+//
+// Lines which can't be parsed are treated as sensitive.
+func Sensitive(line string) bool {
+	tokens, err := tokenize(line)
+	if err != nil {
+		return true
+	}
+
+	name, _ := splitCommand(tokens)
+
+	cmd, ok := lookupCommand(name)
+
+	return ok && cmd.sensitive
+}
+
+// restoreSession - authorizes subcommand mode by credentials from environment or by cached session file.
+//
+// Session restored from file is locked until unlockSession, session file which can't be restored is removed, e.g.
+// cached by older version along with private key.
+func (e *Executor) restoreSession() error {
+	if e.app.Config.Login != "" {
+		e.envAuth = true
+
+		return e.app.UserService.Login(model.User{Login: e.app.Config.Login, Password: e.app.Config.Password})
+	}
+
+	session, err := e.app.Session.Load()
+	if err != nil {
+		return err
+	}
+
+	if errRestore := e.app.UserService.RestoreSession(session); errRestore != nil {
+		return errors.Join(errRestore, e.app.Session.Remove())
+	}
+
+	return nil
+}
+
+// unlockSession - derives keys of session restored from file by SECRETKEEPER_PASSWORD or by password read as secret
+// arguments are, after them. Session with expired token is removed.
+//
+// Prompt is never unlocked this way, it's locked by the user.
+func (e *Executor) unlockSession() error {
+	if e.interactive || !e.app.UserService.IsLocked() {
+		return nil
+	}
+
+	password := e.app.Config.Password
+	if password == "" {
+		value, err := e.readSecret(argSpec{name: "password", label: "Password", secret: true})
 		if err != nil {
-			fmt.Println(err)
-			return
+			return err
 		}
 
-		fmt.Printf("Content:%+v\n", secret)
+		password = value
+	}
 
-		return
-	case "get-secret-binary":
-		if err := e.getSecretBinary(setCommand); err != nil {
-			fmt.Println(err)
-			return
-		}
-		return
-	case "edit-secret":
-		if err := e.editSecret(setCommand, isForce); err != nil {
-			fmt.Println(err)
-			return
+	err := e.app.UserService.Unlock(password)
+
+	switch {
+	case err == nil:
+		return nil
+	case status.Code(err) == codes.Unauthenticated:
+		if errRemove := e.app.Session.Remove(); errRemove != nil {
+			return errRemove
 		}
 
-		return
-	case "exit":
-		fmt.Println("bye bye...application is closing")
+		return &commandError{msg: "error: session has expired, please login again", code: codes.Unauthenticated}
+	case errors.Is(err, storage.ErrWrongPassword):
+		return &commandError{msg: "error: " + err.Error(), code: codes.Unauthenticated}
+	default:
+		return err
+	}
+}
 
-		e.app.Cancel()
-		e.app.Cron.Stop()
+// persistSession - caches session of logged user in subcommand mode, or removes cached session after logout.
+//
+// REPL keeps session in memory only, and session authorized from environment is never cached.
+func (e *Executor) persistSession() error {
+	if e.interactive || e.envAuth {
+		return nil
+	}
 
-		os.Exit(0)
+	if !e.app.UserService.IsLogged() {
+		return e.app.Session.Remove()
 	}
+
+	session, err := e.app.UserService.Session()
+	if err != nil {
+		return err
+	}
+
+	return e.app.Session.Save(session)
 }
 
 // types - is executor for "types" case in Execute method.
-func (e *Executor) types() ([]model.SecretType, error) {
+func (e *Executor) types() ([]secretTypeItem, error) {
 	secrets, err := e.app.SecretTypeService.List()
 	if err != nil {
 		return nil, err
 	}
 
-	var models []model.SecretType
+	models := make([]secretTypeItem, 0, len(secrets.Secrets))
 	for _, secret := range secrets.Secrets {
-
-		models = append(models, model.SecretType{
-			Id:    int(secret.Id),
+		models = append(models, secretTypeItem{
+			ID:    int(secret.Id),
 			Title: secret.Title,
 		})
 	}
@@ -175,29 +306,38 @@ func (e *Executor) types() ([]model.SecretType, error) {
 	return models, nil
 }
 
-// getCommandArgsAndOptions - splits args to command args and options
-func getCommandArgsAndOptions(s string) ([]string, map[string]bool) {
-	s = strings.TrimSpace(s)
+// cachedTypes - returns secret types, they are requested from server only once.
+func (e *Executor) cachedTypes() ([]secretTypeItem, error) {
+	if e.secretTypes != nil {
+		return e.secretTypes, nil
+	}
 
-	setCommand := strings.Split(s, " ")
+	types, err := e.types()
+	if err != nil {
+		return nil, err
+	}
 
-	l := len(setCommand)
+	e.secretTypes = types
 
-	filtered := make([]string, 0, l)
-	options := make(map[string]bool)
+	return types, nil
+}
 
-	for i := 0; i < len(setCommand); i++ {
-		if strings.HasPrefix(setCommand[i], "-") {
-			opt := strings.TrimPrefix(setCommand[i], "--")
-			opt = strings.TrimPrefix(opt, "-")
+// secretTypeID - resolves secret type passed as ID or as case-insensitive name, e.g. card.
+func (e *Executor) secretTypeID(value string) (int, error) {
+	if id, err := strconv.Atoi(value); err == nil {
+		return id, nil
+	}
 
-			optSplited := strings.Split(opt, "=")
-			options[optSplited[0]] = true
+	types, err := e.cachedTypes()
+	if err != nil {
+		return 0, err
+	}
 
-			continue
+	for _, t := range types {
+		if strings.EqualFold(t.Title, value) {
+			return t.ID, nil
 		}
-		filtered = append(filtered, setCommand[i])
 	}
 
-	return filtered, options
+	return 0, &commandError{msg: fmt.Sprintf("error: unknown secret type %s, see types", value), code: codes.NotFound}
 }
@@ -0,0 +1,211 @@
+package executor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/robfig/cron/v3"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"secretKeeper/internal/client/app"
+	"secretKeeper/internal/client/config"
+	"secretKeeper/internal/client/model"
+	"secretKeeper/internal/client/service"
+	"secretKeeper/internal/client/storage"
+	"secretKeeper/pkg/crypt"
+	pb "secretKeeper/proto"
+)
+
+// fakeServer - keeps secrets and personal key of one user in memory and deletes secrets softly, as the server does.
+type fakeServer struct {
+	pb.SecretClient
+	pb.UserClient
+
+	mu          sync.Mutex
+	lastID      uint32
+	secrets     map[uint32]*pb.GetSecretResponse
+	personalKey []byte
+	// expired - makes personal key requests fail, as token has expired.
+	expired bool
+}
+
+func newFakeServer() *fakeServer {
+	return &fakeServer{secrets: make(map[uint32]*pb.GetSecretResponse)}
+}
+
+func (f *fakeServer) Login(context.Context, *pb.LoginRequest, ...grpc.CallOption) (*pb.LoginResponse, error) {
+	return &pb.LoginResponse{Token: "token"}, nil
+}
+
+func (f *fakeServer) SetPublicKey(
+	context.Context, *pb.SetPublicKeyRequest, ...grpc.CallOption,
+) (*pb.SetPublicKeyResponse, error) {
+	return &pb.SetPublicKeyResponse{}, nil
+}
+
+func (f *fakeServer) GetPersonalKey(
+	context.Context, *pb.GetPersonalKeyRequest, ...grpc.CallOption,
+) (*pb.GetPersonalKeyResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.expired {
+		return nil, status.Error(codes.Unauthenticated, "token has expired")
+	}
+
+	return &pb.GetPersonalKeyResponse{WrappedKey: f.personalKey}, nil
+}
+
+func (f *fakeServer) SetPersonalKey(
+	_ context.Context, in *pb.SetPersonalKeyRequest, _ ...grpc.CallOption,
+) (*pb.SetPersonalKeyResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.personalKey = in.WrappedKey
+
+	return &pb.SetPersonalKeyResponse{}, nil
+}
+
+func (f *fakeServer) CreateSecret(
+	_ context.Context, in *pb.CreateSecretRequest, _ ...grpc.CallOption,
+) (*pb.CreateSecretResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.lastID++
+	now := timestamppb.Now()
+	f.secrets[f.lastID] = &pb.GetSecretResponse{
+		Id: f.lastID, Title: in.Title, Type: in.Type, Content: in.Content, CreatedAt: now, UpdatedAt: now,
+	}
+
+	return &pb.CreateSecretResponse{Id: f.lastID, Title: in.Title, Type: in.Type, CreatedAt: now, UpdatedAt: now}, nil
+}
+
+func (f *fakeServer) CreateSecrets(
+	ctx context.Context, in *pb.CreateSecretsRequest, _ ...grpc.CallOption,
+) (*pb.CreateSecretsResponse, error) {
+	result := &pb.CreateSecretsResponse{}
+
+	for _, request := range in.Secrets {
+		created, err := f.CreateSecret(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Secrets = append(result.Secrets, created)
+	}
+
+	return result, nil
+}
+
+func (f *fakeServer) GetSecret(
+	_ context.Context, in *pb.GetSecretRequest, _ ...grpc.CallOption,
+) (*pb.GetSecretResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	stored, ok := f.secrets[uint32(in.Id)]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "secret not found")
+	}
+
+	return stored, nil
+}
+
+func (f *fakeServer) DeleteSecret(
+	_ context.Context, in *pb.DeleteSecretRequest, _ ...grpc.CallOption,
+) (*pb.DeleteSecretResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	stored, ok := f.secrets[in.Id]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "secret not found")
+	}
+
+	stored.IsDelited = true
+
+	return &pb.DeleteSecretResponse{}, nil
+}
+
+func (f *fakeServer) GetListOfSecretsByType(
+	_ context.Context, in *pb.GetListOfSecretsByTypeRequest, _ ...grpc.CallOption,
+) (*pb.GetListOfSecretsByTypeResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var list []*pb.SecretList
+
+	for _, stored := range f.secrets {
+		if stored.Type != in.TypeId {
+			continue
+		}
+
+		list = append(list, &pb.SecretList{
+			Id: stored.Id, TypeId: stored.Type, Title: stored.Title, Content: stored.Content,
+			CreatedAt: stored.CreatedAt, UpdatedAt: stored.UpdatedAt, IsDelited: stored.IsDelited,
+		})
+	}
+
+	return &pb.GetListOfSecretsByTypeResponse{SecretLists: list}, nil
+}
+
+func (f *fakeServer) GetDueEvents(
+	context.Context, *pb.GetDueEventsRequest, ...grpc.CallOption,
+) (*pb.GetDueEventsResponse, error) {
+	return &pb.GetDueEventsResponse{}, nil
+}
+
+// newTestExecutor - creates Executor of subcommand mode served by server, every Executor stands for a new process,
+// so nothing is shared between them except server. User is authorized by credentials from environment.
+func newTestExecutor(t *testing.T, server *fakeServer) *Executor {
+	t.Helper()
+
+	cr, err := crypt.NewCrypt()
+	require.NoError(t, err)
+
+	glCtx := &model.GlobalContext{Ctx: context.Background()}
+	memoryStorage := storage.NewMemoryStorage()
+	keyring := storage.NewKeyring()
+	personalCrypt := keyring.PersonalCrypter(cr)
+	syn := storage.NewSync(memoryStorage, server, glCtx, personalCrypt)
+	organizationService := service.NewOrganizationClientService(glCtx, nil, server, nil, keyring)
+
+	return &Executor{app: &app.App{
+		SecretService: service.NewSecretClientService(
+			glCtx, server, memoryStorage, personalCrypt, syn, organizationService,
+		),
+		UserService: service.NewUserClientService(glCtx, server, keyring),
+		Storage:     memoryStorage,
+		Syncer:      syn,
+		Cron:        cron.New(),
+		Session:     storage.NewSessionFile(filepath.Join(t.TempDir(), "session.json")),
+		Config:      config.Config{Login: "alice", Password: "password"},
+	}}
+}
+
+// withStdin - runs fn with os.Stdin reading input.
+func withStdin(t *testing.T, input string, fn func()) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "stdin")
+	require.NoError(t, os.WriteFile(path, []byte(input), 0o600))
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	stdin := os.Stdin
+	os.Stdin = f
+	defer func() { os.Stdin = stdin }()
+
+	fn()
+}
@@ -0,0 +1,133 @@
+package executor
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	secretModel "secretKeeper/internal/client/model/secret"
+	"secretKeeper/pkg/exporter"
+)
+
+// exportAs - is executor for "export-as" case in Execute method.
+//
+// Age export is encrypted to --recipient public keys, Bitwarden and KeePass exports aren't encrypted at all, so they
+// are written only with --i-understand. Bitwarden export has no attachments, so binary secrets are skipped.
+func (e *Executor) exportAs(in input) (backupReport, error) {
+	format := in.str("format")
+
+	switch {
+	case format == exporter.FormatAge && len(in.options("recipient")) == 0:
+		return backupReport{}, validationError("age export needs --recipient age1... public key")
+	case format != exporter.FormatAge && !in.flag("i-understand"):
+		return backupReport{}, validationError("%s export isn't encrypted, anyone who reads the file reads every "+
+			"secret, pass --i-understand to write it", format)
+	}
+
+	secrets, report, err := e.storedSecrets()
+	if err != nil {
+		return backupReport{}, err
+	}
+
+	entries := make([]exporter.Entry, 0, len(secrets))
+	skipped := 0
+
+	for _, secret := range secrets {
+		entry, errEntry := exportedEntry(secret)
+		if errEntry != nil {
+			return backupReport{}, errEntry
+		}
+
+		if format == exporter.FormatBitwarden && entry.Kind == exporter.KindBinary {
+			skipped++
+
+			continue
+		}
+
+		entries = append(entries, entry)
+	}
+
+	b := secretsBackup(secrets)
+
+	data, errWrite := exporter.Write(format, entries, exporter.Options{
+		Backup: b, Recipients: in.options("recipient"), Armor: in.flag("armor"),
+	})
+	if errWrite != nil {
+		return backupReport{}, validationError("%v", errWrite)
+	}
+
+	if errWrite = writeFileAtomic(in.str("path"), data, 0o600); errWrite != nil {
+		return backupReport{}, errWrite
+	}
+
+	exported := len(entries)
+	if format == exporter.FormatAge {
+		exported = len(b.Secrets)
+	}
+
+	report.Message = fmt.Sprintf("%d secrets are exported to %s", exported, in.str("path"))
+	if skipped > 0 {
+		report.Message += fmt.Sprintf(", %d binary secrets are skipped as %s export has no attachments", skipped, format)
+	}
+
+	report.CreatedAt = b.CreatedAt
+
+	return report, nil
+}
+
+// exportedEntry - maps secret onto entry of export, TOTP secret becomes login with one-time passwords and SSH key
+// becomes text with private key and public key field.
+func exportedEntry(secret secretModel.StoredSecret) (exporter.Entry, error) {
+	entry := exporter.Entry{
+		Title:     secret.Title,
+		CreatedAt: secret.CreatedAt,
+		UpdatedAt: secret.UpdatedAt,
+		ExpiresAt: secret.Expiry.ExpiresAt,
+	}
+
+	var err error
+
+	switch secret.Type {
+	case typeLoginPass:
+		var s secretModel.LoginPassSecret
+		if err = json.Unmarshal([]byte(secret.Content), &s); err == nil {
+			entry.Kind, entry.Login, entry.Password, entry.URL = exporter.KindLogin, s.Login, s.Password, s.URL
+		}
+	case typeText:
+		var s secretModel.TextSecret
+		if err = json.Unmarshal([]byte(secret.Content), &s); err == nil {
+			entry.Kind, entry.Notes = exporter.KindText, s.Text
+		}
+	case typeBinary:
+		// binary secret is its raw content, title is the best guess of its file name
+		entry.Kind, entry.FileName, entry.Data = exporter.KindBinary, strings.TrimSpace(secret.Title), []byte(secret.Content)
+	case typeCard:
+		var s secretModel.CardSecret
+		if err = json.Unmarshal([]byte(secret.Content), &s); err == nil {
+			entry.Kind, entry.CardNumber, entry.CVV, entry.Due = exporter.KindCard, s.CardNumber, s.CVV, s.Due
+		}
+	case typeTOTP:
+		var s secretModel.TOTPSecret
+		if err = json.Unmarshal([]byte(secret.Content), &s); err == nil {
+			entry.Kind, entry.Login, entry.TOTP = exporter.KindLogin, s.Account, s.Key().URI()
+		}
+	case typeSSH:
+		var s secretModel.SSHSecret
+		if err = json.Unmarshal([]byte(secret.Content), &s); err == nil {
+			entry.Kind, entry.Notes = exporter.KindText, s.PrivateKey
+			entry.Fields = append(entry.Fields, exporter.Field{Name: "Public key", Value: s.PublicKey})
+
+			if s.Comment != "" {
+				entry.Fields = append(entry.Fields, exporter.Field{Name: "Comment", Value: s.Comment})
+			}
+		}
+	default:
+		return exporter.Entry{}, fmt.Errorf("secret %d has unknown type %d", secret.Id, secret.Type)
+	}
+
+	if err != nil {
+		return exporter.Entry{}, fmt.Errorf("error in exporting secret %d: %w", secret.Id, err)
+	}
+
+	return entry, nil
+}
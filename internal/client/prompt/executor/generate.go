@@ -0,0 +1,179 @@
+package executor
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"google.golang.org/grpc/codes"
+
+	secretModel "secretKeeper/internal/client/model/secret"
+	"secretKeeper/pkg/passgen"
+)
+
+// generatorFlags - are options of password generator, --words switches it to passphrase.
+var generatorFlags = []flagSpec{
+	{name: "length", short: "l", kind: kindInt, group: "generator"},
+	{name: "no-lower", kind: kindBool, group: "generator"},
+	{name: "no-upper", kind: kindBool, group: "generator"},
+	{name: "no-digits", kind: kindBool, group: "generator"},
+	{name: "no-symbols", kind: kindBool, group: "generator"},
+	{name: "no-ambiguous", kind: kindBool, group: "generator"},
+	{name: "exclude", kind: kindString, group: "generator"},
+	{name: "words", kind: kindInt, group: "generator"},
+	{name: "separator", kind: kindString, group: "generator"},
+	{name: "capitalize", kind: kindBool, group: "generator"},
+}
+
+// generateFlag - makes secret arguments declared as generated to be produced by the generator instead of prompt.
+var generateFlag = flagSpec{name: "generate", short: "g", kind: kindBool}
+
+// generatedResult - is a result of "generate" command.
+type generatedResult struct {
+	Value    string  `json:"value"`
+	Entropy  float64 `json:"entropy"`
+	Strength string  `json:"strength"`
+	Message  string  `json:"message,omitempty"`
+	// ID - is ID of login/pass secret created by --create.
+	ID uint32 `json:"id,omitempty"`
+}
+
+// WriteTable - writes generated value on its own line, so it could be cut, followed by its strength.
+func (g generatedResult) WriteTable(w io.Writer) error {
+	fmt.Fprintln(w, g.Value)
+
+	if _, err := fmt.Fprintf(w, "entropy: %.0f bits, %s\n", g.Entropy, g.Strength); err != nil {
+		return err
+	}
+
+	if g.Message != "" {
+		_, err := fmt.Fprintln(w, g.Message)
+
+		return err
+	}
+
+	return nil
+}
+
+// runGenerator - generates password or passphrase by generator options of the command.
+func runGenerator(in input) (passgen.Result, error) {
+	var (
+		result passgen.Result
+		err    error
+	)
+
+	if in.option("words") != "" {
+		opts := passgen.DefaultPassphraseOptions()
+		opts.Words = in.optionInt("words")
+		opts.Capitalize = in.flag("capitalize")
+
+		if separator, ok := in.opts["separator"]; ok {
+			opts.Separator = separator
+		}
+
+		result, err = passgen.Passphrase(opts)
+	} else {
+		opts := passgen.DefaultPasswordOptions()
+		if in.option("length") != "" {
+			opts.Length = in.optionInt("length")
+		}
+
+		opts.Lower, opts.Upper = !in.flag("no-lower"), !in.flag("no-upper")
+		opts.Digits, opts.Symbols = !in.flag("no-digits"), !in.flag("no-symbols")
+		opts.NoAmbiguous = in.flag("no-ambiguous")
+		opts.Exclude = in.option("exclude")
+
+		result, err = passgen.Password(opts)
+	}
+
+	if err != nil {
+		return passgen.Result{}, validationError("%v", err)
+	}
+
+	return result, nil
+}
+
+// generate - is executor for "generate" case in Execute method.
+//
+// Generated value may be saved as password of existing login/pass secret with --save, or as new one with --create.
+func (e *Executor) generate(in input) (generatedResult, error) {
+	if in.option("save") != "" && in.option("create") != "" {
+		return generatedResult{}, validationError("--save and --create can't be used together")
+	}
+
+	if in.option("save") != "" || in.option("create") != "" {
+		if err := e.unlockSession(); err != nil {
+			return generatedResult{}, err
+		}
+	}
+
+	generated, err := runGenerator(in)
+	if err != nil {
+		return generatedResult{}, err
+	}
+
+	result := generatedResult{
+		Value:    generated.Value,
+		Entropy:  generated.Entropy,
+		Strength: passgen.Strength(generated.Entropy),
+	}
+
+	switch {
+	case in.option("save") != "":
+		id := in.optionInt("save")
+		if errSave := e.savePassword(id, generated.Value); errSave != nil {
+			return generatedResult{}, errSave
+		}
+
+		result.Message = fmt.Sprintf("password of secret %d is updated", id)
+	case in.option("create") != "":
+		id, errCreate := e.createLoginPass(in.option("create"), in.option("login"), generated.Value, in.option("url"))
+		if errCreate != nil {
+			return generatedResult{}, errCreate
+		}
+
+		result.ID, result.Message = id, fmt.Sprint("created new secret with ID: ", id)
+	}
+
+	return result, nil
+}
+
+// savePassword - replaces password of existing login/pass secret, other fields are kept.
+func (e *Executor) savePassword(id int, password string) error {
+	return e.saveCredential(id, "", password)
+}
+
+// saveCredential - replaces login and password of existing login/pass secret, empty login is kept as is.
+func (e *Executor) saveCredential(id int, login, password string) error {
+	stored, err := e.fetchSecret(id)
+	if err != nil {
+		return err
+	}
+
+	var m secretModel.LoginPassSecret
+	if errDecode := json.Unmarshal([]byte(stored.Content), &m); errDecode != nil || m.RecordType != 1 {
+		return &commandError{msg: fmt.Sprintf("error: secret %d is not login/pass", id), code: codes.FailedPrecondition}
+	}
+
+	m.Id, m.Password = id, password
+	if login != "" {
+		m.Login = login
+	}
+
+	cont, errMarshal := json.Marshal(m)
+	if errMarshal != nil {
+		return errMarshal
+	}
+
+	return e.updateSecret(id, m.Title, 1, string(cont), false)
+}
+
+// generatedMessage - appends strength of password generated for the command to its message.
+func (e *Executor) generatedMessage(message string) string {
+	if e.generated == nil {
+		return message
+	}
+
+	return fmt.Sprintf("%s, generated password has %.0f bits of entropy, %s", message, e.generated.Entropy,
+		passgen.Strength(e.generated.Entropy))
+}
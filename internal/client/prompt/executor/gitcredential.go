@@ -0,0 +1,223 @@
+package executor
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+
+	secretModel "secretKeeper/internal/client/model/secret"
+)
+
+// gitCredential - is a credential of git credential helper protocol, see gitcredentials(7).
+type gitCredential struct {
+	Protocol string `json:"protocol,omitempty"`
+	Host     string `json:"host,omitempty"`
+	Path     string `json:"path,omitempty"`
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+}
+
+// WriteTable - writes credential as key=value lines, as git reads them.
+func (g gitCredential) WriteTable(w io.Writer) error {
+	if g.Username == "" && g.Password == "" {
+		return nil
+	}
+
+	_, err := fmt.Fprintf(w, "username=%s\npassword=%s\n", g.Username, g.Password)
+
+	return err
+}
+
+// readGitCredential - reads key=value lines from r until empty line or EOF, unknown keys are skipped.
+func readGitCredential(r io.Reader) (gitCredential, error) {
+	var credential gitCredential
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			break
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return gitCredential{}, validationError("credential line %q must be key=value", line)
+		}
+
+		switch key {
+		case "protocol":
+			credential.Protocol = value
+		case "host":
+			credential.Host = value
+		case "path":
+			credential.Path = value
+		case "username":
+			credential.Username = value
+		case "password":
+			credential.Password = value
+		case "url":
+			u, err := url.Parse(value)
+			if err != nil {
+				return gitCredential{}, validationError("credential url %q is invalid", value)
+			}
+
+			credential.Protocol, credential.Host, credential.Path = u.Scheme, u.Host, strings.TrimPrefix(u.Path, "/")
+			if u.User != nil {
+				credential.Username = u.User.Username()
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return gitCredential{}, err
+	}
+
+	return credential, nil
+}
+
+// address - returns protocol://host[/path] of credential, which is stored as URL of login/pass secret.
+func (g gitCredential) address() string {
+	u := g.Protocol + "://" + g.Host
+	if g.Path != "" {
+		u += "/" + g.Path
+	}
+
+	return u
+}
+
+// gitMatch - is login/pass secret matching credential, more specific URL has higher rank.
+type gitMatch struct {
+	secret secretModel.LoginPassSecret
+	rank   int
+}
+
+// matchGitURL - ranks URL of secret against credential, zero means no match.
+//
+// URL without scheme matches any protocol, URL without path matches any repository of the host.
+func matchGitURL(secretURL string, credential gitCredential) int {
+	if secretURL == "" || credential.Host == "" {
+		return 0
+	}
+
+	if !strings.Contains(secretURL, "://") {
+		secretURL = credential.Protocol + "://" + secretURL
+	}
+
+	u, err := url.Parse(secretURL)
+	if err != nil || !strings.EqualFold(u.Scheme, credential.Protocol) || !strings.EqualFold(u.Host, credential.Host) {
+		return 0
+	}
+
+	path := strings.Trim(u.Path, "/")
+	requested := strings.TrimSuffix(strings.Trim(credential.Path, "/"), ".git")
+
+	switch {
+	case path == "":
+		return 1
+	case strings.TrimSuffix(path, ".git") == requested:
+		return 3
+	case requested != "" && strings.HasPrefix(requested, path+"/"):
+		return 2
+	default:
+		return 0
+	}
+}
+
+// gitMatches - returns login/pass secrets of active vault matching credential, the most specific goes first.
+func (e *Executor) gitMatches(credential gitCredential) ([]gitMatch, error) {
+	secrets, err := e.loginPassSecrets()
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []gitMatch
+
+	for _, secret := range secrets {
+		if credential.Username != "" && secret.Login != credential.Username {
+			continue
+		}
+
+		if rank := matchGitURL(secret.URL, credential); rank > 0 {
+			matches = append(matches, gitMatch{secret: secret, rank: rank})
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].rank != matches[j].rank {
+			return matches[i].rank > matches[j].rank
+		}
+
+		return matches[i].secret.Id < matches[j].secret.Id
+	})
+
+	return matches, nil
+}
+
+// gitCredentialHelper - is executor for "git-credential" case in Execute method.
+//
+// get prints the most specific matching login/pass secret and nothing if there is none, so git asks user instead.
+// store updates password of the secret get returns or creates login/pass secret for credential approved by git, and
+// erase deletes secrets holding credential rejected by git.
+func (e *Executor) gitCredentialHelper(in input) (interface{}, error) {
+	credential, err := readGitCredential(os.Stdin)
+	if err != nil {
+		return nil, err
+	}
+
+	if credential.Host == "" {
+		return nil, nil
+	}
+
+	matches, errMatch := e.gitMatches(credential)
+	if errMatch != nil {
+		return nil, errMatch
+	}
+
+	switch in.str("action") {
+	case "get":
+		if len(matches) == 0 {
+			return nil, nil
+		}
+
+		secret := matches[0].secret
+
+		return gitCredential{Username: secret.Login, Password: secret.Password}, nil
+	case "store":
+		if credential.Username == "" || credential.Password == "" {
+			return nil, nil
+		}
+
+		// the secret get has returned is updated
+		if len(matches) > 0 {
+			if matches[0].secret.Password == credential.Password {
+				return nil, nil
+			}
+
+			return nil, e.savePassword(matches[0].secret.Id, credential.Password)
+		}
+
+		_, errCreate := e.createLoginPass(credential.Host, credential.Username, credential.Password, credential.address())
+
+		return nil, errCreate
+	case "erase":
+		for _, match := range matches {
+			// password is sent for erase, a secret which already holds another one is kept
+			if credential.Password != "" && match.secret.Password != credential.Password {
+				continue
+			}
+
+			if errDelete := e.app.SecretService.DeleteSecret(match.secret.Id); errDelete != nil {
+				return nil, errDelete
+			}
+		}
+
+		return nil, nil
+	default:
+		// git may add actions, unknown ones must be ignored
+		return nil, nil
+	}
+}
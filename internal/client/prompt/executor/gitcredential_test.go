@@ -0,0 +1,36 @@
+package executor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitCredentialHelper_EraseThenGet(t *testing.T) {
+	server := newFakeServer()
+
+	// git runs a new process of the helper for every action
+	helper := func(action, input string) interface{} {
+		var (
+			result interface{}
+			err    error
+		)
+
+		withStdin(t, input, func() {
+			result, err = newTestExecutor(t, server).execute([]string{"git-credential", action})
+		})
+		require.NoError(t, err)
+
+		return result
+	}
+
+	request := "protocol=https\nhost=example.com\npath=team/repo.git\n"
+	approved := request + "username=alice\npassword=first\n\n"
+
+	assert.Nil(t, helper("store", approved))
+	assert.Equal(t, gitCredential{Username: "alice", Password: "first"}, helper("get", request+"\n"))
+
+	assert.Nil(t, helper("erase", approved))
+	assert.Nil(t, helper("get", request+"\n"), "rejected credential must not come back")
+}
@@ -0,0 +1,266 @@
+package executor
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"google.golang.org/grpc/codes"
+
+	secretModel "secretKeeper/internal/client/model/secret"
+	"secretKeeper/pkg/importer"
+)
+
+// importedKinds - are kinds of entries by types of secrets they are imported as.
+var importedKinds = map[int]importer.Kind{
+	typeLoginPass: importer.KindLogin,
+	typeText:      importer.KindText,
+	typeBinary:    importer.KindBinary,
+	typeCard:      importer.KindCard,
+}
+
+// importItem - is a row of "import" result.
+type importItem struct {
+	Title  string `json:"title"`
+	Kind   string `json:"kind"`
+	Folder string `json:"folder,omitempty"`
+	Login  string `json:"login,omitempty"`
+	// Duplicate - secret with the same title and login exists or precedes it in the file, so it isn't imported.
+	Duplicate bool `json:"duplicate"`
+}
+
+// importReport - is a result of "import" command.
+type importReport struct {
+	DryRun     bool         `json:"dry_run"`
+	Imported   int          `json:"imported"`
+	Duplicates int          `json:"duplicates"`
+	Items      []importItem `json:"items"`
+}
+
+// WriteTable - writes a row per secret of the file and a summary.
+func (r importReport) WriteTable(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+	if len(r.Items) > 0 {
+		fmt.Fprintln(tw, "TITLE\tTYPE\tFOLDER\tLOGIN\tACTION")
+	}
+
+	for _, item := range r.Items {
+		action := "create"
+		if item.Duplicate {
+			action = "skip, duplicate"
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", item.Title, item.Kind, item.Folder, item.Login, action)
+	}
+
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	summary := fmt.Sprintf("%d secrets are imported, %d duplicates are skipped", r.Imported, r.Duplicates)
+	if r.DryRun {
+		summary = fmt.Sprintf("dry run: %d secrets would be imported, %d duplicates would be skipped",
+			len(r.Items)-r.Duplicates, r.Duplicates)
+	}
+
+	_, err := fmt.Fprintln(w, summary)
+
+	return err
+}
+
+// importSecrets - is executor for "import" case in Execute method.
+//
+// KDBX database is opened by --key-file and password, which is asked for only if the database can't be opened
+// without it. Secrets are created in batches, so large export takes a few requests.
+func (e *Executor) importSecrets(in input) (importReport, error) {
+	data, err := os.ReadFile(in.str("path"))
+	if err != nil {
+		return importReport{}, &commandError{msg: fmt.Sprintf("error: %v", err), code: codes.NotFound}
+	}
+
+	opts := importer.Options{Mapping: make(map[string]string)}
+
+	for _, m := range in.options("map") {
+		f, column, ok := strings.Cut(m, "=")
+		if !ok || f == "" || column == "" {
+			return importReport{}, validationError("--map must be field=Column, got %s", m)
+		}
+
+		opts.Mapping[strings.ToLower(f)] = column
+	}
+
+	if path := in.option("key-file"); path != "" {
+		if opts.KeyFile, err = os.ReadFile(path); err != nil {
+			return importReport{}, &commandError{msg: fmt.Sprintf("error: %v", err), code: codes.NotFound}
+		}
+	}
+
+	entries, errParse := importer.Parse(in.str("format"), data, opts)
+	if errors.Is(errParse, importer.ErrPassword) && opts.Password == "" {
+		password, errRead := e.readSecret(argSpec{name: "password", label: "Password of the database", secret: true})
+		if errRead != nil {
+			return importReport{}, errRead
+		}
+
+		opts.Password = password
+		entries, errParse = importer.Parse(in.str("format"), data, opts)
+	}
+
+	if errParse != nil {
+		return importReport{}, validationError("%v", errParse)
+	}
+
+	existing, errExisting := e.existingSecretKeys()
+	if errExisting != nil {
+		return importReport{}, errExisting
+	}
+
+	report := importReport{DryRun: in.flag("dry-run"), Items: make([]importItem, 0, len(entries))}
+
+	var secrets []secretModel.NewSecret
+
+	for _, entry := range entries {
+		created, errMap := importedSecrets(entry)
+		if errMap != nil {
+			return importReport{}, errMap
+		}
+
+		for _, secret := range created {
+			item := importItem{Title: secret.Title, Kind: string(importedKinds[secret.Type]), Folder: entry.Folder}
+			if secret.Type == typeLoginPass {
+				item.Login = entry.Login
+			}
+
+			key := secretKey(secret.Type, secret.Title, item.Login)
+			if existing[key] {
+				item.Duplicate = true
+				report.Duplicates++
+			} else {
+				existing[key] = true
+				secrets = append(secrets, secret)
+			}
+
+			report.Items = append(report.Items, item)
+		}
+	}
+
+	if report.DryRun || len(secrets) == 0 {
+		return report, nil
+	}
+
+	imported, errCreate := e.app.SecretService.CreateSecrets(secrets)
+	if errCreate != nil {
+		if imported > 0 {
+			fmt.Fprintf(os.Stderr, "%d of %d secrets are imported before error\n", imported, len(secrets))
+		}
+
+		return importReport{}, errCreate
+	}
+
+	report.Imported = imported
+
+	return report, nil
+}
+
+// importedSecrets - maps entry onto secrets, notes of login and card entries are kept in a separate text secret, as
+// those types have no notes.
+func importedSecrets(entry importer.Entry) ([]secretModel.NewSecret, error) {
+	var (
+		secret   interface{}
+		typeID   int
+		expiry   secretModel.Expiry
+		withNote bool
+	)
+
+	switch entry.Kind {
+	case importer.KindLogin:
+		typeID, withNote = typeLoginPass, true
+		secret = secretModel.LoginPassSecret{
+			Title: entry.Title, RecordType: typeID, Login: entry.Login, Password: entry.Password, URL: entry.URL,
+		}
+	case importer.KindCard:
+		typeID, withNote = typeCard, true
+		card := secretModel.CardSecret{
+			Title: entry.Title, RecordType: typeID, CardNumber: entry.CardNumber, CVV: entry.CVV, Due: entry.Due,
+		}
+
+		// card reminds about itself before it stops being valid, like created one does
+		if due, ok := card.DueTime(); ok {
+			expiry.ExpiresAt = &due
+		}
+
+		secret = card
+	case importer.KindText:
+		typeID = typeText
+		secret = secretModel.TextSecret{Title: entry.Title, RecordType: typeID, Text: entry.Notes}
+	case importer.KindBinary:
+		// binary secret is its raw content
+		return []secretModel.NewSecret{{Title: entry.Title, Type: typeBinary, Content: string(entry.Data)}}, nil
+	default:
+		return nil, fmt.Errorf("entry %q has unknown kind %q", entry.Title, entry.Kind)
+	}
+
+	content, err := json.Marshal(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	secrets := []secretModel.NewSecret{{Title: entry.Title, Type: typeID, Content: string(content), Expiry: expiry}}
+
+	if withNote && entry.Notes != "" {
+		note := secretModel.TextSecret{Title: entry.Title + " (notes)", RecordType: typeText, Text: entry.Notes}
+
+		noteContent, errNote := json.Marshal(note)
+		if errNote != nil {
+			return nil, errNote
+		}
+
+		secrets = append(secrets, secretModel.NewSecret{Title: note.Title, Type: typeText, Content: string(noteContent)})
+	}
+
+	return secrets, nil
+}
+
+// existingSecretKeys - returns keys of secrets of active vault, personal or organization one.
+func (e *Executor) existingSecretKeys() (map[string]bool, error) {
+	logins := make(map[int]string)
+
+	loginPass, err := e.loginPassSecrets()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, secret := range loginPass {
+		logins[secret.Id] = secret.Login
+	}
+
+	keys := make(map[string]bool)
+
+	for _, typeID := range []int{typeLoginPass, typeText, typeBinary, typeCard} {
+		list, errList := e.app.SecretService.GetListOfSecretes(typeID)
+		if errList != nil {
+			return nil, errList
+		}
+
+		for _, secret := range list {
+			// deleted secrets are zeroed in memory storage and flagged by server
+			if secret.Id == 0 || secret.IsDelited {
+				continue
+			}
+
+			keys[secretKey(typeID, secret.Title, logins[int(secret.Id)])] = true
+		}
+	}
+
+	return keys, nil
+}
+
+// secretKey - is key of secret for duplicate detection, titles are compared case-insensitively.
+func secretKey(typeID int, title, login string) string {
+	return fmt.Sprintf("%d\x00%s\x00%s", typeID, strings.ToLower(strings.TrimSpace(title)), login)
+}
@@ -0,0 +1,45 @@
+package executor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportSecrets_DeletedSecretIsNotDuplicate(t *testing.T) {
+	server := newFakeServer()
+
+	path := filepath.Join(t.TempDir(), "export.csv")
+	require.NoError(t, os.WriteFile(path, []byte("name,username,password\nmail,alice,p@ss\n"), 0o600))
+
+	importCSV := func() importReport {
+		result, err := newTestExecutor(t, server).execute([]string{"import", "csv", path})
+		require.NoError(t, err)
+
+		return result.(importReport)
+	}
+
+	// secrets are created and deleted by other processes
+	loggedIn := func() *Executor {
+		e := newTestExecutor(t, server)
+		require.NoError(t, e.restoreSession())
+
+		return e
+	}
+
+	id, err := loggedIn().createLoginPass("mail", "alice", "old", "")
+	require.NoError(t, err)
+
+	report := importCSV()
+	assert.Equal(t, 1, report.Duplicates, "existing secret is a duplicate")
+	assert.Equal(t, 0, report.Imported)
+
+	require.NoError(t, loggedIn().app.SecretService.DeleteSecret(int(id)))
+
+	report = importCSV()
+	assert.Equal(t, 0, report.Duplicates, "deleted secret is not a duplicate")
+	assert.Equal(t, 1, report.Imported)
+}
@@ -0,0 +1,309 @@
+package executor
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"text/template"
+
+	"google.golang.org/grpc/codes"
+
+	"secretKeeper/internal/client/prompt/output"
+)
+
+// defaultInjectMode - is permission of rendered file unless --mode is set, it is readable only by its owner.
+const defaultInjectMode = 0o600
+
+// templateRender - is rendered template with IDs of secrets it references.
+type templateRender struct {
+	content []byte
+	refs    map[int]bool
+}
+
+// inject - is executor for "inject" case in Execute method.
+//
+// With --watch REPL keeps the file up to date in background until exit, subcommand mode does it until interrupted.
+func (e *Executor) inject(in input) (interface{}, error) {
+	mode := os.FileMode(defaultInjectMode)
+
+	if value := in.option("mode"); value != "" {
+		parsed, err := strconv.ParseUint(value, 8, 32)
+		if err != nil || parsed&^0o777 != 0 {
+			return nil, validationError("--mode must be octal permission, e.g. 0600")
+		}
+
+		mode = os.FileMode(parsed)
+	}
+
+	tplPath, outPath := in.str("template"), in.str("out")
+
+	// sync keeps personal secrets only, so changes of vault secrets would never reach the file
+	if in.flag("watch") && e.app.SecretService.VaultID() != 0 {
+		return nil, validationError("--watch follows personal secrets only, switch to them by use-vault 0 or render " +
+			"secrets of the vault without --watch")
+	}
+
+	render, err := e.renderTemplate(tplPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if errWrite := writeFileAtomic(outPath, render.content, mode); errWrite != nil {
+		return nil, errWrite
+	}
+
+	message := fmt.Sprintf("%s is rendered to %s", tplPath, outPath)
+
+	if !in.flag("watch") {
+		return output.Message{Message: message}, nil
+	}
+
+	watch := func(ctx context.Context) error {
+		return e.watchTemplate(ctx, tplPath, outPath, mode, render)
+	}
+
+	if e.interactive {
+		if errStart := e.startBackground("inject "+outPath, watch); errStart != nil {
+			return nil, errStart
+		}
+
+		return output.Message{Message: message + ", watching secrets until exit"}, nil
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	fmt.Fprintln(os.Stderr, message+", watching secrets until interrupted")
+
+	if errWatch := watch(ctx); errWatch != nil {
+		return nil, errWatch
+	}
+
+	return output.Message{Message: "watch is stopped"}, nil
+}
+
+// watchTemplate - re-renders template when sync reports a change of a referenced secret.
+//
+// Sync is scheduled by Cron of the App, which the REPL runs while logged in and unlocked, and subcommand mode runs for
+// the watch. Only personal secrets kept in memory storage, i.e. login/pass, text and card, are seen by sync. File is
+// written only if rendered content differs, and failed renders keep previous file.
+func (e *Executor) watchTemplate(ctx context.Context, tplPath, outPath string, mode os.FileMode,
+	render templateRender) error {
+	changes := make(chan []int)
+
+	stopWatch := e.app.Syncer.Watch(func(ids []int) {
+		select {
+		case changes <- ids:
+		case <-ctx.Done():
+		}
+	})
+	defer stopWatch()
+
+	if !e.interactive {
+		e.app.Cron.Start()
+		defer func() { <-e.app.Cron.Stop().Done() }()
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case ids := <-changes:
+			// changes are of personal secrets, while the REPL may have switched to a vault since
+			if !referencesAny(render.refs, ids) || e.app.SecretService.VaultID() != 0 {
+				continue
+			}
+
+			next, err := e.renderTemplate(tplPath)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "error in rendering %s: %v\n", tplPath, err)
+
+				continue
+			}
+
+			if !bytes.Equal(next.content, render.content) {
+				if errWrite := writeFileAtomic(outPath, next.content, mode); errWrite != nil {
+					fmt.Fprintln(os.Stderr, errWrite)
+
+					continue
+				}
+			}
+
+			render = next
+		}
+	}
+}
+
+// referencesAny - reports whether any of ids is referenced.
+func referencesAny(refs map[int]bool, ids []int) bool {
+	for _, id := range ids {
+		if refs[id] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// renderTemplate - renders template file, {{ secret "title" "field" }} and {{ secret 12 "field" }} are replaced by
+// fields of secrets, field may be omitted for secrets without fields.
+func (e *Executor) renderTemplate(path string) (templateRender, error) {
+	source, err := os.ReadFile(path)
+	if err != nil {
+		return templateRender{}, &commandError{msg: fmt.Sprintf("error: %v", err), code: codes.NotFound}
+	}
+
+	render := templateRender{refs: make(map[int]bool)}
+	resolved := make(map[int]secretResult)
+
+	var titles map[string][]int
+
+	secretFunc := func(ref interface{}, field ...string) (string, error) {
+		var id int
+
+		switch value := ref.(type) {
+		case int:
+			id = value
+		case string:
+			parsed, errAtoi := strconv.Atoi(value)
+			if errAtoi == nil {
+				id = parsed
+
+				break
+			}
+
+			if titles == nil {
+				loaded, errTitles := e.secretTitles()
+				if errTitles != nil {
+					return "", errTitles
+				}
+
+				titles = loaded
+			}
+
+			ids := titles[value]
+
+			switch len(ids) {
+			case 0:
+				return "", &commandError{msg: fmt.Sprintf("error: secret %q is not found", value), code: codes.NotFound}
+			case 1:
+				id = ids[0]
+			default:
+				return "", validationError("title %q is shared by secrets %s, reference one by ID", value, joinInts(ids))
+			}
+		default:
+			return "", validationError("secret must be referenced by title or ID, got %v", ref)
+		}
+
+		if len(field) > 1 {
+			return "", validationError("secret takes one field, got %d", len(field))
+		}
+
+		secret, ok := resolved[id]
+		if !ok {
+			fetched, errSecret := e.secretResult(id)
+			if errSecret != nil {
+				return "", errSecret
+			}
+
+			secret, resolved[id] = fetched, fetched
+		}
+
+		render.refs[id] = true
+
+		switch {
+		case len(field) == 1:
+			result, errField := secretField(secret, field[0])
+
+			return result.Value, errField
+		case secret.Fields == nil:
+			return secret.Content, nil
+		default:
+			return "", validationError("field of secret %d must be set, e.g. {{ secret %d \"password\" }}", id, id)
+		}
+	}
+
+	tpl, errParse := template.New(filepath.Base(path)).
+		Funcs(template.FuncMap{"secret": secretFunc}).
+		Option("missingkey=error").
+		Parse(string(source))
+	if errParse != nil {
+		return templateRender{}, validationError("%v", errParse)
+	}
+
+	var buf bytes.Buffer
+
+	if errExec := tpl.Execute(&buf, nil); errExec != nil {
+		return templateRender{}, errExec
+	}
+
+	render.content = buf.Bytes()
+
+	return render, nil
+}
+
+// secretTitles - returns IDs of secrets of every type by title.
+func (e *Executor) secretTitles() (map[string][]int, error) {
+	types, err := e.cachedTypes()
+	if err != nil {
+		return nil, err
+	}
+
+	titles := make(map[string][]int)
+
+	for _, t := range types {
+		list, errList := e.app.SecretService.GetListOfSecretes(t.ID)
+		if errList != nil {
+			return nil, errList
+		}
+
+		for _, secret := range list {
+			// deleted secrets are zeroed in memory storage and flagged by server
+			if secret.Id == 0 || secret.IsDelited {
+				continue
+			}
+
+			titles[secret.Title] = append(titles[secret.Title], int(secret.Id))
+		}
+	}
+
+	return titles, nil
+}
+
+// writeFileAtomic - writes file with mode by renaming temporary file, so readers never see it partially written
+// and it is never readable by others, even for a moment.
+func writeFileAtomic(path string, data []byte, mode os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("error in creating file: %w", err)
+	}
+
+	defer os.Remove(tmp.Name())
+
+	if errChmod := tmp.Chmod(mode); errChmod != nil {
+		tmp.Close()
+
+		return fmt.Errorf("error in setting file permissions: %w", errChmod)
+	}
+
+	if _, errWrite := tmp.Write(data); errWrite != nil {
+		tmp.Close()
+
+		return fmt.Errorf("error in writing file: %w", errWrite)
+	}
+
+	if errClose := tmp.Close(); errClose != nil {
+		return fmt.Errorf("error in writing file: %w", errClose)
+	}
+
+	if errRename := os.Rename(tmp.Name(), path); errRename != nil {
+		return fmt.Errorf("error in writing file: %w", errRename)
+	}
+
+	return nil
+}
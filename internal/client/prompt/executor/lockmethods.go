@@ -0,0 +1,128 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"google.golang.org/grpc/codes"
+
+	"secretKeeper/internal/client/storage"
+)
+
+// autoLockJob - is name of background job locking the vault after SECRETKEEPER_LOCK_TIMEOUT of inactivity.
+const autoLockJob = "auto-lock"
+
+// errLocked - is returned by commands which need keys while the vault is locked.
+var errLocked = &commandError{msg: "error: vault is locked, unlock it by unlock", code: codes.Unauthenticated}
+
+// lock - is executor for "lock" case in Execute method.
+func (e *Executor) lock() error {
+	if !e.interactive {
+		return validationError("lock works in the prompt, subcommand mode keeps no keys between commands, use logout")
+	}
+
+	return e.lockVault()
+}
+
+// unlock - is executor for "unlock" case in Execute method.
+//
+// Key pair is derived from password again, session is kept, so nothing is asked from server but secrets to sync.
+func (e *Executor) unlock(in input) error {
+	if !e.interactive {
+		return validationError("unlock works in the prompt, subcommand mode keeps no keys between commands")
+	}
+
+	if err := e.app.UserService.Unlock(in.str("password")); err != nil {
+		switch {
+		case errors.Is(err, storage.ErrNotLocked):
+			return &commandError{msg: "error: " + err.Error(), code: codes.FailedPrecondition}
+		case errors.Is(err, storage.ErrWrongPassword):
+			return &commandError{msg: "error: " + err.Error(), code: codes.Unauthenticated}
+		default:
+			return err
+		}
+	}
+
+	e.syncAll()
+	e.app.Cron.Start()
+
+	return nil
+}
+
+// lockVault - zeroes keys, drops decrypted secrets kept in memory and clears clipboard, sync is paused until unlock,
+// as nothing could be decrypted.
+func (e *Executor) lockVault() error {
+	if err := e.app.UserService.Lock(); err != nil {
+		return err
+	}
+
+	// running sync must not fill storage after it is reset
+	<-e.app.Cron.Stop().Done()
+
+	e.app.Storage.ResetStorage()
+	e.stopJob(clipboardJob)
+
+	return nil
+}
+
+// startAutoLock - starts background job locking the vault after SECRETKEEPER_LOCK_TIMEOUT seconds without commands,
+// the job of previous login keeps serving the next one.
+func (e *Executor) startAutoLock() {
+	timeout := time.Duration(e.app.Config.LockTimeout) * time.Second
+	if timeout <= 0 {
+		return
+	}
+
+	e.touch(false)
+
+	// the error only reports that the job is already running
+	_ = e.startBackground(autoLockJob, func(ctx context.Context) error {
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+			}
+
+			if err := e.lockIdle(timeout); err != nil {
+				return err
+			}
+		}
+	})
+}
+
+// lockIdle - locks the vault if no command runs and none has run for timeout.
+func (e *Executor) lockIdle(timeout time.Duration) error {
+	// a command typed meanwhile waits until the vault is locked, so it sees the lock
+	e.idleMu.Lock()
+	defer e.idleMu.Unlock()
+
+	if e.running || time.Since(e.lastActivity) < timeout ||
+		!e.app.UserService.IsLogged() || e.app.UserService.IsLocked() {
+		return nil
+	}
+
+	if err := e.lockVault(); err != nil {
+		return err
+	}
+
+	// stdout is left to results of commands, the notice comes from background job
+	fmt.Fprintf(os.Stderr, "\nvault is locked after %s of inactivity, unlock it by unlock\n", timeout)
+
+	return nil
+}
+
+// touch - records activity of REPL, the vault isn't locked while a command runs.
+func (e *Executor) touch(running bool) {
+	e.idleMu.Lock()
+	defer e.idleMu.Unlock()
+
+	e.running = running
+	e.lastActivity = time.Now()
+}
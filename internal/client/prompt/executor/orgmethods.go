@@ -0,0 +1,142 @@
+package executor
+
+import (
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"secretKeeper/internal/client/prompt/output"
+)
+
+// createOrg - is executor for "create-org" case in Execute method.
+func (e *Executor) createOrg(in input) (createdResult, error) {
+	org, err := e.app.OrganizationService.CreateOrganization(in.str("title"))
+	if err != nil {
+		return createdResult{}, err
+	}
+
+	return createdResult{ID: org.Id, Message: fmt.Sprint("created new organization with ID: ", org.Id)}, nil
+}
+
+// orgs - is executor for "orgs" case in Execute method.
+func (e *Executor) orgs() ([]orgItem, error) {
+	orgs, err := e.app.OrganizationService.List()
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]orgItem, 0, len(orgs))
+	for _, org := range orgs {
+		items = append(items, orgItem{ID: org.Id, Title: org.Title, Role: org.Role})
+	}
+
+	return items, nil
+}
+
+// orgMembers - is executor for "org-members" case in Execute method.
+func (e *Executor) orgMembers(in input) ([]memberItem, error) {
+	id := in.int("org")
+
+	members, err := e.app.OrganizationService.ListMembers(id)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]memberItem, 0, len(members))
+	for _, member := range members {
+		items = append(items, memberItem{Login: member.Login, Role: member.Role})
+	}
+
+	return items, nil
+}
+
+// addMember - is executor for "add-member" case in Execute method.
+func (e *Executor) addMember(in input) error {
+	id := in.int("org")
+
+	if err := e.app.OrganizationService.AddMember(id, in.str("login"), in.str("role")); err != nil {
+		return orgError(err)
+	}
+
+	return nil
+}
+
+// removeMember - is executor for "remove-member" case in Execute method.
+func (e *Executor) removeMember(in input) error {
+	id := in.int("org")
+
+	if err := e.app.OrganizationService.RemoveMember(id, in.str("login")); err != nil {
+		return orgError(err)
+	}
+
+	return nil
+}
+
+// createVault - is executor for "create-vault" case in Execute method.
+func (e *Executor) createVault(in input) (createdResult, error) {
+	id := in.int("org")
+
+	vault, err := e.app.OrganizationService.CreateVault(id, in.str("title"))
+	if err != nil {
+		return createdResult{}, orgError(err)
+	}
+
+	return createdResult{ID: vault.Id, Message: fmt.Sprint("created new vault with ID: ", vault.Id)}, nil
+}
+
+// vaults - is executor for "vaults" case in Execute method.
+func (e *Executor) vaults(in input) ([]vaultItem, error) {
+	id := in.int("org")
+
+	vaults, err := e.app.OrganizationService.ListVaults(id)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]vaultItem, 0, len(vaults))
+	for _, vault := range vaults {
+		items = append(items, vaultItem{
+			ID:               vault.Id,
+			Title:            vault.Title,
+			KeyVersion:       vault.KeyVersion,
+			RotationRequired: vault.RotationRequired,
+		})
+	}
+
+	return items, nil
+}
+
+// useVault - is executor for "use-vault" case in Execute method.
+func (e *Executor) useVault(in input) (output.Message, error) {
+	id := in.int("vault")
+
+	if err := e.app.OrganizationService.UseVault(id); err != nil {
+		return output.Message{}, orgError(err)
+	}
+
+	if err := e.persistSession(); err != nil {
+		return output.Message{}, err
+	}
+
+	if id == 0 {
+		return output.Message{Message: "switched to personal secrets"}, nil
+	}
+
+	return output.Message{Message: fmt.Sprint("switched to vault ", id)}, nil
+}
+
+// orgError - converts gRPC errors of organization methods to human-readable errors.
+func orgError(err error) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	switch st.Code() {
+	case codes.PermissionDenied, codes.NotFound, codes.FailedPrecondition, codes.InvalidArgument:
+		return statusError(st)
+	default:
+		return err
+	}
+}
@@ -0,0 +1,119 @@
+package executor
+
+import (
+	"errors"
+	"sort"
+
+	"google.golang.org/grpc/codes"
+
+	"secretKeeper/internal/client/app"
+	"secretKeeper/internal/client/config"
+)
+
+// profileItem - is a row of "profile list" result.
+type profileItem struct {
+	Name    string `json:"name"`
+	Address string `json:"address"`
+	Output  string `json:"output,omitempty"`
+	Sync    string `json:"sync_interval,omitempty"`
+	Current bool   `json:"current"`
+}
+
+// loadApp - creates App of profile on the first command of subcommand mode, REPL keeps App it has started with until
+// "profile use".
+func (e *Executor) loadApp(profile string) error {
+	if e.app != nil {
+		if profile != "" && profile != e.app.Config.Profile {
+			return validationError("--profile works in subcommand mode, switch the prompt by profile use %s", profile)
+		}
+
+		return nil
+	}
+
+	appL, err := app.NewApp(profile)
+	if err != nil {
+		return profileError(err)
+	}
+
+	e.app = appL
+
+	return nil
+}
+
+// profile - is executor for "profile" case in Execute method.
+func (e *Executor) profile(in input) (interface{}, error) {
+	switch in.str("action") {
+	case "list":
+		return e.listProfiles()
+	case "use":
+		if !in.has("name") {
+			return nil, validationError("name of profile is missing, usage: profile use <name>")
+		}
+
+		return nil, e.useProfile(in.str("name"))
+	default:
+		return nil, validationError("unknown subcommand %s, use list or use", in.str("action"))
+	}
+}
+
+// listProfiles - returns profiles of config file sorted by name.
+func (e *Executor) listProfiles() ([]profileItem, error) {
+	file, err := config.ReadProfiles(e.app.Config.ConfigPath)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]profileItem, 0, len(file.Profiles))
+	for name, p := range file.Profiles {
+		item := profileItem{Name: name, Address: p.Address, Output: p.Output, Current: name == e.app.Config.Profile}
+		if p.SyncInterval != 0 {
+			item.Sync = p.SyncInterval.String()
+		}
+
+		items = append(items, item)
+	}
+
+	sort.Slice(items, func(i, j int) bool { return items[i].Name < items[j].Name })
+
+	return items, nil
+}
+
+// useProfile - makes profile current one of config file, the prompt also switches to it, so the user of the previous
+// profile is logged out, as their keys and secrets must not be mixed with those of another server.
+func (e *Executor) useProfile(name string) error {
+	if err := config.UseProfile(e.app.Config.ConfigPath, name); err != nil {
+		return profileError(err)
+	}
+
+	if !e.interactive {
+		return nil
+	}
+
+	appL, err := app.NewApp(name)
+	if err != nil {
+		return profileError(err)
+	}
+
+	e.stopBackground()
+
+	e.app.UserService.Logout()
+	e.app.Storage.ResetStorage()
+
+	if errClose := e.app.Close(); errClose != nil {
+		return errClose
+	}
+
+	e.app = appL
+	e.secretTypes = nil
+
+	return nil
+}
+
+// profileError - reports unknown profile as not found and invalid config file as invalid argument.
+func profileError(err error) error {
+	if errors.Is(err, config.ErrProfile) {
+		return &commandError{msg: "error: " + err.Error(), code: codes.NotFound}
+	}
+
+	return &commandError{msg: "error: " + err.Error(), code: codes.InvalidArgument}
+}
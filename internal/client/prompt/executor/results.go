@@ -0,0 +1,316 @@
+package executor
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	secretModel "secretKeeper/internal/client/model/secret"
+	pb "secretKeeper/proto"
+)
+
+// commandItem - is a row of "help" result.
+type commandItem struct {
+	Name        string `json:"name"`
+	Usage       string `json:"usage"`
+	Description string `json:"description"`
+}
+
+// createdResult - is a result of commands which create an object with ID.
+type createdResult struct {
+	ID      uint32 `json:"id"`
+	Message string `json:"message"`
+}
+
+// WriteTable - writes message of createdResult.
+func (c createdResult) WriteTable(w io.Writer) error {
+	_, err := fmt.Fprintln(w, c.Message)
+
+	return err
+}
+
+// warnedMessage - is a result of a command which succeeded with a warning, e.g. about breached password.
+type warnedMessage struct {
+	// ID - is ID of created object, if the command creates one.
+	ID      uint32 `json:"id,omitempty"`
+	Message string `json:"message"`
+	Warning string `json:"warning,omitempty"`
+}
+
+// WriteTable - writes message and warning of warnedMessage on separate lines.
+func (m warnedMessage) WriteTable(w io.Writer) error {
+	if _, err := fmt.Fprintln(w, m.Message); err != nil {
+		return err
+	}
+
+	if m.Warning == "" {
+		return nil
+	}
+
+	_, err := fmt.Fprintln(w, m.Warning)
+
+	return err
+}
+
+// loginResult - is a result of "login" command with reminders about due secrets.
+type loginResult struct {
+	Message string     `json:"message"`
+	Due     []dueEvent `json:"due,omitempty"`
+}
+
+// WriteTable - writes message and reminders of loginResult.
+func (l loginResult) WriteTable(w io.Writer) error {
+	if _, err := fmt.Fprintln(w, l.Message); err != nil {
+		return err
+	}
+
+	return dueEvents(l.Due).writeLines(w)
+}
+
+// recoveryShares - is a result of "recovery split" command.
+type recoveryShares struct {
+	Threshold int      `json:"threshold"`
+	Shares    []string `json:"shares"`
+}
+
+// WriteTable - writes shares separated by headers, so they are easy to cut apart.
+func (r recoveryShares) WriteTable(w io.Writer) error {
+	n := len(r.Shares)
+
+	fmt.Fprintf(w, "recovery key is split, any %d of these %d shares restore it. Store them separately.\n", r.Threshold, n)
+
+	for i, share := range r.Shares {
+		if _, err := fmt.Fprintf(w, "\n----- recovery share %d of %d -----\n%s\n", i+1, n, share); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// secretTypeItem - is a row of "types" result.
+type secretTypeItem struct {
+	ID    int    `json:"id"`
+	Title string `json:"title"`
+}
+
+// secretItem - is a row of "get-secrets-by-type" result.
+type secretItem struct {
+	ID    int    `json:"id"`
+	Title string `json:"title"`
+}
+
+// secretResult - is a result of "get-secret" command.
+type secretResult struct {
+	ID        int       `json:"id"`
+	UpdatedAt time.Time `json:"updated_at"`
+	// Fields - are fields of secret stored as JSON, Content is set instead for any other secret.
+	Fields  map[string]interface{} `json:"fields,omitempty"`
+	Content string                 `json:"content,omitempty"`
+}
+
+// WriteTable - writes fields of secretResult sorted by name, or its content.
+func (s secretResult) WriteTable(w io.Writer) error {
+	if s.Fields == nil {
+		_, err := fmt.Fprintln(w, s.Content)
+
+		return err
+	}
+
+	names := make([]string, 0, len(s.Fields))
+	for name := range s.Fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	for _, name := range names {
+		fmt.Fprintf(tw, "%s:\t%v\n", name, s.Fields[name])
+	}
+
+	return tw.Flush()
+}
+
+// fieldResult - is a result of "get-secret" command with --field option.
+type fieldResult struct {
+	Field string `json:"field"`
+	Value string `json:"value"`
+}
+
+// WriteTable - writes bare value of the field, so it could be piped.
+func (f fieldResult) WriteTable(w io.Writer) error {
+	_, err := fmt.Fprintln(w, f.Value)
+
+	return err
+}
+
+// dueEvent - is a reminder about a secret which expires or needs rotation.
+type dueEvent struct {
+	ID      uint32    `json:"id"`
+	VaultID uint32    `json:"vault_id,omitempty"`
+	Title   string    `json:"title"`
+	Kind    string    `json:"kind"`
+	DueAt   time.Time `json:"due_at"`
+}
+
+// dueEvents - is a result of "due" command.
+type dueEvents []dueEvent
+
+// WriteTable - writes a line per reminder.
+func (d dueEvents) WriteTable(w io.Writer) error {
+	if len(d) == 0 {
+		_, err := fmt.Fprintln(w, "nothing is due")
+
+		return err
+	}
+
+	return d.writeLines(w)
+}
+
+// writeLines - writes a line per reminder, nothing is written for no reminders.
+func (d dueEvents) writeLines(w io.Writer) error {
+	for _, event := range d {
+		if _, err := fmt.Fprintln(w, formatDueEvent(event)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// sendResult - is a result of "send" command.
+type sendResult struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// WriteTable - writes token of sendResult with a hint.
+func (s sendResult) WriteTable(w io.Writer) error {
+	_, err := fmt.Fprintf(w, "send is available until %s, pass this token to recipient:\n%s\n",
+		s.ExpiresAt.Local().Format("2006-01-02 15:04"), s.Token)
+
+	return err
+}
+
+// openedSend - is a result of "open-send" command.
+type openedSend struct {
+	Content   string `json:"content"`
+	ViewsLeft int    `json:"views_left"`
+}
+
+// WriteTable - writes content of openedSend and warns once the send is destroyed.
+func (o openedSend) WriteTable(w io.Writer) error {
+	if o.ViewsLeft == 0 {
+		fmt.Fprintln(w, "this was the last view, send is destroyed")
+	}
+
+	_, err := fmt.Fprintf(w, "Content:%+v\n", o.Content)
+
+	return err
+}
+
+// orgItem - is a row of "orgs" result.
+type orgItem struct {
+	ID    uint32 `json:"id"`
+	Title string `json:"title"`
+	Role  string `json:"role"`
+}
+
+// memberItem - is a row of "org-members" result.
+type memberItem struct {
+	Login string `json:"login"`
+	Role  string `json:"role"`
+}
+
+// vaultItem - is a row of "vaults" result.
+type vaultItem struct {
+	ID               uint32 `json:"id"`
+	Title            string `json:"title"`
+	KeyVersion       uint32 `json:"key_version"`
+	RotationRequired bool   `json:"rotation_required"`
+}
+
+// contactItem - is a row of "contacts" and "grantors" results.
+type contactItem struct {
+	Login       string     `json:"login"`
+	WaitHours   uint32     `json:"wait_hours"`
+	Status      string     `json:"status"`
+	RequestedAt *time.Time `json:"requested_at,omitempty"`
+	AvailableAt *time.Time `json:"available_at,omitempty"`
+}
+
+// emergencySecretItem - is a row of "emergency-secrets" result.
+type emergencySecretItem struct {
+	ID        int                    `json:"id"`
+	Title     string                 `json:"title"`
+	Type      int                    `json:"type"`
+	UpdatedAt time.Time              `json:"updated_at"`
+	Fields    map[string]interface{} `json:"fields,omitempty"`
+	Content   string                 `json:"content,omitempty"`
+}
+
+// newContactItem - converts emergency contact to contactItem.
+func newContactItem(contact *pb.EmergencyContact) contactItem {
+	return contactItem{
+		Login:       contact.Login,
+		WaitHours:   contact.WaitHours,
+		Status:      contact.Status,
+		RequestedAt: optionalTime(contact.RequestedAt),
+		AvailableAt: optionalTime(contact.AvailableAt),
+	}
+}
+
+// newContactItems - converts emergency contacts to contactItem rows.
+func newContactItems(contacts []*pb.EmergencyContact) []contactItem {
+	items := make([]contactItem, 0, len(contacts))
+	for _, contact := range contacts {
+		items = append(items, newContactItem(contact))
+	}
+
+	return items
+}
+
+// newEmergencySecretItems - converts decoded secrets of another user to emergencySecretItem rows.
+func newEmergencySecretItems(secrets []secretModel.EmergencySecret) []emergencySecretItem {
+	items := make([]emergencySecretItem, 0, len(secrets))
+	for _, secret := range secrets {
+		fields, content := decodeContent(secret.Content)
+
+		items = append(items, emergencySecretItem{
+			ID:        secret.Id,
+			Title:     secret.Title,
+			Type:      secret.Type,
+			UpdatedAt: secret.UpdatedAt,
+			Fields:    fields,
+			Content:   content,
+		})
+	}
+
+	return items
+}
+
+// decodeContent - returns fields of secret content stored as JSON object, or the content itself otherwise.
+func decodeContent(content string) (map[string]interface{}, string) {
+	var fields map[string]interface{}
+	if err := json.Unmarshal([]byte(content), &fields); err != nil || fields == nil {
+		return nil, content
+	}
+
+	return fields, ""
+}
+
+// optionalTime - converts optional timestamp to time, nil stands for unset one.
+func optionalTime(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+
+	t := ts.AsTime()
+
+	return &t
+}
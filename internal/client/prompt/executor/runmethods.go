@@ -0,0 +1,160 @@
+package executor
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"os/signal"
+	"regexp"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"google.golang.org/grpc/codes"
+
+	"secretKeeper/pkg/redact"
+)
+
+// envNamePattern - is a name of environment variable accepted by POSIX shells.
+var envNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// secretRef - is a reference to a field of secret, e.g. secret://12/password.
+type secretRef struct {
+	id    int
+	field string
+}
+
+// parseSecretRef - parses secret://%id%/%field% reference, field may be omitted for secrets which have no fields.
+func parseSecretRef(value string) (secretRef, error) {
+	u, err := url.Parse(value)
+	if err != nil || u.Scheme != "secret" {
+		return secretRef{}, validationError("%s is not a secret://%%id%%/%%field%% reference", value)
+	}
+
+	id, errID := strconv.Atoi(u.Host)
+	if errID != nil || id <= 0 {
+		return secretRef{}, validationError("secret ID of %s must be a positive number", value)
+	}
+
+	return secretRef{id: id, field: strings.Trim(u.Path, "/")}, nil
+}
+
+// runEnv - resolves NAME=secret://... options to environment variables, every secret is requested once.
+func (e *Executor) runEnv(options []string) ([]string, []string, error) {
+	env := make([]string, 0, len(options))
+	values := make([]string, 0, len(options))
+	resolved := make(map[int]secretResult)
+
+	for _, option := range options {
+		name, value, ok := strings.Cut(option, "=")
+		if !ok || !envNamePattern.MatchString(name) {
+			return nil, nil, validationError("--env must be NAME=secret://%%id%%/%%field%%, got %s", option)
+		}
+
+		ref, err := parseSecretRef(value)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		secret, cached := resolved[ref.id]
+		if !cached {
+			if secret, err = e.secretResult(ref.id); err != nil {
+				return nil, nil, err
+			}
+
+			resolved[ref.id] = secret
+		}
+
+		var resolvedValue string
+
+		switch {
+		case ref.field != "":
+			field, errField := secretField(secret, ref.field)
+			if errField != nil {
+				return nil, nil, errField
+			}
+
+			resolvedValue = field.Value
+		case secret.Fields == nil:
+			resolvedValue = secret.Content
+		default:
+			return nil, nil, validationError("field of secret %d must be set, e.g. secret://%d/password", ref.id, ref.id)
+		}
+
+		env = append(env, name+"="+resolvedValue)
+		values = append(values, resolvedValue)
+	}
+
+	return env, values, nil
+}
+
+// runCommand - is executor for "run" case in Execute method.
+//
+// Secrets are passed to the child process only by its environment, so they are never written to disk, and they are
+// masked in its stdout and stderr. REPL doesn't pass its terminal input to the child.
+func (e *Executor) runCommand(in input) error {
+	env, values, err := e.runEnv(in.options("env"))
+	if err != nil {
+		return err
+	}
+
+	argv := in.list("command")
+
+	stdout, stderr := redact.NewWriter(os.Stdout, values), redact.NewWriter(os.Stderr, values)
+
+	cmd := exec.Command(argv[0], argv[1:]...)
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdout, cmd.Stderr = stdout, stderr
+
+	if !e.interactive {
+		cmd.Stdin = os.Stdin
+	}
+
+	if errStart := cmd.Start(); errStart != nil {
+		if errors.Is(errStart, exec.ErrNotFound) || errors.Is(errStart, os.ErrNotExist) {
+			return &commandError{msg: fmt.Sprintf("error: %v", errStart), code: codes.NotFound}
+		}
+
+		return errStart
+	}
+
+	// terminal delivers interrupt to the child itself, termination is forwarded, so the child may clean up
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
+
+	done := make(chan struct{})
+	defer close(done)
+	defer signal.Stop(signals)
+
+	go func() {
+		for {
+			select {
+			case sig := <-signals:
+				if sig != os.Interrupt {
+					_ = cmd.Process.Signal(sig)
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	errWait := cmd.Wait()
+
+	stdout.Close()
+	stderr.Close()
+
+	var exitErr *exec.ExitError
+	if errors.As(errWait, &exitErr) {
+		// like shells do, killed child is reported as 128 plus signal number
+		if status, ok := exitErr.Sys().(syscall.WaitStatus); ok && status.Signaled() {
+			return &childExitError{code: 128 + int(status.Signal())}
+		}
+
+		return &childExitError{code: exitErr.ExitCode()}
+	}
+
+	return errWait
+}
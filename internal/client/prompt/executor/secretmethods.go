@@ -5,151 +5,177 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
-
-	secretModel "secretKeeper/internal/client/model/secret"
-	"strconv"
 	"strings"
+	"time"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+
+	secretModel "secretKeeper/internal/client/model/secret"
+	"secretKeeper/pkg/totp"
 )
 
 // createAuth - is executor for "create-auth" case in Execute method.
-func (e *Executor) createAuth(args []string) error {
-	switch len(args) - 1 {
-	case 2:
-		return fmt.Errorf("validation error: Password is missing")
-	case 1:
-		return fmt.Errorf("validation error: Login and Password is missing")
-	case 0:
-		return fmt.Errorf("validation error: Title, Login, Password is missing")
-	}
+func (e *Executor) createAuth(in input) (uint32, error) {
+	return e.createLoginPass(in.str("title"), in.str("login"), in.str("password"), in.option("url"))
+}
 
+// createLoginPass - creates new login/pass secret, url is optional. ID of created secret is returned.
+func (e *Executor) createLoginPass(title, login, password, url string) (uint32, error) {
 	m := secretModel.LoginPassSecret{
-		Title:      args[1],
+		Title:      title,
 		RecordType: 1,
-		Login:      args[2],
-		Password:   args[3],
+		Login:      login,
+		Password:   password,
+		URL:        url,
 	}
 
 	cont, errMarshal := json.Marshal(m)
 	if errMarshal != nil {
-		return errMarshal
+		return 0, errMarshal
 	}
 
-	if err := e.app.SecretService.CreateSecret(m.Title, 1, string(cont)); err != nil {
-		return err
-	}
-
-	return nil
+	return e.app.SecretService.CreateSecret(m.Title, 1, string(cont), secretModel.Expiry{})
 }
 
 // createText - is executor for "create-text" case in Execute method.
-func (e *Executor) createText(args []string) error {
-	switch len(args) - 1 {
-	case 1:
-		return fmt.Errorf("validation error: Text is missing")
-	case 0:
-		return fmt.Errorf("validation error: Title and Text is missing")
-	}
-
+func (e *Executor) createText(in input) (uint32, error) {
 	m := secretModel.TextSecret{
-		Title:      args[1],
+		Title:      in.str("title"),
 		RecordType: 2,
-		Text:       strings.Join(args[2:], " "),
+		Text:       in.str("text"),
 	}
 
 	marshal, errMarshal := json.Marshal(m)
 	if errMarshal != nil {
-		return errMarshal
+		return 0, errMarshal
 	}
 
-	if err := e.app.SecretService.CreateSecret(m.Title, 2, string(marshal)); err != nil {
-		return err
-	}
-
-	return nil
+	return e.app.SecretService.CreateSecret(m.Title, 2, string(marshal), secretModel.Expiry{})
 }
 
 // createBinary - is executor for "create-binary" case in Execute method.
-func (e *Executor) createBinary(args []string) error {
-	switch len(args) - 1 {
-	case 1:
-		return fmt.Errorf("validation error: Filepath is missing")
-	case 0:
-		return fmt.Errorf("validation error: Title and Filepath is missing")
-	}
-
+func (e *Executor) createBinary(in input) (uint32, error) {
 	m := secretModel.FileSecret{
-		Title:      args[1],
+		Title:      in.str("title"),
 		RecordType: 3,
-		Path:       args[2],
+		Path:       in.str("path"),
 	}
 
 	f, err := os.Open(m.Path)
 	if err != nil {
-		return err
+		return 0, err
 	}
 	defer f.Close()
 
 	data, errData := ioutil.ReadAll(f)
 	if errData != nil {
-		return errData
-	}
-
-	errCreate := e.app.SecretService.CreateSecret(m.Title, m.RecordType, string(data))
-	if errCreate != nil {
-		return errCreate
+		return 0, errData
 	}
 
-	return nil
+	return e.app.SecretService.CreateSecret(m.Title, m.RecordType, string(data), secretModel.Expiry{})
 }
 
 // createCard - is executor for "create-card" case in Execute method.
-func (e *Executor) createCard(args []string) error {
-	switch len(args) - 1 {
-	case 3:
-		return fmt.Errorf("validation error: Due date is missing")
-	case 2:
-		return fmt.Errorf("validation error: CVV and Due date is missing")
-	case 1:
-		return fmt.Errorf("validation error: Card number, CVV, Due date is missing")
-	case 0:
-		return fmt.Errorf("validation error: Title, Card number, CVV, Due date is missing")
-	}
-
+func (e *Executor) createCard(in input) (uint32, error) {
 	cardModel := secretModel.CardSecret{
-		Title:      args[1],
+		Title:      in.str("title"),
 		RecordType: 4,
-		CardNumber: args[2],
-		CVV:        args[3],
-		Due:        args[4],
+		CardNumber: in.str("number"),
+		CVV:        in.str("cvv"),
+		Due:        in.str("due"),
 	}
 
 	cont, er := json.Marshal(cardModel)
 	if er != nil {
-		return er
+		return 0, er
 	}
 
-	if err := e.app.SecretService.CreateSecret(cardModel.Title, cardModel.RecordType, string(cont)); err != nil {
-		return err
+	// card reminds about itself before it stops being valid
+	var expiry secretModel.Expiry
+	if due, ok := cardModel.DueTime(); ok {
+		expiry.ExpiresAt = &due
 	}
 
-	return nil
+	return e.app.SecretService.CreateSecret(cardModel.Title, cardModel.RecordType, string(cont), expiry)
 }
 
-// deleteSecret - is executor for "delete-secret" case in Execute method.
-func (e *Executor) deleteSecret(args []string) error {
-	switch len(args) - 1 {
-	case 0:
-		return fmt.Errorf("validation error: Secret ID is missing")
+// createTOTP - is executor for "create-totp" case in Execute method.
+func (e *Executor) createTOTP(in input) (uint32, error) {
+	key, err := parseTOTP(in.str("seed"))
+	if err != nil {
+		return 0, err
+	}
+
+	if in.option("digits") != "" {
+		key.Digits = in.optionInt("digits")
+	}
+
+	if in.option("period") != "" {
+		key.Period = in.optionInt("period")
+	}
+
+	if in.option("algorithm") != "" {
+		key.Algorithm = strings.ToUpper(in.option("algorithm"))
+	}
+
+	if errValidate := key.Validate(); errValidate != nil {
+		return 0, validationError("%v", errValidate)
+	}
+
+	m := secretModel.NewTOTPSecret(in.str("title"), key)
+
+	cont, errMarshal := json.Marshal(m)
+	if errMarshal != nil {
+		return 0, errMarshal
+	}
+
+	return e.app.SecretService.CreateSecret(m.Title, m.RecordType, string(cont), secretModel.Expiry{})
+}
+
+// parseTOTP - parses TOTP key from otpauth:// URI or from base32 seed with default parameters.
+func parseTOTP(value string) (totp.Key, error) {
+	var (
+		key totp.Key
+		err error
+	)
+
+	if strings.HasPrefix(value, "otpauth://") {
+		key, err = totp.ParseURI(value)
+	} else {
+		key, err = totp.NewKey(value)
+	}
+
+	if err != nil {
+		return totp.Key{}, validationError("%v", err)
+	}
+
+	return key, nil
+}
+
+// withTOTPCode - adds current code and seconds it has left to fields of TOTP secret, other secrets are kept as is.
+func withTOTPCode(content string, fields map[string]interface{}) map[string]interface{} {
+	var m secretModel.TOTPSecret
+	if fields == nil || json.Unmarshal([]byte(content), &m) != nil || m.RecordType != 5 {
+		return fields
 	}
 
-	id, convErr := strconv.Atoi(args[1])
-	if convErr != nil {
-		return convErr
+	now := time.Now()
+
+	code, err := m.Key().Code(now)
+	if err != nil {
+		return fields
 	}
 
+	fields["Code"], fields["SecondsLeft"] = code, m.Key().Remaining(now)
+
+	return fields
+}
+
+// deleteSecret - is executor for "delete-secret" case in Execute method.
+func (e *Executor) deleteSecret(in input) error {
+	id := in.int("id")
+
 	if err := e.app.SecretService.DeleteSecret(id); err != nil {
 		return err
 	}
@@ -158,15 +184,10 @@ func (e *Executor) deleteSecret(args []string) error {
 }
 
 // getSecretsByTypeId - is executor for "get-secrets-by-type" case in Execute method.
-func (e *Executor) getSecretsByTypeId(args []string) ([]secretModel.SecretList, error) {
-	switch len(args) - 1 {
-	case 0:
-		return nil, fmt.Errorf("validation error: Secret Type ID is missing")
-	}
-
-	id, convErr := strconv.Atoi(args[1])
-	if convErr != nil {
-		return nil, convErr
+func (e *Executor) getSecretsByTypeId(in input) ([]secretItem, error) {
+	id, err := e.secretTypeID(in.str("type"))
+	if err != nil {
+		return nil, err
 	}
 
 	list, err := e.app.SecretService.GetListOfSecretes(id)
@@ -174,10 +195,10 @@ func (e *Executor) getSecretsByTypeId(args []string) ([]secretModel.SecretList,
 		return nil, err
 	}
 
-	var models []secretModel.SecretList
+	models := make([]secretItem, 0, len(list))
 	for _, secret := range list {
-		models = append(models, secretModel.SecretList{
-			Id:    int(secret.Id),
+		models = append(models, secretItem{
+			ID:    int(secret.Id),
 			Title: secret.Title,
 		})
 	}
@@ -186,46 +207,45 @@ func (e *Executor) getSecretsByTypeId(args []string) ([]secretModel.SecretList,
 }
 
 // getSecret - is executor for "get-secret" case in Execute method.
-func (e *Executor) getSecret(args []string) (string, error) {
-	switch len(args) - 1 {
-	case 0:
-		return "", fmt.Errorf("validation error: Secret ID is missing")
-	}
+func (e *Executor) getSecret(in input) (secretResult, error) {
+	return e.secretResult(in.int("id"))
+}
 
-	id, convErr := strconv.Atoi(args[1])
-	if convErr != nil {
-		return "", convErr
+// secretResult - requests secret and decodes its fields.
+func (e *Executor) secretResult(id int) (secretResult, error) {
+	secret, err := e.fetchSecret(id)
+	if err != nil {
+		return secretResult{}, err
 	}
 
+	fields, content := decodeContent(secret.Content)
+
+	fields = withTOTPCode(secret.Content, fields)
+
+	return secretResult{ID: id, UpdatedAt: secret.UpdatedAt, Fields: fields, Content: content}, nil
+}
+
+// fetchSecret - requests decoded secret, missing secret is reported as NotFound.
+func (e *Executor) fetchSecret(id int) (secretModel.ResSecret, error) {
 	secret, err := e.app.SecretService.GetSecret(id)
 	if err != nil {
 		st, _ := status.FromError(err)
 		switch st.Code() {
 		case codes.NotFound:
-			return "", fmt.Errorf(st.Message())
+			return secretModel.ResSecret{}, &commandError{msg: st.Message(), code: codes.NotFound}
 		default:
-			return "", err
+			return secretModel.ResSecret{}, err
 		}
 	}
 
-	return secret.Content, nil
+	return secret, nil
 }
 
 // getSecretBinary - is executor for "get-secret-binary" case in Execute method.
-func (e *Executor) getSecretBinary(args []string) error {
-	switch len(args) - 1 {
-	case 0:
-		return fmt.Errorf("validation error: Secret ID and Path is missing")
-	case 1:
-		return fmt.Errorf("validation error: Path is missing")
-	}
+func (e *Executor) getSecretBinary(in input) error {
+	id := in.int("id")
 
-	id, errConv := strconv.Atoi(args[1])
-	if errConv != nil {
-		return errConv
-	}
-
-	err := e.app.SecretService.GetBinarySecret(id, args[2])
+	err := e.app.SecretService.GetBinarySecret(id, in.str("path"))
 	if err != nil {
 		return err
 	}
@@ -233,116 +253,192 @@ func (e *Executor) getSecretBinary(args []string) error {
 	return nil
 }
 
+// editFieldArgs - are arguments of "edit-secret" command which hold fields of a secret by its type.
+var editFieldArgs = map[int][]argSpec{
+	1: {
+		{name: "login", label: "Login"},
+		{name: "password", label: "Password", secret: true, confirm: true, generated: true},
+	},
+	2: {{name: "text", label: "Text", variadic: true}},
+	3: {{name: "path", label: "Filepath"}},
+	4: {
+		{name: "number", label: "Card number"},
+		{name: "cvv", label: "CVV", secret: true, confirm: true},
+		{name: "due", label: "Due date"},
+	},
+	5: {{name: "seed", label: "Seed or otpauth URI", secret: true}},
+}
+
 // editSecret - is executor for "edit-secret" case in Execute method.
-func (e *Executor) editSecret(args []string, isForce bool) error {
-	var (
-		recordType int
-		id         int
-		converted  []byte
-	)
+func (e *Executor) editSecret(in input, isForce bool) error {
+	id, title, recordType := in.int("id"), in.str("title"), in.int("type")
+
+	specs, ok := editFieldArgs[recordType]
+	if !ok {
+		return validationError("unknown Secret Type ID %d, see types", recordType)
+	}
+
+	fields, errFields := parseInput(specs, nil, in.list("fields"))
+	if errFields != nil {
+		return errFields
+	}
 
-	numArgs := len(args) - 1
-	if numArgs >= 3 {
-		recordType, errConv := strconv.Atoi(args[3])
-		if errConv != nil {
-			return errConv
+	// generator options belong to the command itself
+	fields.opts = in.opts
+
+	if err := e.readSecrets(specs, fields); err != nil {
+		return err
+	}
+
+	var secret interface{}
+
+	switch recordType {
+	case 1:
+		url, ok := in.opts["url"]
+		// url is kept unless it is changed
+		if stored, found, _ := e.app.Storage.GetLoginPassSecret(id); !ok && found {
+			url = stored.URL
 		}
 
-		id, errConv = strconv.Atoi(args[1])
-		if errConv != nil {
-			return errConv
+		secret = secretModel.LoginPassSecret{
+			Id:         id,
+			Title:      title,
+			RecordType: 1,
+			Login:      fields.str("login"),
+			Password:   fields.str("password"),
+			URL:        url,
+		}
+	case 2:
+		secret = secretModel.TextSecret{Id: id, Title: title, RecordType: 2, Text: fields.str("text")}
+	case 3:
+		secret = secretModel.FileSecret{Id: id, Title: title, RecordType: 3, Path: fields.str("path")}
+	case 4:
+		secret = secretModel.CardSecret{
+			Id:         id,
+			Title:      title,
+			RecordType: 4,
+			CardNumber: fields.str("number"),
+			CVV:        fields.str("cvv"),
+			Due:        fields.str("due"),
+		}
+	case 5:
+		key, err := parseTOTP(fields.str("seed"))
+		if err != nil {
+			return err
 		}
 
-		switch recordType {
-		case 1:
-			switch numArgs {
-			case 4:
-				return fmt.Errorf("validation error: Password is missing")
-			case 3:
-				return fmt.Errorf("validation error: Login and Password is missing")
-			default:
-				converted, errConv = json.Marshal(secretModel.LoginPassSecret{
-					Id:         id,
-					Title:      args[2],
-					RecordType: 1,
-					Login:      args[4],
-					Password:   args[5],
-				})
-				if errConv != nil {
-					return errConv
-				}
-			}
-		case 2:
-			switch numArgs {
-			case 3:
-				return fmt.Errorf("validation error: Text is missing")
-			default:
-				converted, errConv = json.Marshal(secretModel.TextSecret{
-					Id:         id,
-					Title:      args[2],
-					RecordType: 2,
-					Text:       strings.Join(args[4:], " "),
-				})
-				if errConv != nil {
-					return errConv
-				}
-			}
-		case 3:
-			switch numArgs {
-			case 3:
-				return fmt.Errorf("validation error: Filepath is missing")
-			default:
-				converted, errConv = json.Marshal(secretModel.FileSecret{
-					Id:         id,
-					Title:      args[2],
-					RecordType: 3,
-					Path:       args[3],
-				})
-				if errConv != nil {
-					return errConv
-				}
-			}
-		case 4:
-			switch numArgs {
-			case 5:
-				return fmt.Errorf("validation error: Due date is missing")
-			case 4:
-				return fmt.Errorf("validation error: CVV and Due date is missing")
-			case 3:
-				return fmt.Errorf("validation error: Card number, CVV and Due date is missing")
-			default:
-				converted, errConv = json.Marshal(secretModel.CardSecret{
-					Id:         id,
-					Title:      args[2],
-					RecordType: 4,
-					CardNumber: args[4],
-					CVV:        args[5],
-					Due:        args[6],
-				})
-				if errConv != nil {
-					return errConv
-				}
+		m := secretModel.NewTOTPSecret(title, key)
+		m.Id = id
+		secret = m
+	}
+
+	converted, errConv := json.Marshal(secret)
+	if errConv != nil {
+		return errConv
+	}
+
+	return e.updateSecret(id, title, recordType, string(converted), isForce)
+}
+
+// updateSecret - saves edited secret, local secrets are re-synced if they are out of date.
+func (e *Executor) updateSecret(id int, title string, recordType int, content string, isForce bool) error {
+	if err := e.app.SecretService.EditSecret(id, title, recordType, content, isForce); err != nil {
+		st, _ := status.FromError(err)
+
+		if st.Code() == codes.FailedPrecondition {
+			if errSync := e.app.Syncer.SyncAll(); errSync != nil {
+				return &commandError{msg: st.Message() + ", re-sync of local secrets failed: " + errSync.Error(), code: st.Code()}
 			}
+
+			return &commandError{msg: st.Message() + ", local secrets are re-synced", code: st.Code()}
 		}
-	} else {
-		return fmt.Errorf("validation error: Secret ID, Title, Secret Type ID and secret fields is missing")
+
+		return &commandError{msg: st.Message(), code: st.Code()}
+	}
+
+	return nil
+}
+
+// setExpiry - is executor for "set-expiry" case in Execute method.
+func (e *Executor) setExpiry(in input, isAutoExpire bool) error {
+	id := in.int("id")
+
+	expiry := secretModel.Expiry{AutoExpire: isAutoExpire}
+
+	if in.str("date") != "never" {
+		expiresAt, errParse := time.ParseInLocation("2006-01-02", in.str("date"), time.Local)
+		if errParse != nil {
+			return validationError("Expiry date must be in YYYY-MM-DD format or never")
+		}
+
+		expiry.ExpiresAt = &expiresAt
+	}
+
+	days := in.int("days")
+	if days < 0 {
+		return validationError("Rotation period must be a number of days")
 	}
 
-	if err := e.app.SecretService.EditSecret(id, args[3], recordType, string(converted), isForce); err != nil {
+	expiry.RotateEveryDays = days
+
+	if err := e.app.SecretService.SetExpiry(id, expiry); err != nil {
 		st, _ := status.FromError(err)
 
-		fmt.Println(st.Message())
+		switch st.Code() {
+		case codes.NotFound, codes.InvalidArgument, codes.PermissionDenied:
+			return statusError(st)
+		default:
+			return err
+		}
+	}
 
-		if st.Code() == codes.FailedPrecondition {
-			fmt.Println("starting re-sync")
+	return nil
+}
 
-			e.app.Syncer.SyncAll()
+// due - is executor for "due" case in Execute method.
+func (e *Executor) due() (dueEvents, error) {
+	events, err := e.app.SecretService.DueEvents()
+	if err != nil {
+		return nil, err
+	}
 
-			fmt.Println("re-sync ended")
+	result := make(dueEvents, 0, len(events))
+	for _, event := range events {
+		result = append(result, dueEvent{
+			ID:      event.SecretId,
+			VaultID: event.VaultId,
+			Title:   event.Title,
+			Kind:    event.Kind,
+			DueAt:   event.DueAt.AsTime(),
+		})
+	}
+
+	return result, nil
+}
+
+// formatDueEvent - formats reminder about a secret for output.
+func formatDueEvent(event dueEvent) string {
+	var state string
+
+	switch event.Kind {
+	case "expiring":
+		if event.DueAt.Before(time.Now()) {
+			state = "expired at"
+		} else {
+			state = "expires at"
 		}
+	case "expired":
+		state = "moved to trash, expired at"
+	case "rotation":
+		state = "needs rotation since"
+	default:
+		state = event.Kind
+	}
 
-		return nil
+	line := fmt.Sprintf("ID:%v Title: %v %s %s", event.ID, event.Title, state, event.DueAt.Local().Format("2006-01-02 15:04"))
+	if event.VaultID != 0 {
+		line += fmt.Sprintf(" Vault: %v", event.VaultID)
 	}
 
-	return nil
+	return line
 }
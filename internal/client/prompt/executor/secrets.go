@@ -0,0 +1,115 @@
+package executor
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// readSecrets - fills secret arguments omitted from command line or passed as "-".
+//
+// Arguments declared as generated are produced by password generator if --generate is set.
+//
+// With --stdin they are read from standard input line by line in order of declaration, otherwise through masked
+// terminal prompt.
+func (e *Executor) readSecrets(specs []argSpec, in input) error {
+	for _, spec := range specs {
+		if !spec.secret {
+			continue
+		}
+
+		passed := in.has(spec.name) && in.str(spec.name) != "-"
+
+		if spec.generated && in.flag("generate") {
+			if passed {
+				return validationError("%s can't be passed together with --generate", spec.label)
+			}
+
+			generated, err := runGenerator(in)
+			if err != nil {
+				return err
+			}
+
+			e.generated = &generated
+			in.args[spec.name] = []string{generated.Value}
+
+			continue
+		}
+
+		if passed {
+			continue
+		}
+
+		value, err := e.readSecret(spec)
+		if err != nil {
+			return err
+		}
+
+		in.args[spec.name] = []string{value}
+	}
+
+	return nil
+}
+
+// readSecret - reads value of secret argument from stdin or through masked prompt.
+func (e *Executor) readSecret(spec argSpec) (string, error) {
+	if e.stdin {
+		if e.stdinReader == nil {
+			e.stdinReader = bufio.NewReader(os.Stdin)
+		}
+
+		line, err := e.stdinReader.ReadString('\n')
+		if err != nil && (!errors.Is(err, io.EOF) || line == "") {
+			return "", validationError("%s is missing in standard input", spec.label)
+		}
+
+		return strings.TrimRight(line, "\r\n"), nil
+	}
+
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return "", validationError("%s is missing, pass it as argument or with --stdin", spec.label)
+	}
+
+	value, err := readMasked(fd, spec.label+": ")
+	if err != nil {
+		return "", err
+	}
+
+	if value == "" {
+		return "", validationError("%s is empty", spec.label)
+	}
+
+	if spec.confirm {
+		repeated, errRepeat := readMasked(fd, "Repeat "+spec.label+": ")
+		if errRepeat != nil {
+			return "", errRepeat
+		}
+
+		if repeated != value {
+			return "", validationError("%s values do not match", spec.label)
+		}
+	}
+
+	return value, nil
+}
+
+// readMasked - prints prompt to stderr and reads a line from terminal without echo.
+func readMasked(fd int, prompt string) (string, error) {
+	fmt.Fprint(os.Stderr, prompt)
+
+	value, err := term.ReadPassword(fd)
+
+	fmt.Fprintln(os.Stderr)
+
+	if err != nil {
+		return "", fmt.Errorf("error in reading from terminal: %w", err)
+	}
+
+	return string(value), nil
+}
@@ -0,0 +1,11 @@
+package executor
+
+// IDs of secret types, entries of other tools are mapped onto the first four.
+const (
+	typeLoginPass = 1
+	typeText      = 2
+	typeBinary    = 3
+	typeCard      = 4
+	typeTOTP      = 5
+	typeSSH       = 6
+)
@@ -0,0 +1,59 @@
+package executor
+
+import (
+	"errors"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"secretKeeper/internal/client/service"
+)
+
+// send - is executor for "send" case in Execute method.
+func (e *Executor) send(in input) (sendResult, error) {
+	minutes := in.int("ttl")
+	if minutes < 0 {
+		return sendResult{}, validationError("Time to live must be a number of minutes, 0 stands for a day")
+	}
+
+	views := in.int("views")
+	if views < 0 {
+		return sendResult{}, validationError("Views must be a number, 0 stands for a single view")
+	}
+
+	token, expiresAt, err := e.app.SendService.Send(
+		in.str("text"), time.Duration(minutes)*time.Minute, views,
+	)
+	if err != nil {
+		return sendResult{}, sendError(err)
+	}
+
+	return sendResult{Token: token, ExpiresAt: expiresAt}, nil
+}
+
+// openSend - is executor for "open-send" case in Execute method.
+func (e *Executor) openSend(in input) (openedSend, error) {
+	content, viewsLeft, err := e.app.SendService.Open(in.str("token"))
+	if err != nil {
+		return openedSend{}, sendError(err)
+	}
+
+	return openedSend{Content: content, ViewsLeft: viewsLeft}, nil
+}
+
+// sendError - converts grpc errors of one-time sends to readable ones.
+func sendError(err error) error {
+	if errors.Is(err, service.ErrInvalidSendToken) {
+		return validationError("%v", err)
+	}
+
+	st, _ := status.FromError(err)
+
+	switch st.Code() {
+	case codes.NotFound, codes.InvalidArgument, codes.Unauthenticated:
+		return statusError(st)
+	default:
+		return err
+	}
+}
@@ -0,0 +1,111 @@
+package executor
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"secretKeeper/internal/client/config"
+	"secretKeeper/internal/client/storage"
+)
+
+func TestSession_KeysAreNotCached(t *testing.T) {
+	server := newFakeServer()
+	path := filepath.Join(t.TempDir(), "session.json")
+
+	// run - executes command in a new process authorized by session file, password comes from environment
+	run := func(password string, tokens ...string) (interface{}, error) {
+		e := newTestExecutor(t, server)
+		e.app.Config = config.Config{Password: password}
+		e.app.Session = storage.NewSessionFile(path)
+
+		return e.execute(tokens)
+	}
+
+	_, err := run("", "login", "alice", "password")
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	var cached map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &cached))
+	assert.ElementsMatch(t, []string{"login", "token", "public_key", "vault_id"}, keys(cached))
+
+	created, err := run("password", "create-text", "Note", "hello")
+	require.NoError(t, err)
+
+	id := fmt.Sprint(created.(createdResult).ID)
+
+	got, err := run("password", "get-secret", id, "--field", "text")
+	require.NoError(t, err)
+	assert.Contains(t, fmt.Sprint(got), "hello")
+
+	withStdin(t, "password\n", func() {
+		got, err = run("", "get-secret", id, "--field", "text", "--stdin")
+	})
+	require.NoError(t, err)
+	assert.Contains(t, fmt.Sprint(got), "hello")
+
+	_, err = run("", "get-secret", id)
+	assert.ErrorContains(t, err, "Password is missing")
+
+	_, err = run("wrong", "get-secret", id)
+	assert.ErrorContains(t, err, storage.ErrWrongPassword.Error())
+
+	// logout needs no keys, so no password is asked
+	_, err = run("", "logout")
+	require.NoError(t, err)
+	assert.NoFileExists(t, path)
+}
+
+func TestSession_ExpiredSessionIsRemoved(t *testing.T) {
+	server := newFakeServer()
+	path := filepath.Join(t.TempDir(), "session.json")
+
+	e := newTestExecutor(t, server)
+	e.app.Config = config.Config{}
+	e.app.Session = storage.NewSessionFile(path)
+
+	_, err := e.execute([]string{"login", "alice", "password"})
+	require.NoError(t, err)
+	require.FileExists(t, path)
+
+	server.expired = true
+
+	e = newTestExecutor(t, server)
+	e.app.Config = config.Config{Password: "password"}
+	e.app.Session = storage.NewSessionFile(path)
+
+	_, err = e.execute([]string{"types"})
+	assert.ErrorContains(t, err, "session has expired")
+	assert.NoFileExists(t, path)
+}
+
+func TestSession_PrivateKeyOfOlderVersionIsRemoved(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"login":"alice","token":"token","private_key":"AAAA"}`), 0o600))
+
+	e := newTestExecutor(t, newFakeServer())
+	e.app.Config = config.Config{Password: "password"}
+	e.app.Session = storage.NewSessionFile(path)
+
+	_, err := e.execute([]string{"types"})
+	assert.ErrorIs(t, err, storage.ErrNoSession)
+	assert.NoFileExists(t, path)
+}
+
+// keys - returns keys of JSON object.
+func keys(object map[string]interface{}) []string {
+	result := make([]string, 0, len(object))
+	for key := range object {
+		result = append(result, key)
+	}
+
+	return result
+}
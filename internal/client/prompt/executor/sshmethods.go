@@ -0,0 +1,230 @@
+package executor
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"google.golang.org/grpc/codes"
+
+	secretModel "secretKeeper/internal/client/model/secret"
+	"secretKeeper/internal/client/prompt/output"
+	"secretKeeper/pkg/sshagent"
+)
+
+// sshKeyResult - is a result of "generate-ssh-key" and "import-ssh-key" commands.
+type sshKeyResult struct {
+	ID        uint32 `json:"id"`
+	Message   string `json:"message"`
+	PublicKey string `json:"public_key"`
+}
+
+// WriteTable - writes message and public key, so it could be copied to authorized_keys.
+func (s sshKeyResult) WriteTable(w io.Writer) error {
+	_, err := fmt.Fprintf(w, "%s\n%s\n", s.Message, s.PublicKey)
+
+	return err
+}
+
+// generateSSHKey - is executor for "generate-ssh-key" case in Execute method.
+func (e *Executor) generateSSHKey(in input) (sshKeyResult, error) {
+	comment := in.option("comment")
+	if comment == "" {
+		comment = in.str("title")
+	}
+
+	key, err := sshagent.GenerateKey(in.option("type"), in.optionInt("bits"), comment)
+	if err != nil {
+		if errors.Is(err, sshagent.ErrKeyType) || errors.Is(err, sshagent.ErrKeyBits) {
+			return sshKeyResult{}, validationError("%v", err)
+		}
+
+		return sshKeyResult{}, err
+	}
+
+	return e.createSSHKey(in, key, comment)
+}
+
+// importSSHKey - is executor for "import-ssh-key" case in Execute method.
+//
+// Passphrase of encrypted key is asked like secret arguments, so it could be passed with --stdin.
+func (e *Executor) importSSHKey(in input) (sshKeyResult, error) {
+	data, err := os.ReadFile(in.str("path"))
+	if err != nil {
+		return sshKeyResult{}, &commandError{msg: fmt.Sprintf("error: %v", err), code: codes.NotFound}
+	}
+
+	comment := in.option("comment")
+	if comment == "" {
+		comment = in.str("title")
+	}
+
+	key, errImport := sshagent.ImportKey(data, "", comment)
+	if errors.Is(errImport, sshagent.ErrPassphrase) {
+		passphrase, errRead := e.readSecret(argSpec{name: "passphrase", label: "Passphrase of the key", secret: true})
+		if errRead != nil {
+			return sshKeyResult{}, errRead
+		}
+
+		key, errImport = sshagent.ImportKey(data, passphrase, comment)
+	}
+
+	if errImport != nil {
+		return sshKeyResult{}, validationError("%v", errImport)
+	}
+
+	return e.createSSHKey(in, key, comment)
+}
+
+// createSSHKey - stores generated or imported key as SSH secret.
+func (e *Executor) createSSHKey(in input, key sshagent.Key, comment string) (sshKeyResult, error) {
+	m := secretModel.SSHSecret{
+		Title:      in.str("title"),
+		RecordType: typeSSH,
+		PrivateKey: key.PrivateKey,
+		PublicKey:  key.PublicKey,
+		Comment:    comment,
+		Confirm:    in.flag("confirm"),
+	}
+
+	cont, err := json.Marshal(m)
+	if err != nil {
+		return sshKeyResult{}, err
+	}
+
+	id, errCreate := e.app.SecretService.CreateSecret(m.Title, m.RecordType, string(cont), secretModel.Expiry{})
+	if errCreate != nil {
+		return sshKeyResult{}, errCreate
+	}
+
+	return sshKeyResult{
+		ID:        id,
+		Message:   fmt.Sprintf("created new secret with ID: %d, public key:", id),
+		PublicKey: key.PublicKey,
+	}, nil
+}
+
+// sshIdentities - loads SSH keys of the vault, nothing is served until user is logged in.
+//
+// It's called by connections of the agent, so login, logout or switch of profile waits until keys are loaded.
+func (e *Executor) sshIdentities() ([]sshagent.Identity, error) {
+	e.sessionMu.RLock()
+	defer e.sessionMu.RUnlock()
+
+	if !e.app.UserService.IsLogged() {
+		return nil, nil
+	}
+
+	list, err := e.app.SecretService.GetListOfSecretes(typeSSH)
+	if err != nil {
+		return nil, err
+	}
+
+	identities := make([]sshagent.Identity, 0, len(list))
+
+	for _, listed := range list {
+		if listed.IsDelited {
+			continue
+		}
+
+		stored, errFetch := e.fetchSecret(int(listed.Id))
+		if errFetch != nil {
+			return nil, errFetch
+		}
+
+		var m secretModel.SSHSecret
+		if errDecode := json.Unmarshal([]byte(stored.Content), &m); errDecode != nil {
+			return nil, errDecode
+		}
+
+		signer, errSigner := sshagent.ParseSigner(m.PrivateKey)
+		if errSigner != nil {
+			return nil, errSigner
+		}
+
+		identities = append(identities, sshagent.Identity{Signer: signer, Comment: m.Comment, Confirm: m.Confirm})
+	}
+
+	return identities, nil
+}
+
+// sshAgent - is executor for "ssh-agent" case in Execute method.
+//
+// REPL serves the agent in background until exit, subcommand mode serves it until interrupted.
+func (e *Executor) sshAgent(in input) (interface{}, error) {
+	path := in.option("socket")
+	if path == "" {
+		path = e.app.Config.SSHAgentSocket
+	}
+
+	l, err := sshagent.Listen(path)
+	if err != nil {
+		return nil, err
+	}
+
+	a := sshagent.NewAgent(e.sshIdentities, e.confirmKeyUse, in.flag("confirm"))
+	hint := fmt.Sprintf("ssh-agent is listening, run: export SSH_AUTH_SOCK=%s", path)
+
+	if e.interactive {
+		errStart := e.startBackground("ssh-agent", func(ctx context.Context) error {
+			return sshagent.Serve(ctx, l, a)
+		})
+		if errStart != nil {
+			l.Close()
+
+			return nil, errStart
+		}
+
+		return output.Message{Message: hint}, nil
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	fmt.Fprintln(os.Stderr, hint)
+
+	if errServe := sshagent.Serve(ctx, l, a); errServe != nil {
+		return nil, errServe
+	}
+
+	return output.Message{Message: "ssh-agent is stopped"}, nil
+}
+
+// confirmKeyUse - asks to confirm use of SSH key by SSH_ASKPASS program like OpenSSH agent does, or on terminal if
+// the agent serves in foreground. Use is denied if neither is available.
+func (e *Executor) confirmKeyUse(comment string) bool {
+	question := fmt.Sprintf("Allow use of key %s?", comment)
+
+	if askpass := os.Getenv("SSH_ASKPASS"); askpass != "" {
+		cmd := exec.Command(askpass, question)
+		cmd.Env = append(os.Environ(), "SSH_ASKPASS_PROMPT=confirm")
+
+		return cmd.Run() == nil
+	}
+
+	if e.interactive {
+		return false
+	}
+
+	tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
+	if err != nil {
+		return false
+	}
+	defer tty.Close()
+
+	fmt.Fprintf(tty, "%s [y/N]: ", question)
+
+	answer, _ := bufio.NewReader(tty).ReadString('\n')
+
+	answer = strings.ToLower(strings.TrimSpace(answer))
+
+	return answer == "y" || answer == "yes"
+}
@@ -0,0 +1,38 @@
+package executor
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSSHIdentities_WhileLoggingInAndOut(t *testing.T) {
+	e := newTestExecutor(t, newFakeServer())
+
+	_, err := e.execute([]string{"login", "alice", "password"})
+	require.NoError(t, err)
+
+	// connections of the agent load keys while the prompt logs in and out, run it with -race
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+
+		for i := 0; i < 20; i++ {
+			_, _ = e.sshIdentities()
+		}
+	}()
+
+	for i := 0; i < 10; i++ {
+		_, err = e.execute([]string{"logout"})
+		require.NoError(t, err)
+
+		_, err = e.execute([]string{"login", "alice", "password"})
+		require.NoError(t, err)
+	}
+
+	wg.Wait()
+}
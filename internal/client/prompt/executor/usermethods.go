@@ -2,68 +2,79 @@ package executor
 
 import (
 	"fmt"
-	"secretKeeper/internal/client/model"
+	"os"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+
+	"secretKeeper/internal/client/model"
+	"secretKeeper/internal/client/prompt/output"
 )
 
 // login - is executor for "login" case in Execute method.
-func (e *Executor) login(args []string) error {
-	switch len(args) - 1 {
-	case 1:
-		return fmt.Errorf("validation error: Password is missing")
-	case 0:
-		return fmt.Errorf("validation error: Login and Password is missing")
-	}
-
-	user := model.User{Login: args[1], Password: args[2]}
+func (e *Executor) login(in input) error {
+	user := model.User{Login: in.str("login"), Password: in.str("password")}
 
 	if err := e.app.UserService.Login(user); err != nil {
 		st, _ := status.FromError(err)
 
 		switch st.Code() {
 		case codes.NotFound:
-			return fmt.Errorf("error: User not found")
+			return &commandError{msg: "error: User not found", code: codes.NotFound}
 		default:
-			return fmt.Errorf("error:" + st.Message())
+			return statusError(st)
 		}
 	}
 
+	return e.startSession()
+}
+
+// startSession - starts sync of logged user in REPL, or caches session in subcommand mode.
+func (e *Executor) startSession() error {
+	if !e.interactive {
+		return e.persistSession()
+	}
+
 	// firstly we sync all on start up
-	e.app.Syncer.SyncAll()
+	e.syncAll()
 
 	// then we spawn goroutin with cron job to sync data every minute
 	go e.app.Cron.Run()
 
+	e.startAutoLock()
+
 	return nil
 }
 
-// register - is executor for "register" case in Execute method.
-func (e *Executor) register(args []string) error {
-	switch len(args) - 1 {
-	case 1:
-		return fmt.Errorf("validation error: Password is missing")
-	case 0:
-		return fmt.Errorf("validation error: Login and Password is missing")
+// syncAll - syncs secrets kept in memory of the prompt, errors go to stderr, as the command itself has succeeded.
+func (e *Executor) syncAll() {
+	if err := e.app.Syncer.SyncAll(); err != nil {
+		fmt.Fprintf(os.Stderr, "sync: %v\n", err)
 	}
+}
 
-	user := model.User{Login: args[1], Password: args[2]}
+// register - is executor for "register" case in Execute method.
+func (e *Executor) register(in input) error {
+	user := model.User{Login: in.str("login"), Password: in.str("password")}
 	if err := e.app.UserService.Register(user); err != nil {
 		switch status.Code(err) {
 		case codes.InvalidArgument:
-			return fmt.Errorf("error: data is invalid or user already exists")
+			return &commandError{msg: "error: data is invalid or user already exists", code: codes.InvalidArgument}
 		default:
 			return err
 		}
 	}
 
-	return nil
+	return e.persistSession()
 }
 
 // deleteUser - is executor for "delete-user" case in Execute method.
 func (e *Executor) deleteUser() error {
-	return e.app.UserService.Delete()
+	if err := e.app.UserService.Delete(); err != nil {
+		return err
+	}
+
+	return e.persistSession()
 }
 
 // logout - is executor for "types" case in Execute method.
@@ -74,5 +85,85 @@ func (e *Executor) logout() error {
 
 	e.app.Storage.ResetStorage()
 
-	return nil
+	return e.persistSession()
+}
+
+// recovery - is executor for "recovery" case in Execute method.
+func (e *Executor) recovery(in input) (interface{}, error) {
+	switch in.str("action") {
+	case "split":
+		sub, err := parseInput(recoverySplitArgs, nil, in.list("args"))
+		if err != nil {
+			return nil, err
+		}
+
+		return e.recoverySplit(sub)
+	case "restore":
+		sub, err := parseInput(recoveryRestoreArgs, nil, in.list("args"))
+		if err != nil {
+			return nil, err
+		}
+
+		if errSecrets := e.readSecrets(recoveryRestoreArgs, sub); errSecrets != nil {
+			return nil, errSecrets
+		}
+
+		return e.recoveryRestore(sub)
+	default:
+		return nil, validationError("unknown subcommand %s, use split or restore", in.str("action"))
+	}
+}
+
+var (
+	// recoverySplitArgs - are arguments of "recovery split" command.
+	recoverySplitArgs = []argSpec{
+		{name: "shares", label: "Shares count", kind: kindInt},
+		{name: "threshold", label: "Threshold", kind: kindInt},
+	}
+	// recoveryRestoreArgs - are arguments of "recovery restore" command.
+	recoveryRestoreArgs = []argSpec{
+		{name: "login", label: "Login"},
+		{name: "password", label: "New password", secret: true, confirm: true},
+		{name: "shares", label: "Shares", variadic: true},
+	}
+)
+
+// recoverySplit - is executor for "recovery split" case in Execute method.
+func (e *Executor) recoverySplit(in input) (recoveryShares, error) {
+	n, m := in.int("shares"), in.int("threshold")
+
+	if err := e.unlockSession(); err != nil {
+		return recoveryShares{}, err
+	}
+
+	shares, err := e.app.UserService.SplitRecovery(n, m)
+	if err != nil {
+		return recoveryShares{}, err
+	}
+
+	return recoveryShares{Threshold: m, Shares: shares}, nil
+}
+
+// recoveryRestore - is executor for "recovery restore" case in Execute method.
+func (e *Executor) recoveryRestore(in input) (output.Message, error) {
+	user := model.User{Login: in.str("login"), Password: in.str("password")}
+
+	if err := e.app.UserService.RestoreRecovery(user, in.list("shares")); err != nil {
+		st, _ := status.FromError(err)
+
+		switch st.Code() {
+		case codes.PermissionDenied, codes.InvalidArgument:
+			return output.Message{}, statusError(st)
+		default:
+			return output.Message{}, err
+		}
+	}
+
+	if err := e.startSession(); err != nil {
+		return output.Message{}, err
+	}
+
+	return output.Message{
+		Message: "password is changed, you are logged in. Vault keys of your organizations have to be rotated.",
+	}, nil
 }
@@ -0,0 +1,260 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Format - is a format results of commands are rendered in.
+type Format string
+
+const (
+	// FormatTable - is human-readable format, which is used by default.
+	FormatTable Format = "table"
+	FormatJSON  Format = "json"
+	FormatYAML  Format = "yaml"
+)
+
+// timeLayout - is layout of time values in table format.
+const timeLayout = "2006-01-02 15:04"
+
+// ParseFormat - parses name of Format, empty name stands for FormatTable.
+func ParseFormat(name string) (Format, error) {
+	switch Format(strings.ToLower(name)) {
+	case "", FormatTable:
+		return FormatTable, nil
+	case FormatJSON:
+		return FormatJSON, nil
+	case FormatYAML:
+		return FormatYAML, nil
+	default:
+		return "", fmt.Errorf("unknown output format %s, use json, yaml or table", name)
+	}
+}
+
+// Tabler - is implemented by results which render themselves in table format.
+type Tabler interface {
+	WriteTable(w io.Writer) error
+}
+
+// Message - is a result of a command which has nothing to return except a notice.
+type Message struct {
+	Message string `json:"message"`
+}
+
+// WriteTable - writes text of Message.
+func (m Message) WriteTable(w io.Writer) error {
+	_, err := fmt.Fprintln(w, m.Message)
+
+	return err
+}
+
+// Error - is a structured representation of a failed command.
+type Error struct {
+	// Code - is name of gRPC status code, e.g. NotFound.
+	Code     string `json:"code"`
+	Message  string `json:"message"`
+	ExitCode int    `json:"exit_code"`
+}
+
+// Write - renders result of a command in provided Format.
+//
+// JSON uses json tags of result, YAML is converted from JSON, so both formats share field names. Table format uses
+// Tabler if result implements it, otherwise structs are rendered as key-value pairs and slices of structs as tables.
+func Write(w io.Writer, format Format, v interface{}) error {
+	switch format {
+	case FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(v)
+	case FormatYAML:
+		return writeYAML(w, v)
+	default:
+		return writeTable(w, v)
+	}
+}
+
+// WriteError - renders failed command in provided Format, table format gets only the message.
+func WriteError(w io.Writer, format Format, e Error) error {
+	if format == FormatJSON || format == FormatYAML {
+		return Write(w, format, struct {
+			Error Error `json:"error"`
+		}{Error: e})
+	}
+
+	_, err := fmt.Fprintln(w, e.Message)
+
+	return err
+}
+
+// writeYAML - converts JSON representation of v to YAML, so key order and names are kept.
+func writeYAML(w io.Writer, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	var node yaml.Node
+	if errUnmarshal := yaml.Unmarshal(data, &node); errUnmarshal != nil {
+		return errUnmarshal
+	}
+
+	blockStyle(&node)
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+
+	if errEncode := encoder.Encode(&node); errEncode != nil {
+		return errEncode
+	}
+
+	return encoder.Close()
+}
+
+// blockStyle - resets flow style of JSON nodes, so encoder picks block style and quotes only where needed.
+func blockStyle(node *yaml.Node) {
+	node.Style = 0
+
+	for _, child := range node.Content {
+		blockStyle(child)
+	}
+}
+
+// writeTable - renders v in table format.
+func writeTable(w io.Writer, v interface{}) error {
+	if v == nil {
+		return nil
+	}
+
+	if t, ok := v.(Tabler); ok {
+		return t.WriteTable(w)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(v))
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+	switch {
+	case value.Kind() == reflect.Slice && structType(value.Type().Elem()):
+		if value.Len() == 0 {
+			return nil
+		}
+
+		fields := tableFields(structOf(value.Type().Elem()))
+
+		header := make([]string, 0, len(fields))
+		for _, field := range fields {
+			header = append(header, strings.ToUpper(field.name))
+		}
+		fmt.Fprintln(tw, strings.Join(header, "\t"))
+
+		for i := 0; i < value.Len(); i++ {
+			row := reflect.Indirect(value.Index(i))
+
+			cells := make([]string, 0, len(fields))
+			for _, field := range fields {
+				cells = append(cells, cell(row.Field(field.index)))
+			}
+			fmt.Fprintln(tw, strings.Join(cells, "\t"))
+		}
+	case value.Kind() == reflect.Struct:
+		for _, field := range tableFields(value.Type()) {
+			fmt.Fprintf(tw, "%s:\t%s\n", field.name, cell(value.Field(field.index)))
+		}
+	default:
+		fmt.Fprintln(tw, cell(value))
+	}
+
+	return tw.Flush()
+}
+
+type tableField struct {
+	name  string
+	index int
+}
+
+// tableFields - returns exported fields of struct type named by their json tags.
+func tableFields(t reflect.Type) []tableField {
+	fields := make([]tableField, 0, t.NumField())
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		fields = append(fields, tableField{name: name, index: i})
+	}
+
+	return fields
+}
+
+// cell - formats a value of table cell.
+func cell(value reflect.Value) string {
+	if !value.IsValid() {
+		return "-"
+	}
+
+	if value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return "-"
+		}
+
+		return cell(value.Elem())
+	}
+
+	switch v := value.Interface().(type) {
+	case time.Time:
+		if v.IsZero() {
+			return "-"
+		}
+
+		return v.Local().Format(timeLayout)
+	case []byte:
+		return string(v)
+	}
+
+	if value.Kind() == reflect.Map {
+		pairs := make([]string, 0, value.Len())
+		for _, key := range value.MapKeys() {
+			pairs = append(pairs, fmt.Sprintf("%v=%v", key.Interface(), value.MapIndex(key).Interface()))
+		}
+		sort.Strings(pairs)
+
+		return strings.Join(pairs, " ")
+	}
+
+	return fmt.Sprint(value.Interface())
+}
+
+// structType - reports whether t is a struct or a pointer to struct.
+func structType(t reflect.Type) bool {
+	return structOf(t).Kind() == reflect.Struct
+}
+
+// structOf - dereferences pointer type.
+func structOf(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Pointer {
+		return t.Elem()
+	}
+
+	return t
+}
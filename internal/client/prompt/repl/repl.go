@@ -0,0 +1,61 @@
+package repl
+
+import (
+	"strings"
+
+	"github.com/c-bata/go-prompt"
+
+	"secretKeeper/internal/client/prompt/completer"
+	"secretKeeper/internal/client/prompt/executor"
+)
+
+// eofParser - is a console parser which remembers whether the last key was Ctrl+D.
+type eofParser struct {
+	prompt.ConsoleParser
+	eof bool
+}
+
+// Read - reads input and tracks Ctrl+D.
+func (p *eofParser) Read() ([]byte, error) {
+	b, err := p.ConsoleParser.Read()
+	if len(b) > 0 {
+		p.eof = len(b) == 1 && b[0] == 0x04
+	}
+
+	return b, err
+}
+
+// Run - reads commands until Ctrl+D or exit command and passes them to executor.
+//
+// go-prompt records every line in history before it is executed, so history is managed here and lines of
+// sensitive commands, e.g. login with password, are never recorded.
+func Run(exec *executor.Executor, comp *completer.Completer) {
+	parser := &eofParser{ConsoleParser: prompt.NewStandardInputParser()}
+
+	p := prompt.New(
+		func(string) {},
+		comp.Complete,
+		prompt.OptionParser(parser),
+		prompt.OptionTitle("Gophkeeper"),
+		prompt.OptionPrefix(">>>"),
+		prompt.OptionInputTextColor(prompt.Yellow),
+	)
+
+	var history []string
+
+	for {
+		// a copy, so go-prompt doesn't write a sensitive line to backing array of history
+		_ = prompt.OptionHistory(append([]string(nil), history...))(p)
+
+		line := p.Input()
+		if line == "" && parser.eof {
+			return
+		}
+
+		exec.Execute(line)
+
+		if strings.TrimSpace(line) != "" && !executor.Sensitive(line) {
+			history = append(history, line)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"secretKeeper/internal/client/model"
+	"secretKeeper/internal/client/model/secret"
+	"secretKeeper/internal/client/storage"
+	"secretKeeper/pkg/crypt"
+	pb "secretKeeper/proto"
+)
+
+type EmergencyClientService struct {
+	glCtx   *model.GlobalContext
+	client  pb.EmergencyClient
+	keyring *storage.Keyring
+	legacy  crypt.Crypter
+}
+
+// NewEmergencyClientService - creates new EmergencyClientService.
+func NewEmergencyClientService(
+	glCtx *model.GlobalContext, client pb.EmergencyClient, keyring *storage.Keyring, legacy crypt.Crypter,
+) *EmergencyClientService {
+	return &EmergencyClientService{
+		glCtx:   glCtx,
+		client:  client,
+		keyring: keyring,
+		legacy:  legacy,
+	}
+}
+
+// AddContact - wraps personal key of logged user for public key of the contact and designates the contact as
+// trusted one with provided waiting period.
+func (e *EmergencyClientService) AddContact(login string, waitHours int) (*pb.EmergencyContact, error) {
+	personalKey, ok := e.keyring.PersonalKey()
+	if !ok {
+		return nil, storage.ErrNoKeyPair
+	}
+
+	found, err := e.client.LookupContact(e.glCtx.Ctx, &pb.LookupContactRequest{Login: login})
+	if err != nil {
+		return nil, err
+	}
+
+	wrapped, errWrap := crypt.WrapKey(personalKey, found.PublicKey)
+	if errWrap != nil {
+		return nil, errWrap
+	}
+
+	result, errAdd := e.client.AddContact(e.glCtx.Ctx, &pb.AddContactRequest{
+		Login:      login,
+		WaitHours:  uint32(waitHours),
+		WrappedKey: wrapped,
+	})
+	if errAdd != nil {
+		return nil, errAdd
+	}
+
+	return result.Contact, nil
+}
+
+// RemoveContact - removes trusted contact of logged user.
+func (e *EmergencyClientService) RemoveContact(login string) error {
+	_, err := e.client.RemoveContact(e.glCtx.Ctx, &pb.RemoveContactRequest{Login: login})
+
+	return err
+}
+
+// ListContacts - returns trusted contacts of logged user.
+func (e *EmergencyClientService) ListContacts() ([]*pb.EmergencyContact, error) {
+	result, err := e.client.ListContacts(e.glCtx.Ctx, &pb.ListContactsRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Contacts, nil
+}
+
+// ListGrantors - returns users who designated logged user as their trusted contact.
+func (e *EmergencyClientService) ListGrantors() ([]*pb.EmergencyContact, error) {
+	result, err := e.client.ListGrantors(e.glCtx.Ctx, &pb.ListGrantorsRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Grantors, nil
+}
+
+// RequestAccess - requests emergency access to personal secrets of the owner.
+func (e *EmergencyClientService) RequestAccess(ownerLogin string) (*pb.EmergencyContact, error) {
+	result, err := e.client.RequestAccess(e.glCtx.Ctx, &pb.RequestAccessRequest{OwnerLogin: ownerLogin})
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Grantor, nil
+}
+
+// DenyAccess - denies emergency access requested by trusted contact of logged user.
+func (e *EmergencyClientService) DenyAccess(login string) error {
+	_, err := e.client.DenyAccess(e.glCtx.Ctx, &pb.DenyAccessRequest{Login: login})
+
+	return err
+}
+
+// OpenAccess - fetches personal secrets of the owner once access is granted and decodes them with personal key of
+// the owner unwrapped by key pair of logged user.
+func (e *EmergencyClientService) OpenAccess(ownerLogin string) ([]secret.EmergencySecret, error) {
+	kp, err := e.keyring.KeyPair()
+	if err != nil {
+		return nil, err
+	}
+
+	result, errAccess := e.client.GetEmergencyAccess(
+		e.glCtx.Ctx, &pb.GetEmergencyAccessRequest{OwnerLogin: ownerLogin},
+	)
+	if errAccess != nil {
+		return nil, errAccess
+	}
+
+	ownerKey, errUnwrap := crypt.UnwrapKey(result.WrappedKey, kp)
+	if errUnwrap != nil {
+		return nil, errUnwrap
+	}
+
+	keyCrypt, errCrypt := crypt.NewKeyCrypt(ownerKey)
+	if errCrypt != nil {
+		return nil, errCrypt
+	}
+
+	cr := crypt.NewFallbackCrypt(keyCrypt, e.legacy)
+
+	secrets := make([]secret.EmergencySecret, 0, len(result.Secrets))
+	for _, s := range result.Secrets {
+		decoded, errDecode := cr.Decode(string(s.Content))
+		if errDecode != nil {
+			return nil, errDecode
+		}
+
+		secrets = append(secrets, secret.EmergencySecret{
+			Id:        int(s.Id),
+			Title:     s.Title,
+			Type:      int(s.Type),
+			UpdatedAt: s.UpdatedAt.AsTime(),
+			Content:   decoded,
+		})
+	}
+
+	return secrets, nil
+}
@@ -0,0 +1,304 @@
+package service
+
+import (
+	"fmt"
+
+	"secretKeeper/internal/client/model"
+	"secretKeeper/internal/client/storage"
+	"secretKeeper/pkg/crypt"
+	pb "secretKeeper/proto"
+)
+
+type OrganizationClientService struct {
+	glCtx            *model.GlobalContext
+	client           pb.OrganizationClient
+	secretClient     pb.SecretClient
+	secretTypeClient pb.SecretTypeClient
+	keyring          *storage.Keyring
+}
+
+// NewOrganizationClientService - creates new OrganizationClientService.
+func NewOrganizationClientService(
+	glCtx *model.GlobalContext,
+	client pb.OrganizationClient,
+	secretClient pb.SecretClient,
+	secretTypeClient pb.SecretTypeClient,
+	keyring *storage.Keyring,
+) *OrganizationClientService {
+	return &OrganizationClientService{
+		glCtx:            glCtx,
+		client:           client,
+		secretClient:     secretClient,
+		secretTypeClient: secretTypeClient,
+		keyring:          keyring,
+	}
+}
+
+// CreateOrganization - creates new organization on server, where logged user becomes an owner.
+func (o *OrganizationClientService) CreateOrganization(title string) (*pb.OrganizationItem, error) {
+	result, err := o.client.CreateOrganization(o.glCtx.Ctx, &pb.CreateOrganizationRequest{Title: title})
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Organization, nil
+}
+
+// List - returns organizations where logged user is a member.
+func (o *OrganizationClientService) List() ([]*pb.OrganizationItem, error) {
+	result, err := o.client.ListOrganizations(o.glCtx.Ctx, &pb.ListOrganizationsRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Organizations, nil
+}
+
+// ListMembers - returns members of organization.
+func (o *OrganizationClientService) ListMembers(orgID int) ([]*pb.Member, error) {
+	result, err := o.client.ListMembers(o.glCtx.Ctx, &pb.ListMembersRequest{OrganizationId: uint32(orgID)})
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Members, nil
+}
+
+// AddMember - adds a user to organization and then rotates keys of every organization vault, so the new member
+// could open them.
+func (o *OrganizationClientService) AddMember(orgID int, login, role string) error {
+	result, err := o.client.AddMember(o.glCtx.Ctx, &pb.AddMemberRequest{
+		OrganizationId: uint32(orgID),
+		Login:          login,
+		Role:           role,
+	})
+	if err != nil {
+		return err
+	}
+
+	return o.rotateVaults(orgID, result.RotateVaultIds)
+}
+
+// RemoveMember - removes a user from organization and then rotates keys of every organization vault, so removed
+// member could no longer open them.
+func (o *OrganizationClientService) RemoveMember(orgID int, login string) error {
+	result, err := o.client.RemoveMember(o.glCtx.Ctx, &pb.RemoveMemberRequest{
+		OrganizationId: uint32(orgID),
+		Login:          login,
+	})
+	if err != nil {
+		return err
+	}
+
+	return o.rotateVaults(orgID, result.RotateVaultIds)
+}
+
+// CreateVault - generates new vault key, wraps it for every member of organization and creates vault on server.
+func (o *OrganizationClientService) CreateVault(orgID int, title string) (*pb.VaultItem, error) {
+	key, err := crypt.NewKey()
+	if err != nil {
+		return nil, err
+	}
+
+	keys, errWrap := o.wrapForMembers(orgID, key)
+	if errWrap != nil {
+		return nil, errWrap
+	}
+
+	result, errCreate := o.client.CreateVault(o.glCtx.Ctx, &pb.CreateVaultRequest{
+		OrganizationId: uint32(orgID),
+		Title:          title,
+		Keys:           keys,
+	})
+	if errCreate != nil {
+		return nil, errCreate
+	}
+
+	o.keyring.SetVaultKey(int(result.Vault.Id), key)
+
+	return result.Vault, nil
+}
+
+// ListVaults - returns vaults of organization.
+func (o *OrganizationClientService) ListVaults(orgID int) ([]*pb.VaultItem, error) {
+	result, err := o.client.ListVaults(o.glCtx.Ctx, &pb.ListVaultsRequest{OrganizationId: uint32(orgID)})
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Vaults, nil
+}
+
+// UseVault - makes vault active for secret commands, 0 switches back to personal secrets.
+func (o *OrganizationClientService) UseVault(vaultID int) error {
+	if vaultID != 0 {
+		if _, err := o.VaultCrypter(vaultID); err != nil {
+			return err
+		}
+	}
+
+	o.glCtx.VaultID = vaultID
+
+	return nil
+}
+
+// VaultCrypter - returns crypt.Crypter built from vault key, which is taken from keyring or fetched from server
+// and unwrapped with key pair of logged user.
+func (o *OrganizationClientService) VaultCrypter(vaultID int) (crypt.Crypter, error) {
+	key, ok := o.keyring.VaultKey(vaultID)
+	if !ok {
+		_, fetched, err := o.fetchVaultKey(vaultID)
+		if err != nil {
+			return nil, err
+		}
+
+		key = fetched
+		o.keyring.SetVaultKey(vaultID, key)
+	}
+
+	return crypt.NewKeyCrypt(key)
+}
+
+// fetchVaultKey - returns current version of vault key and unwrapped vault key.
+func (o *OrganizationClientService) fetchVaultKey(vaultID int) (int, []byte, error) {
+	kp, err := o.keyring.KeyPair()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	result, errKey := o.client.GetVaultKey(o.glCtx.Ctx, &pb.GetVaultKeyRequest{VaultId: uint32(vaultID)})
+	if errKey != nil {
+		return 0, nil, errKey
+	}
+
+	key, errUnwrap := crypt.UnwrapKey(result.WrappedKey, kp)
+	if errUnwrap != nil {
+		return 0, nil, errUnwrap
+	}
+
+	return int(result.KeyVersion), key, nil
+}
+
+// wrapForMembers - wraps key for public key of every organization member.
+func (o *OrganizationClientService) wrapForMembers(orgID int, key []byte) ([]*pb.MemberKey, error) {
+	members, err := o.ListMembers(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]*pb.MemberKey, 0, len(members))
+	for _, member := range members {
+		wrapped, errWrap := crypt.WrapKey(key, member.PublicKey)
+		if errWrap != nil {
+			return nil, fmt.Errorf("could not wrap key for %s: %w", member.Login, errWrap)
+		}
+
+		keys = append(keys, &pb.MemberKey{UserId: member.UserId, WrappedKey: wrapped})
+	}
+
+	return keys, nil
+}
+
+// rotateVaults - rotates keys of provided vaults one by one.
+func (o *OrganizationClientService) rotateVaults(orgID int, vaultIDs []uint32) error {
+	for _, id := range vaultIDs {
+		if err := o.rotateVault(orgID, int(id)); err != nil {
+			return fmt.Errorf("vault %d rotation failed, run the command again: %w", id, err)
+		}
+	}
+
+	return nil
+}
+
+// rotateVault - generates new vault key, wraps it for current members, re-encrypts every vault secret and sends
+// everything to server in one request.
+func (o *OrganizationClientService) rotateVault(orgID, vaultID int) error {
+	version, oldKey, err := o.fetchVaultKey(vaultID)
+	if err != nil {
+		return err
+	}
+
+	oldCrypt, errOld := crypt.NewKeyCrypt(oldKey)
+	if errOld != nil {
+		return errOld
+	}
+
+	newKey, errKey := crypt.NewKey()
+	if errKey != nil {
+		return errKey
+	}
+
+	newCrypt, errNew := crypt.NewKeyCrypt(newKey)
+	if errNew != nil {
+		return errNew
+	}
+
+	keys, errWrap := o.wrapForMembers(orgID, newKey)
+	if errWrap != nil {
+		return errWrap
+	}
+
+	secrets, errSecrets := o.reEncryptSecrets(vaultID, oldCrypt, newCrypt)
+	if errSecrets != nil {
+		return errSecrets
+	}
+
+	_, errRotate := o.client.RotateVaultKey(o.glCtx.Ctx, &pb.RotateVaultKeyRequest{
+		VaultId:    uint32(vaultID),
+		KeyVersion: uint32(version + 1),
+		Keys:       keys,
+		Secrets:    secrets,
+	})
+	if errRotate != nil {
+		return errRotate
+	}
+
+	o.keyring.SetVaultKey(vaultID, newKey)
+
+	return nil
+}
+
+// reEncryptSecrets - decodes content of every vault secret with old key and encodes it with new key.
+func (o *OrganizationClientService) reEncryptSecrets(
+	vaultID int, oldCrypt, newCrypt crypt.Crypter,
+) ([]*pb.RotatedSecret, error) {
+	types, err := o.secretTypeClient.GetSecretTypesList(o.glCtx.Ctx, &pb.SecretTypesListRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	var secrets []*pb.RotatedSecret
+	for _, secretType := range types.Secrets {
+		list, errList := o.secretClient.GetListOfSecretsByType(o.glCtx.Ctx, &pb.GetListOfSecretsByTypeRequest{
+			TypeId:  secretType.Id,
+			VaultId: uint32(vaultID),
+		})
+		if errList != nil {
+			return nil, errList
+		}
+
+		for _, item := range list.SecretLists {
+			secret, errGet := o.secretClient.GetSecret(o.glCtx.Ctx, &pb.GetSecretRequest{
+				Id:      int32(item.Id),
+				VaultId: uint32(vaultID),
+			})
+			if errGet != nil {
+				return nil, errGet
+			}
+
+			decoded, errDecode := oldCrypt.Decode(string(secret.Content))
+			if errDecode != nil {
+				return nil, errDecode
+			}
+
+			encoded, errEncode := newCrypt.Encode(decoded)
+			if errEncode != nil {
+				return nil, errEncode
+			}
+
+			secrets = append(secrets, &pb.RotatedSecret{Id: item.Id, Content: []byte(encoded)})
+		}
+	}
+
+	return secrets, nil
+}
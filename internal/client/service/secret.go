@@ -2,8 +2,8 @@ package service
 
 import (
 	"errors"
-	"fmt"
 	"os"
+	"time"
 
 	"google.golang.org/protobuf/types/known/timestamppb"
 
@@ -15,17 +15,28 @@ import (
 	pb "secretKeeper/proto"
 )
 
+// VaultCrypter - provides crypt.Crypter of organization vault.
+type VaultCrypter interface {
+	VaultCrypter(vaultID int) (crypt.Crypter, error)
+}
+
 type SecretClientService struct {
 	glCtx   *model.GlobalContext
 	client  pb.SecretClient
 	storage storage.Memorier
 	crypt   crypt.Crypter
 	syncer  storage.Syncer
+	vaults  VaultCrypter
 }
 
 // NewSecretClientService - creates new SecretClientService.
 func NewSecretClientService(
-	glCtx *model.GlobalContext, client pb.SecretClient, st storage.Memorier, cr crypt.Crypter, sr storage.Syncer,
+	glCtx *model.GlobalContext,
+	client pb.SecretClient,
+	st storage.Memorier,
+	cr crypt.Crypter,
+	sr storage.Syncer,
+	vc VaultCrypter,
 ) *SecretClientService {
 	return &SecretClientService{
 		glCtx:   glCtx,
@@ -33,19 +44,26 @@ func NewSecretClientService(
 		storage: st,
 		crypt:   cr,
 		syncer:  sr,
+		vaults:  vc,
 	}
 }
 
 // GetListOfSecretes - attempts to return list of secrets from memory, if nothing is found then makes gRPC request
 // to server.
+//
+// Secrets of active vault are not kept in memory, so they are always requested from server.
 func (s *SecretClientService) GetListOfSecretes(id int) ([]*pb.SecretList, error) {
-	var list []*pb.SecretList
-	list = s.storage.GetSecretList(id)
-	if len(list) > 0 {
-		return list, nil
+	if s.glCtx.VaultID == 0 {
+		list := s.storage.GetSecretList(id)
+		if len(list) > 0 {
+			return list, nil
+		}
 	}
 
-	result, err := s.client.GetListOfSecretsByType(s.glCtx.Ctx, &pb.GetListOfSecretsByTypeRequest{TypeId: uint32(id)})
+	result, err := s.client.GetListOfSecretsByType(s.glCtx.Ctx, &pb.GetListOfSecretsByTypeRequest{
+		TypeId:  uint32(id),
+		VaultId: uint32(s.glCtx.VaultID),
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -55,7 +73,12 @@ func (s *SecretClientService) GetListOfSecretes(id int) ([]*pb.SecretList, error
 
 // GetBinarySecret - get binary data from server and stores it into file.
 func (s *SecretClientService) GetBinarySecret(id int, location string) error {
-	res, err := s.client.GetSecret(s.glCtx.Ctx, &pb.GetSecretRequest{Id: int32(id)})
+	cr, errCrypt := s.activeCrypter()
+	if errCrypt != nil {
+		return errCrypt
+	}
+
+	res, err := s.client.GetSecret(s.glCtx.Ctx, &pb.GetSecretRequest{Id: int32(id), VaultId: uint32(s.glCtx.VaultID)})
 	if err != nil {
 		return err
 	}
@@ -64,7 +87,7 @@ func (s *SecretClientService) GetBinarySecret(id int, location string) error {
 		return errors.New("this method only works with binary data, please appropriate method next time")
 	}
 
-	decoded, errDecode := s.crypt.Decode(string(res.Content))
+	decoded, errDecode := cr.Decode(string(res.Content))
 	if errDecode != nil {
 		return errDecode
 	}
@@ -80,15 +103,17 @@ func (s *SecretClientService) GetBinarySecret(id int, location string) error {
 		return wrError
 	}
 
-	fmt.Println("data written to file")
-
 	return nil
 }
 
 // GetSecret -  makes gRPC request to server.
 func (s *SecretClientService) GetSecret(id int) (secret.ResSecret, error) {
+	cr, errCrypt := s.activeCrypter()
+	if errCrypt != nil {
+		return secret.ResSecret{}, errCrypt
+	}
 
-	result, err := s.client.GetSecret(s.glCtx.Ctx, &pb.GetSecretRequest{Id: int32(id)})
+	result, err := s.client.GetSecret(s.glCtx.Ctx, &pb.GetSecretRequest{Id: int32(id), VaultId: uint32(s.glCtx.VaultID)})
 	if err != nil {
 		return secret.ResSecret{}, err
 	}
@@ -97,7 +122,7 @@ func (s *SecretClientService) GetSecret(id int) (secret.ResSecret, error) {
 		return secret.ResSecret{}, errors.New("to get binary data, pleas use proper method")
 	}
 
-	decoded, errDecode := s.crypt.Decode(string(result.Content))
+	decoded, errDecode := cr.Decode(string(result.Content))
 	if errDecode != nil {
 		return secret.ResSecret{}, errDecode
 	}
@@ -114,37 +139,171 @@ func (s *SecretClientService) GetSecret(id int) (secret.ResSecret, error) {
 	}, nil
 }
 
-// CreateSecret - creates new secret on the server and then makes re-sync memory storage.
-func (s *SecretClientService) CreateSecret(title string, recordType int, content string) error {
-	contentT := []byte(s.crypt.Encode(content))
+// StoredSecret - makes gRPC request to server and returns decoded secret of any type with its metadata.
+func (s *SecretClientService) StoredSecret(id int) (secret.StoredSecret, error) {
+	cr, errCrypt := s.activeCrypter()
+	if errCrypt != nil {
+		return secret.StoredSecret{}, errCrypt
+	}
+
+	result, err := s.client.GetSecret(s.glCtx.Ctx, &pb.GetSecretRequest{Id: int32(id), VaultId: uint32(s.glCtx.VaultID)})
+	if err != nil {
+		return secret.StoredSecret{}, err
+	}
+
+	if result.IsDelited {
+		return secret.StoredSecret{}, apperr.ErrSecretNotFound
+	}
+
+	decoded, errDecode := cr.Decode(string(result.Content))
+	if errDecode != nil {
+		return secret.StoredSecret{}, errDecode
+	}
+
+	stored := secret.StoredSecret{
+		Id:        int(result.Id),
+		Title:     result.Title,
+		Type:      int(result.Type),
+		CreatedAt: result.CreatedAt.AsTime(),
+		UpdatedAt: result.UpdatedAt.AsTime(),
+		Expiry: secret.Expiry{
+			RotateEveryDays: int(result.RotateEveryDays),
+			AutoExpire:      result.AutoExpire,
+		},
+		Content: decoded,
+	}
+
+	if result.ExpiresAt != nil {
+		expiresAt := result.ExpiresAt.AsTime()
+		stored.Expiry.ExpiresAt = &expiresAt
+	}
+
+	return stored, nil
+}
+
+// CreateSecret - creates new secret with provided secret.Expiry on the server and then makes re-sync memory storage.
+// ID of created secret is returned.
+func (s *SecretClientService) CreateSecret(
+	title string, recordType int, content string, expiry secret.Expiry,
+) (uint32, error) {
+	cr, errCrypt := s.activeCrypter()
+	if errCrypt != nil {
+		return 0, errCrypt
+	}
+
+	encoded, errEncode := cr.Encode(content)
+	if errEncode != nil {
+		return 0, errEncode
+	}
 
 	result, err := s.client.CreateSecret(s.glCtx.Ctx, &pb.CreateSecretRequest{
 		Title:   title,
 		Type:    uint32(recordType),
-		Content: contentT,
+		Content: []byte(encoded),
+		VaultId: uint32(s.glCtx.VaultID),
+
+		ExpiresAt:       optionalTimestamp(expiry.ExpiresAt),
+		RotateEveryDays: uint32(expiry.RotateEveryDays),
+		AutoExpire:      expiry.AutoExpire,
 	})
 
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	fmt.Println("created new secret with ID:", result.Id)
+	// secret is created, memory storage is refreshed by the next sync if this one fails
+	_ = s.syncer.SyncAll()
 
-	s.syncer.SyncAll()
+	return result.Id, nil
+}
 
-	return nil
+const (
+	// createBatchSize - is how many secrets are sent in one CreateSecrets request, server accepts up to 1000.
+	createBatchSize = 500
+	// createBatchBytes - is how much content is sent in one CreateSecrets request, gRPC messages are limited by 4MB.
+	createBatchBytes = 3 << 20
+)
+
+// CreateSecrets - creates secrets on the server in batches and then makes re-sync memory storage once.
+//
+// Number of created secrets is returned along with error, as batches sent before a failed one are kept.
+func (s *SecretClientService) CreateSecrets(secrets []secret.NewSecret) (int, error) {
+	cr, errCrypt := s.activeCrypter()
+	if errCrypt != nil {
+		return 0, errCrypt
+	}
+
+	created := 0
+	batch := make([]*pb.CreateSecretRequest, 0, createBatchSize)
+	batchBytes := 0
+
+	send := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+
+		result, err := s.client.CreateSecrets(s.glCtx.Ctx, &pb.CreateSecretsRequest{
+			Secrets: batch,
+			VaultId: uint32(s.glCtx.VaultID),
+		})
+		if err != nil {
+			return err
+		}
+
+		created += len(result.Secrets)
+		batch, batchBytes = batch[:0], 0
+
+		return nil
+	}
+
+	for _, item := range secrets {
+		encoded, errEncode := cr.Encode(item.Content)
+		if errEncode != nil {
+			return created, s.afterBatch(created, errEncode)
+		}
+
+		content := []byte(encoded)
+
+		if len(batch) == createBatchSize || (len(batch) > 0 && batchBytes+len(content) > createBatchBytes) {
+			if err := send(); err != nil {
+				return created, s.afterBatch(created, err)
+			}
+		}
+
+		batch = append(batch, &pb.CreateSecretRequest{
+			Title:   item.Title,
+			Type:    uint32(item.Type),
+			Content: content,
+
+			ExpiresAt:       optionalTimestamp(item.Expiry.ExpiresAt),
+			RotateEveryDays: uint32(item.Expiry.RotateEveryDays),
+			AutoExpire:      item.Expiry.AutoExpire,
+			CreatedAt:       optionalTimestamp(nonZeroTime(item.CreatedAt)),
+			UpdatedAt:       optionalTimestamp(nonZeroTime(item.UpdatedAt)),
+		})
+		batchBytes += len(content)
+	}
+
+	return created, s.afterBatch(created, send())
+}
+
+// afterBatch - re-syncs memory storage if any secret is created, err is passed through.
+func (s *SecretClientService) afterBatch(created int, err error) error {
+	if created > 0 {
+		_ = s.syncer.SyncAll()
+	}
+
+	return err
 }
 
 // DeleteSecret - deletes a secrete from server and then makes re-sync memory storage.
 func (s *SecretClientService) DeleteSecret(id int) error {
 	s.storage.DeleteSecret(id)
-	_, err := s.client.DeleteSecret(s.glCtx.Ctx, &pb.DeleteSecretRequest{Id: uint32(id)})
+	_, err := s.client.DeleteSecret(s.glCtx.Ctx, &pb.DeleteSecretRequest{Id: uint32(id), VaultId: uint32(s.glCtx.VaultID)})
 	if err != nil {
 		return err
 	}
 
-	fmt.Println("successfully deleted secret")
-
 	// s.storage.ResetStorage()
 	// s.syncer.SyncAll()
 
@@ -154,27 +313,91 @@ func (s *SecretClientService) DeleteSecret(id int) error {
 // EditSecret - edits secret on the server and then makes re-sync memory storage.
 func (s *SecretClientService) EditSecret(id int, title string, recordType int, content string, isForce bool) error {
 
+	cr, errCrypt := s.activeCrypter()
+	if errCrypt != nil {
+		return errCrypt
+	}
+
 	localSecret, _ := s.GetSecret(id)
 
-	contentT := []byte(s.crypt.Encode(content))
+	encoded, errEncode := cr.Encode(content)
+	if errEncode != nil {
+		return errEncode
+	}
 
 	_, err := s.client.EditSecret(
 		s.glCtx.Ctx, &pb.EditSecretRequest{
 			Id:        uint32(id),
 			Title:     title,
 			Type:      uint32(recordType),
-			Content:   contentT,
+			Content:   []byte(encoded),
 			UpdatedAt: timestamppb.New(localSecret.UpdatedAt),
 			IsForce:   isForce,
+			VaultId:   uint32(s.glCtx.VaultID),
 		},
 	)
 	if err != nil {
 		return err
 	}
 
-	fmt.Println("successfully edited secret")
-
-	s.syncer.SyncAll()
+	// secret is edited, memory storage is refreshed by the next sync if this one fails
+	_ = s.syncer.SyncAll()
 
 	return nil
 }
+
+// SetExpiry - replaces secret.Expiry of a secret in active vault or of a personal secret on the server.
+func (s *SecretClientService) SetExpiry(id int, expiry secret.Expiry) error {
+	_, err := s.client.SetSecretExpiry(s.glCtx.Ctx, &pb.SetSecretExpiryRequest{
+		Id:              uint32(id),
+		VaultId:         uint32(s.glCtx.VaultID),
+		ExpiresAt:       optionalTimestamp(expiry.ExpiresAt),
+		RotateEveryDays: uint32(expiry.RotateEveryDays),
+		AutoExpire:      expiry.AutoExpire,
+	})
+
+	return err
+}
+
+// DueEvents - returns reminders about secrets of logged user and their vaults, which expire soon, have expired or
+// need rotation.
+func (s *SecretClientService) DueEvents() ([]*pb.DueEvent, error) {
+	result, err := s.client.GetDueEvents(s.glCtx.Ctx, &pb.GetDueEventsRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Events, nil
+}
+
+// VaultID - returns ID of active vault, zero stands for personal secrets.
+func (s *SecretClientService) VaultID() int {
+	return s.glCtx.VaultID
+}
+
+// activeCrypter - returns crypt.Crypter of active vault or personal crypt.Crypter if no vault is active.
+func (s *SecretClientService) activeCrypter() (crypt.Crypter, error) {
+	if s.glCtx.VaultID == 0 {
+		return s.crypt, nil
+	}
+
+	return s.vaults.VaultCrypter(s.glCtx.VaultID)
+}
+
+// nonZeroTime - returns nil for zero time, so it isn't sent.
+func nonZeroTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+
+	return &t
+}
+
+// optionalTimestamp - converts optional *time.Time to *timestamppb.Timestamp.
+func optionalTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+
+	return timestamppb.New(*t)
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+
+	"secretKeeper/internal/client/model"
+	"secretKeeper/pkg/crypt"
+	pb "secretKeeper/proto"
+)
+
+// ErrInvalidSendToken - is returned when send token is malformed.
+var ErrInvalidSendToken = errors.New("send token must look like id#key")
+
+type SendClientService struct {
+	glCtx  *model.GlobalContext
+	client pb.SendClient
+}
+
+// NewSendClientService - creates new SendClientService.
+func NewSendClientService(glCtx *model.GlobalContext, client pb.SendClient) *SendClientService {
+	return &SendClientService{glCtx: glCtx, client: client}
+}
+
+// Send - encrypts content with a new random key and stores it on the server as one-time send.
+//
+// Returned token consists of send id and the key separated by '#', like a fragment of URL, the key never reaches
+// the server, so token has to be passed to recipient as a whole.
+func (s *SendClientService) Send(content string, ttl time.Duration, maxViews int) (string, time.Time, error) {
+	key, err := crypt.NewKey()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	cr, errCrypt := crypt.NewKeyCrypt(key)
+	if errCrypt != nil {
+		return "", time.Time{}, errCrypt
+	}
+
+	encoded, errEncode := cr.Encode(content)
+	if errEncode != nil {
+		return "", time.Time{}, errEncode
+	}
+
+	result, errSend := s.client.SendSecret(s.glCtx.Ctx, &pb.SendSecretRequest{
+		Content:    []byte(encoded),
+		TtlMinutes: uint32(ttl / time.Minute),
+		MaxViews:   uint32(maxViews),
+	})
+	if errSend != nil {
+		return "", time.Time{}, errSend
+	}
+
+	return result.Id + "#" + base64.RawURLEncoding.EncodeToString(key), result.ExpiresAt.AsTime(), nil
+}
+
+// Open - fetches one-time send by token made by Send and decrypts it, it works without login.
+//
+// Token may also be a link whose last path segment and fragment form the token. Opening consumes one view.
+func (s *SendClientService) Open(token string) (string, int, error) {
+	id, key, err := parseSendToken(token)
+	if err != nil {
+		return "", 0, err
+	}
+
+	cr, errCrypt := crypt.NewKeyCrypt(key)
+	if errCrypt != nil {
+		return "", 0, ErrInvalidSendToken
+	}
+
+	result, errOpen := s.client.OpenSend(s.glCtx.Ctx, &pb.OpenSendRequest{Id: id})
+	if errOpen != nil {
+		return "", 0, errOpen
+	}
+
+	content, errDecode := cr.Decode(string(result.Content))
+	if errDecode != nil {
+		return "", 0, errDecode
+	}
+
+	return content, int(result.ViewsLeft), nil
+}
+
+// parseSendToken - splits send token to id and key.
+func parseSendToken(token string) (string, []byte, error) {
+	token = strings.TrimSpace(token)
+	token = token[strings.LastIndex(token, "/")+1:]
+
+	id, encodedKey, found := strings.Cut(token, "#")
+	if !found || id == "" {
+		return "", nil, ErrInvalidSendToken
+	}
+
+	key, err := base64.RawURLEncoding.DecodeString(encodedKey)
+	if err != nil || len(key) != crypt.KeySize {
+		return "", nil, ErrInvalidSendToken
+	}
+
+	return id, key, nil
+}
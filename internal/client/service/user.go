@@ -1,22 +1,32 @@
 package service
 
 import (
+	"crypto/sha256"
+	"strings"
+
 	"google.golang.org/grpc/metadata"
 
 	"secretKeeper/internal/client/model"
+	"secretKeeper/internal/client/storage"
+	"secretKeeper/pkg/crypt"
 	pb "secretKeeper/proto"
 )
 
 type UserClientService struct {
-	glCtx  *model.GlobalContext
-	client pb.UserClient
+	glCtx   *model.GlobalContext
+	client  pb.UserClient
+	keyring *storage.Keyring
+	login   string
 }
 
 // NewUserClientService - creates new UserClientService.
-func NewUserClientService(glCtx *model.GlobalContext, client pb.UserClient) *UserClientService {
+func NewUserClientService(
+	glCtx *model.GlobalContext, client pb.UserClient, keyring *storage.Keyring,
+) *UserClientService {
 	return &UserClientService{
-		glCtx:  glCtx,
-		client: client,
+		glCtx:   glCtx,
+		client:  client,
+		keyring: keyring,
 	}
 }
 
@@ -34,8 +44,9 @@ func (u *UserClientService) Login(user model.User) error {
 	}
 
 	u.glCtx.Ctx = metadata.AppendToOutgoingContext(u.glCtx.Ctx, "authorization", "Bearer "+result.Token)
+	u.login = user.Login
 
-	return nil
+	return u.publishKeyPair(user)
 }
 
 // Register - creates a new user on server. On successful creation adds authorization token to metadata in global
@@ -47,8 +58,9 @@ func (u *UserClientService) Register(user model.User) error {
 	}
 
 	u.glCtx.Ctx = metadata.AppendToOutgoingContext(u.glCtx.Ctx, "authorization", "Bearer "+result.Token)
+	u.login = user.Login
 
-	return nil
+	return u.publishKeyPair(user)
 }
 
 // Delete - deletes a user from server. On successful deletion, removes authorization token from metadata in global
@@ -60,11 +72,284 @@ func (u *UserClientService) Delete() error {
 	}
 
 	u.glCtx.Ctx = metadata.NewOutgoingContext(u.glCtx.Ctx, metadata.MD{})
+	u.login = ""
+	u.glCtx.VaultID = 0
+	u.keyring.Reset()
+
+	return nil
+}
+
+// IsLogged - reports whether authorization token is attached to global shared context.
+func (u *UserClientService) IsLogged() bool {
+	return u.token() != ""
+}
+
+// Session - returns model.Session of logged user, so it could be restored by RestoreSession in another run.
+func (u *UserClientService) Session() (model.Session, error) {
+	token := u.token()
+	if token == "" {
+		return model.Session{}, storage.ErrNoSession
+	}
+
+	public, err := u.keyring.PublicKey()
+	if err != nil {
+		return model.Session{}, err
+	}
+
+	return model.Session{
+		Login:     u.login,
+		Token:     token,
+		PublicKey: public[:],
+		VaultID:   u.glCtx.VaultID,
+	}, nil
+}
+
+// RestoreSession - attaches token of model.Session to global shared context, the session is locked, as no keys are
+// cached, Unlock derives them from password.
+func (u *UserClientService) RestoreSession(session model.Session) error {
+	var public [crypt.KeySize]byte
+	if len(session.PublicKey) != len(public) {
+		return storage.ErrNoSession
+	}
+
+	copy(public[:], session.PublicKey)
+
+	u.glCtx.Ctx = metadata.AppendToOutgoingContext(u.glCtx.Ctx, "authorization", "Bearer "+session.Token)
+	u.glCtx.VaultID = session.VaultID
+	u.login = session.Login
+	u.keyring.LockSession(public)
 
 	return nil
 }
 
+// token - returns authorization token attached to global shared context.
+func (u *UserClientService) token() string {
+	md, ok := metadata.FromOutgoingContext(u.glCtx.Ctx)
+	if !ok {
+		return ""
+	}
+
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return ""
+	}
+
+	return strings.TrimPrefix(values[len(values)-1], "Bearer ")
+}
+
 // Logout -  removes authorization token from metadata in global shared context.
 func (u *UserClientService) Logout() {
 	u.glCtx.Ctx = metadata.NewOutgoingContext(u.glCtx.Ctx, metadata.MD{})
+	u.login = ""
+	u.glCtx.VaultID = 0
+	u.keyring.Reset()
+}
+
+// Lock - zeroes keys of logged user, session stays authorized, so Unlock needs only password.
+func (u *UserClientService) Lock() error {
+	if !u.IsLogged() {
+		return storage.ErrNoSession
+	}
+
+	return u.keyring.Lock()
+}
+
+// Unlock - derives key pair of logged user from password again and restores keys zeroed by Lock, no request is made
+// unless personal key wasn't kept, as by restored session.
+func (u *UserClientService) Unlock(password string) error {
+	kp, err := crypt.DeriveKeyPair(u.login, password)
+	if err != nil {
+		return err
+	}
+
+	if errUnlock := u.keyring.Unlock(kp); errUnlock != nil {
+		kp.Zero()
+
+		return errUnlock
+	}
+
+	if _, ok := u.keyring.PersonalKey(); !ok {
+		return u.loadPersonalKey(kp)
+	}
+
+	return nil
+}
+
+// IsLocked - reports whether keys of logged user are zeroed by Lock.
+func (u *UserClientService) IsLocked() bool {
+	return u.keyring.Locked()
+}
+
+// publishKeyPair - derives key pair of a user, keeps it in keyring and sends public key to server, so other members
+// of organizations could wrap vault keys for the user. Then personal key is loaded.
+func (u *UserClientService) publishKeyPair(user model.User) error {
+	kp, err := crypt.DeriveKeyPair(user.Login, user.Password)
+	if err != nil {
+		return err
+	}
+
+	u.keyring.SetKeyPair(kp)
+
+	if _, errSet := u.client.SetPublicKey(u.glCtx.Ctx, &pb.SetPublicKeyRequest{PublicKey: kp.Public[:]}); errSet != nil {
+		return errSet
+	}
+
+	return u.loadPersonalKey(kp)
+}
+
+// loadPersonalKey - unwraps personal key of a user stored on server and keeps it in keyring.
+//
+// On the first login personal key is generated and stored on server wrapped for public key of the user, so it could
+// be also wrapped for trusted contacts later.
+func (u *UserClientService) loadPersonalKey(kp *crypt.KeyPair) error {
+	result, err := u.client.GetPersonalKey(u.glCtx.Ctx, &pb.GetPersonalKeyRequest{})
+	if err != nil {
+		return err
+	}
+
+	if len(result.WrappedKey) > 0 {
+		key, errUnwrap := crypt.UnwrapKey(result.WrappedKey, kp)
+		if errUnwrap != nil {
+			return errUnwrap
+		}
+
+		u.keyring.SetPersonalKey(key)
+
+		return nil
+	}
+
+	key, errKey := crypt.NewKey()
+	if errKey != nil {
+		return errKey
+	}
+
+	wrapped, errWrap := crypt.WrapKey(key, kp.Public[:])
+	if errWrap != nil {
+		return errWrap
+	}
+
+	if _, errSet := u.client.SetPersonalKey(u.glCtx.Ctx, &pb.SetPersonalKeyRequest{WrappedKey: wrapped}); errSet != nil {
+		return errSet
+	}
+
+	u.keyring.SetPersonalKey(key)
+
+	return nil
+}
+
+// SplitRecovery - generates new recovery key, stores on server personal key of logged user wrapped for the recovery
+// key and splits the recovery key into n shares, any m of which restore it.
+//
+// Previously generated shares stop working.
+func (u *UserClientService) SplitRecovery(n, m int) ([]string, error) {
+	personalKey, ok := u.keyring.PersonalKey()
+	if !ok {
+		return nil, storage.ErrNoKeyPair
+	}
+
+	recoveryKey, err := crypt.NewKey()
+	if err != nil {
+		return nil, err
+	}
+
+	shares, errSplit := crypt.SplitSecret(recoveryKey, n, m)
+	if errSplit != nil {
+		return nil, errSplit
+	}
+
+	rkp, errKp := crypt.NewKeyPair(recoveryKey)
+	if errKp != nil {
+		return nil, errKp
+	}
+	defer rkp.Zero()
+
+	wrapped, errWrap := crypt.WrapKey(personalKey, rkp.Public[:])
+	if errWrap != nil {
+		return nil, errWrap
+	}
+
+	tokenHash := sha256.Sum256(crypt.RecoveryToken(recoveryKey))
+
+	if _, errSet := u.client.SetRecovery(u.glCtx.Ctx, &pb.SetRecoveryRequest{
+		WrappedKey: wrapped,
+		TokenHash:  tokenHash[:],
+	}); errSet != nil {
+		return nil, errSet
+	}
+
+	texts := make([]string, 0, len(shares))
+	for _, share := range shares {
+		texts = append(texts, share.String())
+	}
+
+	return texts, nil
+}
+
+// RestoreRecovery - rebuilds recovery key from shares, sets new password of a user and logs the user in.
+//
+// Personal key is re-wrapped for new key pair of the user, so personal secrets and emergency contacts keep working.
+func (u *UserClientService) RestoreRecovery(user model.User, texts []string) error {
+	shares := make([]crypt.Share, 0, len(texts))
+	for _, text := range texts {
+		share, err := crypt.ParseShare(text)
+		if err != nil {
+			return err
+		}
+
+		shares = append(shares, share)
+	}
+
+	recoveryKey, errCombine := crypt.CombineShares(shares)
+	if errCombine != nil {
+		return errCombine
+	}
+
+	rkp, errKp := crypt.NewKeyPair(recoveryKey)
+	if errKp != nil {
+		return errKp
+	}
+	defer rkp.Zero()
+
+	token := crypt.RecoveryToken(recoveryKey)
+
+	wrapped, errGet := u.client.GetRecoveryKey(u.glCtx.Ctx, &pb.GetRecoveryKeyRequest{
+		Login:         user.Login,
+		RecoveryToken: token,
+	})
+	if errGet != nil {
+		return errGet
+	}
+
+	personalKey, errUnwrap := crypt.UnwrapKey(wrapped.WrappedKey, rkp)
+	if errUnwrap != nil {
+		return errUnwrap
+	}
+
+	kp, errDerive := crypt.DeriveKeyPair(user.Login, user.Password)
+	if errDerive != nil {
+		return errDerive
+	}
+
+	wrappedPersonal, errWrap := crypt.WrapKey(personalKey, kp.Public[:])
+	if errWrap != nil {
+		return errWrap
+	}
+
+	result, errRecover := u.client.Recover(u.glCtx.Ctx, &pb.RecoverRequest{
+		Login:              user.Login,
+		RecoveryToken:      token,
+		Password:           user.Password,
+		PublicKey:          kp.Public[:],
+		WrappedPersonalKey: wrappedPersonal,
+	})
+	if errRecover != nil {
+		return errRecover
+	}
+
+	u.glCtx.Ctx = metadata.AppendToOutgoingContext(u.glCtx.Ctx, "authorization", "Bearer "+result.Token)
+	u.login = user.Login
+	u.keyring.SetKeyPair(kp)
+	u.keyring.SetPersonalKey(personalKey)
+
+	return nil
 }
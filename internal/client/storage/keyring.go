@@ -0,0 +1,290 @@
+package storage
+
+import (
+	"errors"
+	"sync"
+
+	"secretKeeper/pkg/crypt"
+)
+
+var (
+	// ErrNoKeyPair - is returned when key pair of a user is not derived yet.
+	ErrNoKeyPair = errors.New("key pair is missing, please login again")
+	// ErrLocked - is returned when keys are zeroed by Lock until Unlock.
+	ErrLocked = errors.New("vault is locked, unlock it by password")
+	// ErrNotLocked - is returned by Unlock of Keyring which isn't locked.
+	ErrNotLocked = errors.New("vault isn't locked")
+	// ErrWrongPassword - is returned by Unlock when derived key pair isn't the locked one.
+	ErrWrongPassword = errors.New("password is wrong")
+)
+
+type Keyring struct {
+	mu          sync.RWMutex
+	keyPair     *crypt.KeyPair
+	personalKey []byte
+	vaultKeys   map[int][]byte
+	// locked - keeps what Unlock needs while keys are zeroed, nil if Keyring isn't locked.
+	locked *lockedKeys
+}
+
+// lockedKeys - are public key of locked key pair and personal key wrapped for it, neither of them is secret.
+type lockedKeys struct {
+	public      [crypt.KeySize]byte
+	wrappedKey  []byte
+	hasPersonal bool
+}
+
+// NewKeyring - creates new Keyring.
+func NewKeyring() *Keyring {
+	return &Keyring{vaultKeys: make(map[int][]byte, 0)}
+}
+
+// SetKeyPair - sets key pair of logged user, previous key pair is zeroed and locked one is forgotten.
+func (k *Keyring) SetKeyPair(kp *crypt.KeyPair) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if k.keyPair != nil {
+		k.keyPair.Zero()
+	}
+
+	k.keyPair = kp
+	k.locked = nil
+}
+
+// KeyPair - returns key pair of logged user or ErrNoKeyPair.
+func (k *Keyring) KeyPair() (*crypt.KeyPair, error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	if k.locked != nil {
+		return nil, ErrLocked
+	}
+
+	if k.keyPair == nil {
+		return nil, ErrNoKeyPair
+	}
+
+	return k.keyPair, nil
+}
+
+// SetPersonalKey - sets unwrapped personal key of logged user, previous key is zeroed.
+func (k *Keyring) SetPersonalKey(key []byte) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	zero(k.personalKey)
+	k.personalKey = key
+}
+
+// PersonalKey - returns personal key of logged user and true, or nil and false if key is not set.
+func (k *Keyring) PersonalKey() ([]byte, bool) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	return k.personalKey, k.personalKey != nil
+}
+
+// PersonalCrypter - returns crypt.Crypter which encodes personal secrets with personal key of logged user.
+//
+// Secrets encoded by legacy crypt.Crypter before personal key was introduced are still decoded.
+func (k *Keyring) PersonalCrypter(legacy crypt.Crypter) crypt.Crypter {
+	return &personalCrypt{keyring: k, legacy: legacy}
+}
+
+// SetVaultKey - caches unwrapped vault key.
+func (k *Keyring) SetVaultKey(vaultID int, key []byte) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	zero(k.vaultKeys[vaultID])
+	k.vaultKeys[vaultID] = key
+}
+
+// VaultKey - returns cached vault key and true, or nil and false if key is not cached.
+func (k *Keyring) VaultKey(vaultID int) ([]byte, bool) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	key, ok := k.vaultKeys[vaultID]
+
+	return key, ok
+}
+
+// ForgetVaultKey - zeroes and removes cached vault key.
+func (k *Keyring) ForgetVaultKey(vaultID int) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	zero(k.vaultKeys[vaultID])
+	delete(k.vaultKeys, vaultID)
+}
+
+// Lock - zeroes and removes key pair, personal key and cached vault keys, keeping personal key wrapped for public key,
+// so Unlock restores them from password alone.
+func (k *Keyring) Lock() error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if k.locked != nil {
+		return nil
+	}
+
+	if k.keyPair == nil {
+		return ErrNoKeyPair
+	}
+
+	locked := &lockedKeys{public: k.keyPair.Public, hasPersonal: k.personalKey != nil}
+
+	if locked.hasPersonal {
+		wrapped, err := crypt.WrapKey(k.personalKey, k.keyPair.Public[:])
+		if err != nil {
+			return err
+		}
+
+		locked.wrappedKey = wrapped
+	}
+
+	k.reset()
+	k.locked = locked
+
+	return nil
+}
+
+// LockSession - locks Keyring of session restored without keys, Unlock accepts key pair derived from password which
+// public key is provided, personal key is loaded after it.
+func (k *Keyring) LockSession(public [crypt.KeySize]byte) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	k.reset()
+	k.locked = &lockedKeys{public: public}
+}
+
+// PublicKey - returns public key of logged user, it's kept while Keyring is locked, or ErrNoKeyPair.
+func (k *Keyring) PublicKey() ([crypt.KeySize]byte, error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	switch {
+	case k.locked != nil:
+		return k.locked.public, nil
+	case k.keyPair != nil:
+		return k.keyPair.Public, nil
+	default:
+		return [crypt.KeySize]byte{}, ErrNoKeyPair
+	}
+}
+
+// Unlock - restores keys zeroed by Lock from key pair derived from password, which must be the locked one.
+func (k *Keyring) Unlock(kp *crypt.KeyPair) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if k.locked == nil {
+		return ErrNotLocked
+	}
+
+	if kp.Public != k.locked.public {
+		return ErrWrongPassword
+	}
+
+	if k.locked.hasPersonal {
+		key, err := crypt.UnwrapKey(k.locked.wrappedKey, kp)
+		if err != nil {
+			return err
+		}
+
+		k.personalKey = key
+	}
+
+	k.keyPair = kp
+	k.locked = nil
+
+	return nil
+}
+
+// Locked - reports whether keys are zeroed by Lock.
+func (k *Keyring) Locked() bool {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	return k.locked != nil
+}
+
+// Reset - zeroes and removes key pair and all cached vault keys, locked Keyring is unlocked.
+func (k *Keyring) Reset() {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	k.reset()
+	k.locked = nil
+}
+
+// reset - zeroes and removes keys, it's called under lock.
+func (k *Keyring) reset() {
+	if k.keyPair != nil {
+		k.keyPair.Zero()
+		k.keyPair = nil
+	}
+
+	zero(k.personalKey)
+	k.personalKey = nil
+
+	for id, key := range k.vaultKeys {
+		zero(key)
+		delete(k.vaultKeys, id)
+	}
+}
+
+// zero - overwrites provided bytes.
+func zero(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
+
+type personalCrypt struct {
+	keyring *Keyring
+	legacy  crypt.Crypter
+}
+
+// Encode - encodes payload with personal key, legacy crypt.Crypter only decodes secrets stored before personal keys,
+// so nothing is encoded while the key is not loaded or Keyring is locked.
+func (p *personalCrypt) Encode(payload string) (string, error) {
+	if p.keyring.Locked() {
+		return "", ErrLocked
+	}
+
+	cr := p.personalKeyCrypter()
+	if cr == nil {
+		return "", ErrNoKeyPair
+	}
+
+	return cr.Encode(payload)
+}
+
+// Decode - decodes sha with personal key and falls back to legacy crypt.Crypter, nothing is decoded while Keyring is
+// locked.
+func (p *personalCrypt) Decode(sha string) (string, error) {
+	if p.keyring.Locked() {
+		return "", ErrLocked
+	}
+
+	return crypt.NewFallbackCrypt(p.personalKeyCrypter(), p.legacy).Decode(sha)
+}
+
+// personalKeyCrypter - returns crypt.Crypter built from personal key, or nil if personal key is not set.
+func (p *personalCrypt) personalKeyCrypter() crypt.Crypter {
+	key, ok := p.keyring.PersonalKey()
+	if !ok {
+		return nil
+	}
+
+	cr, err := crypt.NewKeyCrypt(key)
+	if err != nil {
+		return nil
+	}
+
+	return cr
+}
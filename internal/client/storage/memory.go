@@ -70,6 +70,9 @@ func (ms *MemoryStorage) SetLoginPassSecrets(models []secret.LoginPassSecret) {
 
 // ResetStorage - removes all records from MemoryStorage.
 func (ms *MemoryStorage) ResetStorage() {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
 	ms.LoginPassSecrets = make(map[int]secret.LoginPassSecret, 0)
 	ms.TextSecrets = make(map[int]secret.TextSecret, 0)
 	ms.CardSecrets = make(map[int]secret.CardSecret, 0)
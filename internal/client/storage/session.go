@@ -0,0 +1,71 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"secretKeeper/internal/client/model"
+)
+
+// ErrNoSession - is returned when there is no cached session.
+var ErrNoSession = errors.New("session is missing, please login first")
+
+type SessionFile struct {
+	path string
+}
+
+// NewSessionFile - creates new SessionFile stored at provided path.
+func NewSessionFile(path string) *SessionFile {
+	return &SessionFile{path: path}
+}
+
+// Load - reads model.Session from file or returns ErrNoSession if file doesn't exist.
+func (s *SessionFile) Load() (model.Session, error) {
+	var session model.Session
+
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return session, ErrNoSession
+		}
+
+		return session, fmt.Errorf("error in reading session file: %w", err)
+	}
+
+	if errUnmarshal := json.Unmarshal(data, &session); errUnmarshal != nil {
+		return session, fmt.Errorf("session file is damaged: %w", errUnmarshal)
+	}
+
+	return session, nil
+}
+
+// Save - writes model.Session to file readable only by its owner.
+func (s *SessionFile) Save(session model.Session) error {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+
+	if errDir := os.MkdirAll(filepath.Dir(s.path), 0700); errDir != nil {
+		return fmt.Errorf("error in creating session directory: %w", errDir)
+	}
+
+	tmp := s.path + ".tmp"
+	if errWrite := os.WriteFile(tmp, data, 0600); errWrite != nil {
+		return fmt.Errorf("error in writing session file: %w", errWrite)
+	}
+
+	return os.Rename(tmp, s.path)
+}
+
+// Remove - removes session file, missing file is not an error.
+func (s *SessionFile) Remove() error {
+	if err := os.Remove(s.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("error in removing session file: %w", err)
+	}
+
+	return nil
+}
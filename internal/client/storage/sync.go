@@ -2,7 +2,10 @@ package storage
 
 import (
 	"encoding/json"
-	"fmt"
+	"errors"
+	"sort"
+	"sync"
+	"time"
 
 	"secretKeeper/internal/client/model"
 	"secretKeeper/internal/client/model/secret"
@@ -11,10 +14,11 @@ import (
 )
 
 type Syncer interface {
-	SyncAll()
+	SyncAll() error
 	SyncPassLoginData() error
 	SyncCardData() error
 	SyncTextData() error
+	Watch(fn func(ids []int)) (stop func())
 }
 
 type Sync struct {
@@ -22,37 +26,105 @@ type Sync struct {
 	secretClient pb.SecretClient
 	glCtx        *model.GlobalContext
 	cr           crypt.Crypter
+
+	mu sync.Mutex
+	// versions - are update times of synced secrets by type, they are compared to find changed secrets.
+	versions map[int]map[int]time.Time
+	watchers map[int]func(ids []int)
+	watchID  int
 }
 
 // NewSync - creates new Sync.
 func NewSync(de DataEditor, sc pb.SecretClient, ctx *model.GlobalContext, cr crypt.Crypter) *Sync {
-	return &Sync{storage: de, secretClient: sc, glCtx: ctx, cr: cr}
+	return &Sync{
+		storage:      de,
+		secretClient: sc,
+		glCtx:        ctx,
+		cr:           cr,
+		versions:     make(map[int]map[int]time.Time),
+		watchers:     make(map[int]func(ids []int)),
+	}
+}
+
+// Watch - calls fn with IDs of secrets which are created, updated or deleted by a sync, stop unregisters fn.
+//
+// First sync of a type reports all its secrets.
+func (s *Sync) Watch(fn func(ids []int)) (stop func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.watchID++
+	id := s.watchID
+	s.watchers[id] = fn
+
+	return func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		delete(s.watchers, id)
+	}
 }
 
-// SyncAll - runs SyncTextData, SyncPassLoginData, SyncCardData under the hood.
-func (s *Sync) SyncAll() {
-	if err := s.SyncTextData(); err != nil {
-		fmt.Println(err)
+// notify - compares update times of synced secrets of a type to previous sync and reports changed ones to watchers.
+func (s *Sync) notify(typeID int, versions map[int]time.Time) {
+	s.mu.Lock()
+
+	previous := s.versions[typeID]
+	s.versions[typeID] = versions
+
+	var changed []int
+
+	for id, updatedAt := range versions {
+		if before, ok := previous[id]; !ok || !before.Equal(updatedAt) {
+			changed = append(changed, id)
+		}
 	}
 
-	if err := s.SyncPassLoginData(); err != nil {
-		fmt.Println(err)
+	for id := range previous {
+		if _, ok := versions[id]; !ok {
+			changed = append(changed, id)
+		}
 	}
 
-	if err := s.SyncCardData(); err != nil {
-		fmt.Println(err)
+	watchers := make([]func(ids []int), 0, len(s.watchers))
+	for _, fn := range s.watchers {
+		watchers = append(watchers, fn)
 	}
+
+	s.mu.Unlock()
+
+	if len(changed) == 0 {
+		return
+	}
+
+	sort.Ints(changed)
+
+	for _, fn := range watchers {
+		fn(changed)
+	}
+}
+
+// SyncAll - runs SyncTextData, SyncPassLoginData, SyncCardData under the hood, a failed one doesn't stop the rest and
+// their errors are joined.
+func (s *Sync) SyncAll() error {
+	return errors.Join(s.SyncTextData(), s.SyncPassLoginData(), s.SyncCardData())
 }
 
 // SyncTextData - makes gRPC request to server and on success sets acquired records to MemoryStorage.TextSecrets.
 func (s *Sync) SyncTextData() error {
 	texts, err := s.secretClient.GetListOfSecretsByType(s.glCtx.Ctx, &pb.GetListOfSecretsByTypeRequest{TypeId: 2})
 	if err != nil {
-		panic(err)
+		return err
 	}
 
 	var list []secret.TextSecret
+	versions := make(map[int]time.Time)
 	for _, text := range texts.SecretLists {
+		// server keeps deleted secrets, another process may have deleted them
+		if text.IsDelited {
+			continue
+		}
+
 		id := int(text.Id)
 		m := secret.TextSecret{}
 
@@ -69,9 +141,11 @@ func (s *Sync) SyncTextData() error {
 		m.UpdatedAt = text.UpdatedAt.AsTime()
 
 		list = append(list, m)
+		versions[id] = m.UpdatedAt
 	}
 
 	s.storage.SetTextSecrets(list)
+	s.notify(2, versions)
 
 	return nil
 }
@@ -80,11 +154,17 @@ func (s *Sync) SyncTextData() error {
 func (s *Sync) SyncCardData() error {
 	cards, err := s.secretClient.GetListOfSecretsByType(s.glCtx.Ctx, &pb.GetListOfSecretsByTypeRequest{TypeId: 4})
 	if err != nil {
-		panic(err)
+		return err
 	}
 
 	var list []secret.CardSecret
+	versions := make(map[int]time.Time)
 	for _, card := range cards.SecretLists {
+		// server keeps deleted secrets, another process may have deleted them
+		if card.IsDelited {
+			continue
+		}
+
 		id := int(card.Id)
 		m := secret.CardSecret{}
 
@@ -101,9 +181,11 @@ func (s *Sync) SyncCardData() error {
 		m.UpdatedAt = card.UpdatedAt.AsTime()
 
 		list = append(list, m)
+		versions[id] = m.UpdatedAt
 	}
 
 	s.storage.SetCardSecrets(list)
+	s.notify(4, versions)
 
 	return nil
 }
@@ -113,11 +195,17 @@ func (s *Sync) SyncCardData() error {
 func (s *Sync) SyncPassLoginData() error {
 	lists, err := s.secretClient.GetListOfSecretsByType(s.glCtx.Ctx, &pb.GetListOfSecretsByTypeRequest{TypeId: 1})
 	if err != nil {
-		panic(err)
+		return err
 	}
 
 	var list []secret.LoginPassSecret
+	versions := make(map[int]time.Time)
 	for _, sList := range lists.SecretLists {
+		// server keeps deleted secrets, another process may have deleted them
+		if sList.IsDelited {
+			continue
+		}
+
 		id := int(sList.Id)
 		m := secret.LoginPassSecret{}
 
@@ -134,9 +222,11 @@ func (s *Sync) SyncPassLoginData() error {
 		m.UpdatedAt = sList.UpdatedAt.AsTime()
 
 		list = append(list, m)
+		versions[id] = m.UpdatedAt
 	}
 
 	s.storage.SetLoginPassSecrets(list)
+	s.notify(1, versions)
 
 	return nil
 }
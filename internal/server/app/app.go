@@ -3,6 +3,7 @@ package app
 import (
 	"context"
 	"fmt"
+	"time"
 
 	grpcauth "github.com/grpc-ecosystem/go-grpc-middleware/auth"
 	grpczap "github.com/grpc-ecosystem/go-grpc-middleware/logging/zap"
@@ -12,6 +13,7 @@ import (
 
 	"secretKeeper/internal/server/config"
 	"secretKeeper/internal/server/middleware/auth"
+	"secretKeeper/internal/server/scheduler"
 	"secretKeeper/internal/server/service"
 	"secretKeeper/internal/server/storage/postgres"
 	"secretKeeper/pkg/crypt"
@@ -22,9 +24,14 @@ import (
 )
 
 type App struct {
-	DB     *pgx.Conn
-	Server *server.GrpcServer
-	Logger *zap.Logger
+	DB *pgx.Conn
+	// SchedulerDB - is connection of Scheduler, pgx.Conn isn't safe for concurrent use, so the one of gRPC handlers
+	// isn't shared with it.
+	SchedulerDB *pgx.Conn
+	Server      *server.GrpcServer
+	Scheduler   *scheduler.ExpiryScheduler
+	Logger      *zap.Logger
+	Config      config.Config
 }
 
 // NewApp - creates new App.
@@ -66,27 +73,55 @@ func NewApp(ctx context.Context) (*App, error) {
 	secretStorage := postgres.NewSecretPostgresStorage(dbConn)
 	secretGrpcService := service.NewSecretGrpc(secretStorage)
 
+	sendStorage := postgres.NewSendPostgresStorage(dbConn)
+	sendGrpcService := service.NewSendGrpc(sendStorage)
+
+	schedulerConn, errScheduler := pgx.Connect(ctx, cfg.DSN)
+	if errScheduler != nil {
+		return nil, fmt.Errorf("scheduler db open: %w", errScheduler)
+	}
+
+	expiryScheduler := scheduler.NewExpiryScheduler(
+		postgres.NewSecretPostgresStorage(schedulerConn), postgres.NewSendPostgresStorage(schedulerConn), log,
+		time.Duration(cfg.ExpiryWindowDays)*24*time.Hour,
+	)
+
+	organizationStorage := postgres.NewOrganizationPostgresStorage(dbConn)
+	organizationGrpcService := service.NewOrganizationGrpc(organizationStorage)
+
+	emergencyStorage := postgres.NewEmergencyPostgresStorage(dbConn)
+	emergencyGrpcService := service.NewEmergencyGrpc(emergencyStorage, usersStorage)
+
 	jwtAuthMiddleware := auth.NewJwtMiddleware(jwtManager, cr).Auth
+	roleMiddleware := auth.NewRoleMiddleware(organizationStorage).Unary()
 
 	gRPCServer := server.NewGrpcServer(
 		server.WithServerConfig(cfg),
 		server.WithLogger(log),
-		server.WithServices(usersGrpcService, secretTypeGrpcService, secretGrpcService),
+		server.WithServices(
+			usersGrpcService, secretTypeGrpcService, secretGrpcService, organizationGrpcService, emergencyGrpcService,
+			sendGrpcService,
+		),
+		// recovery goes first, so a panic in any interceptor after it is turned into Internal error
 		server.WithStreamInterceptors(
+			grpcrecovery.StreamServerInterceptor(),
 			grpczap.StreamServerInterceptor(log),
 			grpcauth.StreamServerInterceptor(jwtAuthMiddleware),
-			grpcrecovery.StreamServerInterceptor(),
 		),
 		server.WithUnaryInterceptors(
+			grpcrecovery.UnaryServerInterceptor(),
 			grpczap.UnaryServerInterceptor(log),
 			grpcauth.UnaryServerInterceptor(jwtAuthMiddleware),
-			grpcrecovery.UnaryServerInterceptor(),
+			roleMiddleware,
 		),
 	)
 
 	return &App{
-		DB:     dbConn,
-		Server: gRPCServer,
-		Logger: log,
+		DB:          dbConn,
+		SchedulerDB: schedulerConn,
+		Server:      gRPCServer,
+		Scheduler:   expiryScheduler,
+		Logger:      log,
+		Config:      cfg,
 	}, nil
 }
@@ -26,9 +26,12 @@ type JwtMiddleware struct {
 // NewJwtMiddleware - creates JwtMiddleware.
 func NewJwtMiddleware(j jwt.Manager, c crypt.Crypter) *JwtMiddleware {
 	return &JwtMiddleware{
-		jwtManager:         j,
-		crypter:            c,
-		unProtectedMethods: []string{"/proto.User/Register", "/proto.User/Login"},
+		jwtManager: j,
+		crypter:    c,
+		unProtectedMethods: []string{
+			"/proto.User/Register", "/proto.User/Login", "/proto.User/GetRecoveryKey", "/proto.User/Recover",
+			"/proto.Send/OpenSend",
+		},
 	}
 }
 
@@ -0,0 +1,174 @@
+package auth
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"secretKeeper/internal/server/model"
+	"secretKeeper/internal/server/storage"
+)
+
+// RoleCtx - a unique type to avoid collisions.
+type RoleCtx struct{}
+
+// methodRule - describes minimal model.Role of a caller inside organization required to call a method.
+//
+// If personal is true, method can be called without organization scope, then caller works with their own data.
+type methodRule struct {
+	role     model.Role
+	personal bool
+}
+
+// vaultScoped - is implemented by requests which may address a vault.
+type vaultScoped interface {
+	GetVaultId() uint32
+}
+
+// organizationScoped - is implemented by requests which address an organization.
+type organizationScoped interface {
+	GetOrganizationId() uint32
+}
+
+type RoleMiddleware struct {
+	storage storage.OrganizationServerStorage
+	rules   map[string]methodRule
+}
+
+// NewRoleMiddleware - creates RoleMiddleware.
+func NewRoleMiddleware(s storage.OrganizationServerStorage) *RoleMiddleware {
+	return &RoleMiddleware{
+		storage: s,
+		rules: map[string]methodRule{
+			"/proto.User/Register":                 {role: model.RoleNone},
+			"/proto.User/Login":                    {role: model.RoleNone},
+			"/proto.User/Delete":                   {role: model.RoleNone},
+			"/proto.User/SetPublicKey":             {role: model.RoleNone},
+			"/proto.User/SetPersonalKey":           {role: model.RoleNone},
+			"/proto.User/GetPersonalKey":           {role: model.RoleNone},
+			"/proto.User/SetRecovery":              {role: model.RoleNone},
+			"/proto.User/GetRecoveryKey":           {role: model.RoleNone},
+			"/proto.User/Recover":                  {role: model.RoleNone},
+			"/proto.SecretType/GetSecretTypesList": {role: model.RoleNone},
+
+			"/proto.Secret/CreateSecret":           {role: model.RoleWriter, personal: true},
+			"/proto.Secret/CreateSecrets":          {role: model.RoleWriter, personal: true},
+			"/proto.Secret/GetSecret":              {role: model.RoleReader, personal: true},
+			"/proto.Secret/DeleteSecret":           {role: model.RoleWriter, personal: true},
+			"/proto.Secret/EditSecret":             {role: model.RoleWriter, personal: true},
+			"/proto.Secret/GetListOfSecretsByType": {role: model.RoleReader, personal: true},
+			"/proto.Secret/SetSecretExpiry":        {role: model.RoleWriter, personal: true},
+			"/proto.Secret/GetDueEvents":           {role: model.RoleNone},
+
+			"/proto.Organization/CreateOrganization": {role: model.RoleNone},
+			"/proto.Organization/ListOrganizations":  {role: model.RoleNone},
+			"/proto.Organization/AddMember":          {role: model.RoleAdmin},
+			"/proto.Organization/RemoveMember":       {role: model.RoleAdmin},
+			"/proto.Organization/ListMembers":        {role: model.RoleReader},
+			"/proto.Organization/CreateVault":        {role: model.RoleAdmin},
+			"/proto.Organization/ListVaults":         {role: model.RoleReader},
+			"/proto.Organization/GetVaultKey":        {role: model.RoleReader},
+			"/proto.Organization/RotateVaultKey":     {role: model.RoleAdmin},
+
+			"/proto.Emergency/LookupContact":      {role: model.RoleNone},
+			"/proto.Emergency/AddContact":         {role: model.RoleNone},
+			"/proto.Emergency/RemoveContact":      {role: model.RoleNone},
+			"/proto.Emergency/ListContacts":       {role: model.RoleNone},
+			"/proto.Emergency/ListGrantors":       {role: model.RoleNone},
+			"/proto.Emergency/RequestAccess":      {role: model.RoleNone},
+			"/proto.Emergency/DenyAccess":         {role: model.RoleNone},
+			"/proto.Emergency/GetEmergencyAccess": {role: model.RoleNone},
+
+			"/proto.Send/SendSecret": {role: model.RoleNone},
+			"/proto.Send/OpenSend":   {role: model.RoleNone},
+		},
+	}
+}
+
+// Unary - returns interceptor which checks role of a caller against the rule of called method.
+//
+// It must be chained after JwtMiddleware, as it relies on user id attached with JwtTokenCtx.
+//
+// Methods without a rule are rejected. On success role of a caller is attached to context with RoleCtx.
+func (r *RoleMiddleware) Unary() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		rule, ok := r.rules[info.FullMethod]
+		if !ok {
+			return nil, status.Errorf(codes.PermissionDenied, "method %s is not allowed", info.FullMethod)
+		}
+
+		if rule.role == model.RoleNone {
+			return handler(ctx, req)
+		}
+
+		role, isScoped, err := r.callerRole(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		if !isScoped {
+			if rule.personal {
+				return handler(ctx, req)
+			}
+
+			return nil, status.Error(codes.InvalidArgument, "organization or vault must be provided")
+		}
+
+		if role < rule.role {
+			return nil, status.Errorf(codes.PermissionDenied, "role %s or higher is required", rule.role)
+		}
+
+		return handler(context.WithValue(ctx, RoleCtx{}, role), req)
+	}
+}
+
+// callerRole - returns role of a caller inside organization addressed by request.
+//
+// If request doesn't address any organization or vault, then false is returned as representation of personal scope.
+func (r *RoleMiddleware) callerRole(ctx context.Context, req interface{}) (model.Role, bool, error) {
+	var (
+		role model.Role
+		err  error
+	)
+
+	token, _ := ctx.Value(JwtTokenCtx{}).(string)
+	uid, errParse := uuid.Parse(token)
+	if errParse != nil {
+		return model.RoleNone, false, status.Error(codes.Unauthenticated, errParse.Error())
+	}
+	user := model.User{ID: &uid}
+
+	switch scoped := req.(type) {
+	case vaultScoped:
+		if scoped.GetVaultId() == 0 {
+			return model.RoleNone, false, nil
+		}
+
+		role, err = r.storage.GetVaultRole(ctx, model.Vault{ID: int(scoped.GetVaultId())}, user)
+	case organizationScoped:
+		if scoped.GetOrganizationId() == 0 {
+			return model.RoleNone, false, nil
+		}
+
+		role, err = r.storage.GetMemberRole(ctx, model.Organization{ID: int(scoped.GetOrganizationId())}, user)
+	default:
+		return model.RoleNone, false, nil
+	}
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.RoleNone, true, status.Error(codes.PermissionDenied, "you are not a member of organization")
+		}
+
+		return model.RoleNone, true, status.Error(codes.Internal, err.Error())
+	}
+
+	return role, true, nil
+}
@@ -0,0 +1,150 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"secretKeeper/internal/server/model"
+	storagemock "secretKeeper/internal/server/storage/mock"
+	pb "secretKeeper/proto"
+)
+
+func TestRoleMiddleware_Unary(t *testing.T) {
+	uid := uuid.New()
+	user := model.User{ID: &uid}
+
+	tests := []struct {
+		name    string
+		method  string
+		token   string
+		req     interface{}
+		prepare func(m *storagemock.MockOrganizationServerStorage)
+		code    codes.Code
+		role    model.Role
+	}{
+		{
+			name:   "unknown method is denied",
+			method: "/proto.Secret/Unknown",
+			token:  uid.String(),
+			req:    &pb.GetSecretRequest{},
+			code:   codes.PermissionDenied,
+		},
+		{
+			name:   "method without role is allowed",
+			method: "/proto.User/Login",
+			req:    &pb.LoginRequest{},
+			code:   codes.OK,
+		},
+		{
+			name:   "personal secret is allowed without organization",
+			method: "/proto.Secret/CreateSecret",
+			token:  uid.String(),
+			req:    &pb.CreateSecretRequest{},
+			code:   codes.OK,
+		},
+		{
+			name:   "invalid token is unauthenticated",
+			method: "/proto.Secret/CreateSecret",
+			token:  "token",
+			req:    &pb.CreateSecretRequest{VaultId: 5},
+			code:   codes.Unauthenticated,
+		},
+		{
+			name:   "reader can't call write method",
+			method: "/proto.Secret/CreateSecret",
+			token:  uid.String(),
+			req:    &pb.CreateSecretRequest{VaultId: 5},
+			prepare: func(m *storagemock.MockOrganizationServerStorage) {
+				m.EXPECT().GetVaultRole(gomock.Any(), model.Vault{ID: 5}, user).Return(model.RoleReader, nil)
+			},
+			code: codes.PermissionDenied,
+		},
+		{
+			name:   "reader can call read method",
+			method: "/proto.Secret/GetSecret",
+			token:  uid.String(),
+			req:    &pb.GetSecretRequest{VaultId: 5},
+			prepare: func(m *storagemock.MockOrganizationServerStorage) {
+				m.EXPECT().GetVaultRole(gomock.Any(), model.Vault{ID: 5}, user).Return(model.RoleReader, nil)
+			},
+			code: codes.OK,
+			role: model.RoleReader,
+		},
+		{
+			name:   "non-member of vault is denied",
+			method: "/proto.Secret/GetSecret",
+			token:  uid.String(),
+			req:    &pb.GetSecretRequest{VaultId: 7},
+			prepare: func(m *storagemock.MockOrganizationServerStorage) {
+				m.EXPECT().GetVaultRole(gomock.Any(), model.Vault{ID: 7}, user).Return(model.RoleNone, pgx.ErrNoRows)
+			},
+			code: codes.PermissionDenied,
+		},
+		{
+			name:   "non-member of organization is denied",
+			method: "/proto.Organization/ListMembers",
+			token:  uid.String(),
+			req:    &pb.ListMembersRequest{OrganizationId: 3},
+			prepare: func(m *storagemock.MockOrganizationServerStorage) {
+				m.EXPECT().GetMemberRole(gomock.Any(), model.Organization{ID: 3}, user).
+					Return(model.RoleNone, pgx.ErrNoRows)
+			},
+			code: codes.PermissionDenied,
+		},
+		{
+			name:   "organization method requires organization",
+			method: "/proto.Organization/ListMembers",
+			token:  uid.String(),
+			req:    &pb.ListMembersRequest{},
+			code:   codes.InvalidArgument,
+		},
+		{
+			name:   "storage failure is internal error",
+			method: "/proto.Secret/GetSecret",
+			token:  uid.String(),
+			req:    &pb.GetSecretRequest{VaultId: 5},
+			prepare: func(m *storagemock.MockOrganizationServerStorage) {
+				m.EXPECT().GetVaultRole(gomock.Any(), model.Vault{ID: 5}, user).
+					Return(model.RoleNone, errors.New("connection lost"))
+			},
+			code: codes.Internal,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+
+			organizationMock := storagemock.NewMockOrganizationServerStorage(ctl)
+			if tt.prepare != nil {
+				tt.prepare(organizationMock)
+			}
+
+			called := false
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				called = true
+				role, _ := ctx.Value(RoleCtx{}).(model.Role)
+				assert.Equal(t, tt.role, role)
+
+				return req, nil
+			}
+
+			ctx := context.WithValue(context.Background(), JwtTokenCtx{}, tt.token)
+			_, err := NewRoleMiddleware(organizationMock).Unary()(
+				ctx, tt.req, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler,
+			)
+
+			assert.Equal(t, tt.code, status.Code(err))
+			assert.Equal(t, tt.code == codes.OK, called, "handler is called only for allowed requests")
+		})
+	}
+}
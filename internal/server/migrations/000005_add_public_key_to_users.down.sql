@@ -0,0 +1 @@
+alter table users drop column if exists public_key;
\ No newline at end of file
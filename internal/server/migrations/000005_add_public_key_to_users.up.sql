@@ -0,0 +1 @@
+alter table users add column if not exists public_key bytea;
\ No newline at end of file
@@ -0,0 +1,18 @@
+create table if not exists organizations
+(
+    id         bigserial primary key,
+    title      text not null,
+    created_at TIMESTAMPTZ default now()
+);
+
+create table if not exists organization_members
+(
+    organization_id bigint not null,
+    user_id         uuid   not null,
+    role            text   not null,
+    created_at      TIMESTAMPTZ default now(),
+
+    primary key (organization_id, user_id),
+    constraint fk_organization_id foreign key (organization_id) references organizations (id) on delete cascade,
+    constraint fk_user_id foreign key (user_id) references users (id) on delete cascade
+);
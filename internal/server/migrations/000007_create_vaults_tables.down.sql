@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS vault_keys;
+DROP TABLE IF EXISTS vaults;
\ No newline at end of file
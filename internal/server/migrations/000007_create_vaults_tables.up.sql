@@ -0,0 +1,23 @@
+create table if not exists vaults
+(
+    id                bigserial primary key,
+    organization_id   bigint  not null,
+    title             text    not null,
+    key_version       integer not null default 1,
+    rotation_required boolean not null default false,
+    created_at        TIMESTAMPTZ default now(),
+
+    constraint fk_organization_id foreign key (organization_id) references organizations (id) on delete cascade
+);
+
+create table if not exists vault_keys
+(
+    vault_id    bigint  not null,
+    user_id     uuid    not null,
+    key_version integer not null,
+    wrapped_key bytea   not null,
+
+    primary key (vault_id, user_id),
+    constraint fk_vault_id foreign key (vault_id) references vaults (id) on delete cascade,
+    constraint fk_user_id foreign key (user_id) references users (id) on delete cascade
+);
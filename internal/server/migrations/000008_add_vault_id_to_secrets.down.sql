@@ -0,0 +1 @@
+alter table secrets drop column if exists vault_id;
\ No newline at end of file
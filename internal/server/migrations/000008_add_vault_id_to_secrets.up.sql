@@ -0,0 +1,5 @@
+alter table secrets
+    add column if not exists vault_id bigint null,
+    add constraint fk_vault_id foreign key (vault_id) references vaults (id) on delete cascade;
+
+create index if not exists index_vault_id_secrets on secrets (vault_id);
@@ -0,0 +1 @@
+alter table users drop column if exists personal_key;
\ No newline at end of file
@@ -0,0 +1,14 @@
+create table if not exists emergency_contacts
+(
+    owner_id     uuid    not null,
+    contact_id   uuid    not null,
+    wait_hours   integer not null,
+    status       text    not null default 'idle',
+    wrapped_key  bytea   not null,
+    requested_at TIMESTAMPTZ,
+    created_at   TIMESTAMPTZ default now(),
+
+    primary key (owner_id, contact_id),
+    constraint fk_owner_id foreign key (owner_id) references users (id) on delete cascade,
+    constraint fk_contact_id foreign key (contact_id) references users (id) on delete cascade
+);
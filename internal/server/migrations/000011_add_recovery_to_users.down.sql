@@ -0,0 +1,2 @@
+alter table users drop column if exists recovery_token_hash;
+alter table users drop column if exists recovery_key;
\ No newline at end of file
@@ -0,0 +1,2 @@
+alter table users add column if not exists recovery_key bytea;
+alter table users add column if not exists recovery_token_hash bytea;
\ No newline at end of file
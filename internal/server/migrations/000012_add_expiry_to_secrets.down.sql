@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS secret_events;
+
+alter table secrets
+    drop column if exists expires_at,
+    drop column if exists rotate_every_days,
+    drop column if exists auto_expire;
@@ -0,0 +1,17 @@
+alter table secrets
+    add column if not exists expires_at        TIMESTAMPTZ,
+    add column if not exists rotate_every_days integer,
+    add column if not exists auto_expire       boolean not null default false;
+
+create index if not exists index_expires_at_secrets on secrets (expires_at);
+
+create table if not exists secret_events
+(
+    secret_id  bigint not null,
+    kind       text   not null,
+    due_at     TIMESTAMPTZ not null,
+    created_at TIMESTAMPTZ default now(),
+
+    primary key (secret_id, kind),
+    constraint fk_secret_id foreign key (secret_id) references secrets (id) on delete cascade
+);
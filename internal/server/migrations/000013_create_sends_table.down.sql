@@ -0,0 +1 @@
+DROP TABLE IF EXISTS sends;
\ No newline at end of file
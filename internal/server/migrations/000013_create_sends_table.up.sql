@@ -0,0 +1,13 @@
+create table if not exists sends
+(
+    id         uuid primary key default gen_random_uuid(),
+    user_id    uuid        not null,
+    content    bytea       not null,
+    views_left integer     not null,
+    expires_at TIMESTAMPTZ not null,
+    created_at TIMESTAMPTZ default now(),
+
+    constraint fk_user_id foreign key (user_id) references users (id) on delete cascade
+);
+
+create index if not exists index_expires_at_sends on sends (expires_at);
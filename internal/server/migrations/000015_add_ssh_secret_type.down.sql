@@ -0,0 +1 @@
+delete from secret_types where id = 6;
@@ -0,0 +1,5 @@
+insert into secret_types (id, title)
+values (6, 'ssh')
+on conflict (id) do nothing;
+
+select setval(pg_get_serial_sequence('secret_types', 'id'), (select max(id) from secret_types));
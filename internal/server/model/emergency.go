@@ -0,0 +1,55 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EmergencyStatus - is a state of emergency access request of a trusted contact.
+type EmergencyStatus string
+
+const (
+	EmergencyIdle      EmergencyStatus = "idle"
+	EmergencyRequested EmergencyStatus = "requested"
+	EmergencyDenied    EmergencyStatus = "denied"
+	// EmergencyGranted - is never stored, requested access becomes granted once waiting period is over.
+	EmergencyGranted EmergencyStatus = "granted"
+)
+
+// EmergencyContact - is a trusted contact of an owner, who can request access to personal secrets of the owner.
+//
+// WrappedKey is a personal key of the owner wrapped for public key of the contact.
+type EmergencyContact struct {
+	OwnerID      uuid.UUID       `json:"owner_id"`
+	OwnerLogin   string          `json:"owner_login"`
+	ContactID    uuid.UUID       `json:"contact_id"`
+	ContactLogin string          `json:"contact_login"`
+	WaitHours    int             `json:"wait_hours"`
+	Status       EmergencyStatus `json:"status"`
+	WrappedKey   []byte          `json:"-"`
+	RequestedAt  *time.Time      `json:"requested_at"`
+	CreatedAt    time.Time       `json:"created_at"`
+}
+
+// AvailableAt - returns time when requested access becomes granted, or nil if access is not requested.
+func (c EmergencyContact) AvailableAt() *time.Time {
+	if c.Status != EmergencyRequested || c.RequestedAt == nil {
+		return nil
+	}
+
+	at := c.RequestedAt.Add(time.Duration(c.WaitHours) * time.Hour)
+
+	return &at
+}
+
+// CurrentStatus - returns EmergencyGranted if waiting period of requested access is over at provided time,
+// otherwise returns stored status.
+func (c EmergencyContact) CurrentStatus(now time.Time) EmergencyStatus {
+	at := c.AvailableAt()
+	if at != nil && !now.Before(*at) {
+		return EmergencyGranted
+	}
+
+	return c.Status
+}
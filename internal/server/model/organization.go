@@ -0,0 +1,59 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Role - is a role of a member inside an organization. Roles are ordered, so a higher role includes all permissions
+// of a lower one.
+type Role int
+
+const (
+	RoleNone Role = iota
+	RoleReader
+	RoleWriter
+	RoleAdmin
+	RoleOwner
+)
+
+var roleTitles = map[Role]string{
+	RoleNone:   "",
+	RoleReader: "reader",
+	RoleWriter: "writer",
+	RoleAdmin:  "admin",
+	RoleOwner:  "owner",
+}
+
+// String - returns title of Role as it is stored in database.
+func (r Role) String() string {
+	return roleTitles[r]
+}
+
+// ParseRole - returns Role by its title.
+func ParseRole(title string) (Role, error) {
+	for role, t := range roleTitles {
+		if role != RoleNone && t == title {
+			return role, nil
+		}
+	}
+
+	return RoleNone, fmt.Errorf("unknown role %q", title)
+}
+
+type Organization struct {
+	ID        int       `json:"id"`
+	Title     string    `json:"title"`
+	Role      Role      `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Member struct {
+	OrganizationID int       `json:"organization_id"`
+	UserID         uuid.UUID `json:"user_id"`
+	Login          string    `json:"login"`
+	Role           Role      `json:"role"`
+	PublicKey      []byte    `json:"public_key"`
+}
@@ -9,10 +9,37 @@ import (
 type Secret struct {
 	ID        int       `json:"ID"`
 	UserID    uuid.UUID `json:"user_id"`
+	VaultID   int       `json:"vault_id"`
 	TypeID    int       `json:"type_id"`
 	Title     string    `json:"title"`
 	Content   []byte    `json:"content"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 	IsDelited bool      `json:"is_deleted"`
+
+	ExpiresAt       *time.Time `json:"expires_at"`
+	RotateEveryDays int        `json:"rotate_every_days"`
+	// AutoExpire - moves secret to trash once ExpiresAt is passed.
+	AutoExpire bool `json:"auto_expire"`
+}
+
+// DueEventKind - is a kind of reminder about a secret which needs attention.
+type DueEventKind string
+
+const (
+	// DueExpiring - secret expires within reminder window or has already expired.
+	DueExpiring DueEventKind = "expiring"
+	// DueRotation - secret was not updated for longer than its rotation period.
+	DueRotation DueEventKind = "rotation"
+	// DueExpired - secret has expired and has been moved to trash automatically.
+	DueExpired DueEventKind = "expired"
+)
+
+// DueEvent - is a reminder about a secret emitted by expiry scheduler.
+type DueEvent struct {
+	SecretID int          `json:"secret_id"`
+	VaultID  int          `json:"vault_id"`
+	Title    string       `json:"title"`
+	Kind     DueEventKind `json:"kind"`
+	DueAt    time.Time    `json:"due_at"`
 }
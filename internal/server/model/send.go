@@ -0,0 +1,19 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Send - is a one-time secret for a person without account.
+//
+// Content is encrypted by sender with a key the server never sees, so the server only counts views and purges it.
+type Send struct {
+	ID        uuid.UUID `json:"id"`
+	UserID    uuid.UUID `json:"user_id"`
+	Content   []byte    `json:"-"`
+	ViewsLeft int       `json:"views_left"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
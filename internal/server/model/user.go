@@ -3,7 +3,11 @@ package model
 import "github.com/google/uuid"
 
 type User struct {
-	ID       *uuid.UUID `json:"id"`
-	Login    string     `json:"login" validate:"gte=3"`
-	Password string     `json:"-" validate:"gte=3"`
+	ID                *uuid.UUID `json:"id"`
+	Login             string     `json:"login" validate:"gte=3"`
+	Password          string     `json:"-" validate:"gte=3"`
+	PublicKey         []byte     `json:"public_key"`
+	PersonalKey       []byte     `json:"-"`
+	RecoveryKey       []byte     `json:"-"`
+	RecoveryTokenHash []byte     `json:"-"`
 }
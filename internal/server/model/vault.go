@@ -0,0 +1,24 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Vault struct {
+	ID               int       `json:"id"`
+	OrganizationID   int       `json:"organization_id"`
+	Title            string    `json:"title"`
+	KeyVersion       int       `json:"key_version"`
+	RotationRequired bool      `json:"rotation_required"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+// VaultKey - is a vault key wrapped with public key of a member, so only that member can unwrap it.
+type VaultKey struct {
+	VaultID    int       `json:"vault_id"`
+	UserID     uuid.UUID `json:"user_id"`
+	KeyVersion int       `json:"key_version"`
+	WrappedKey []byte    `json:"wrapped_key"`
+}
@@ -0,0 +1,75 @@
+// Package scheduler is package that runs periodic jobs of server application
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+
+	"secretKeeper/internal/server/storage"
+)
+
+type ExpiryScheduler struct {
+	storage storage.SecretServerStorage
+	sends   storage.SendServerStorage
+	logger  *zap.Logger
+	cron    *cron.Cron
+	window  time.Duration
+}
+
+// NewExpiryScheduler - creates new ExpiryScheduler, which reminds about secrets expiring or needing rotation within
+// provided window, moves auto expiring secrets to trash and purges expired sends.
+func NewExpiryScheduler(
+	s storage.SecretServerStorage, sends storage.SendServerStorage, l *zap.Logger, window time.Duration,
+) *ExpiryScheduler {
+	return &ExpiryScheduler{
+		storage: s,
+		sends:   sends,
+		logger:  l,
+		// storages may share one connection, so refresh never overlaps a slow previous one
+		cron:   cron.New(cron.WithChain(cron.SkipIfStillRunning(cron.DiscardLogger))),
+		window: window,
+	}
+}
+
+// Start - runs refresh once and then schedules it according to cron spec.
+func (e *ExpiryScheduler) Start(ctx context.Context, spec string) error {
+	if _, err := e.cron.AddFunc(spec, func() { e.Refresh(ctx) }); err != nil {
+		return err
+	}
+
+	e.Refresh(ctx)
+	e.cron.Start()
+
+	e.logger.Sugar().Infof("expiry scheduler is running with schedule %s", spec)
+
+	return nil
+}
+
+// Refresh - moves expired secrets to trash, renews reminders about due secrets and purges expired sends.
+func (e *ExpiryScheduler) Refresh(ctx context.Context) {
+	now := time.Now()
+
+	expired, err := e.storage.RefreshDueEvents(ctx, now, now.Add(e.window))
+	if err != nil {
+		e.logger.Error(err.Error())
+	} else if expired > 0 {
+		e.logger.Sugar().Infof("%d expired secrets moved to trash", expired)
+	}
+
+	purged, errPurge := e.sends.PurgeSends(ctx, now)
+	if errPurge != nil {
+		e.logger.Error(errPurge.Error())
+	} else if purged > 0 {
+		e.logger.Sugar().Infof("%d expired sends purged", purged)
+	}
+}
+
+// Stop - stops scheduling and waits for running refresh to finish.
+func (e *ExpiryScheduler) Stop() {
+	e.logger.Info("Stopping expiry scheduler")
+
+	<-e.cron.Stop().Done()
+}
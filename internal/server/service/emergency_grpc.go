@@ -0,0 +1,276 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"secretKeeper/internal/server/model"
+	"secretKeeper/internal/server/storage"
+	pb "secretKeeper/proto"
+)
+
+// maxEmergencyWaitHours - is the longest waiting period an owner can configure for a contact.
+const maxEmergencyWaitHours = 24 * 90
+
+type EmergencyGrpc struct {
+	pb.UnimplementedEmergencyServer
+
+	storage     storage.EmergencyServerStorage
+	userStorage storage.UserServerStorage
+}
+
+// NewEmergencyGrpc - creates new emergency access grpc service.
+func NewEmergencyGrpc(s storage.EmergencyServerStorage, u storage.UserServerStorage) *EmergencyGrpc {
+	return &EmergencyGrpc{storage: s, userStorage: u}
+}
+
+// RegisterService - registers service via grpc server.
+func (e *EmergencyGrpc) RegisterService(r grpc.ServiceRegistrar) {
+	pb.RegisterEmergencyServer(r, e)
+}
+
+// LookupContact - returns public key of a user by login, so personal key could be wrapped for the user.
+func (e *EmergencyGrpc) LookupContact(
+	ctx context.Context, in *pb.LookupContactRequest,
+) (*pb.LookupContactResponse, error) {
+	user, err := e.userStorage.GetByLogin(ctx, model.User{Login: in.Login})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "user not found")
+		}
+
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	if len(user.PublicKey) == 0 {
+		return nil, status.Error(codes.NotFound, "user has never logged in to publish public key")
+	}
+
+	return &pb.LookupContactResponse{PublicKey: user.PublicKey}, nil
+}
+
+// AddContact - designates a user as trusted contact of authorized user.
+//
+// Personal key of authorized user must be already wrapped for public key of the contact.
+func (e *EmergencyGrpc) AddContact(ctx context.Context, in *pb.AddContactRequest) (*pb.AddContactResponse, error) {
+	owner, err := userFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if in.WaitHours == 0 || in.WaitHours > maxEmergencyWaitHours {
+		return nil, status.Errorf(codes.InvalidArgument, "waiting period must be from 1 to %d hours", maxEmergencyWaitHours)
+	}
+
+	if len(in.WrappedKey) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "wrapped key is required")
+	}
+
+	contact, errAdd := e.storage.AddContact(ctx, model.EmergencyContact{
+		OwnerID:      *owner.ID,
+		ContactLogin: in.Login,
+		WaitHours:    int(in.WaitHours),
+		WrappedKey:   in.WrappedKey,
+	})
+	if errAdd != nil {
+		if errors.Is(errAdd, pgx.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "contact not found or has never logged in to publish public key")
+		}
+
+		return nil, status.Error(codes.Internal, errAdd.Error())
+	}
+
+	return &pb.AddContactResponse{Contact: castEmergencyContact(contact, contact.ContactLogin, time.Now())}, nil
+}
+
+// RemoveContact - removes trusted contact of authorized user together with personal key wrapped for the contact.
+func (e *EmergencyGrpc) RemoveContact(
+	ctx context.Context, in *pb.RemoveContactRequest,
+) (*pb.RemoveContactResponse, error) {
+	owner, err := userFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	_, errRemove := e.storage.RemoveContact(ctx, model.EmergencyContact{OwnerID: *owner.ID, ContactLogin: in.Login})
+	if errRemove != nil {
+		if errors.Is(errRemove, pgx.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "contact not found")
+		}
+
+		return nil, status.Error(codes.Internal, errRemove.Error())
+	}
+
+	return &pb.RemoveContactResponse{}, nil
+}
+
+// ListContacts - returns trusted contacts of authorized user.
+func (e *EmergencyGrpc) ListContacts(
+	ctx context.Context, in *pb.ListContactsRequest,
+) (*pb.ListContactsResponse, error) {
+	owner, err := userFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	contacts, errList := e.storage.GetContacts(ctx, owner)
+	if errList != nil {
+		return nil, status.Error(codes.Internal, errList.Error())
+	}
+
+	now := time.Now()
+
+	resp := &pb.ListContactsResponse{}
+	for _, contact := range contacts {
+		resp.Contacts = append(resp.Contacts, castEmergencyContact(contact, contact.ContactLogin, now))
+	}
+
+	return resp, nil
+}
+
+// ListGrantors - returns owners who designated authorized user as their trusted contact.
+func (e *EmergencyGrpc) ListGrantors(
+	ctx context.Context, in *pb.ListGrantorsRequest,
+) (*pb.ListGrantorsResponse, error) {
+	contact, err := userFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	grantors, errList := e.storage.GetGrantors(ctx, contact)
+	if errList != nil {
+		return nil, status.Error(codes.Internal, errList.Error())
+	}
+
+	now := time.Now()
+
+	resp := &pb.ListGrantorsResponse{}
+	for _, grantor := range grantors {
+		resp.Grantors = append(resp.Grantors, castEmergencyContact(grantor, grantor.OwnerLogin, now))
+	}
+
+	return resp, nil
+}
+
+// RequestAccess - starts waiting period after which authorized user gets access to personal secrets of the owner,
+// unless the owner denies it.
+func (e *EmergencyGrpc) RequestAccess(
+	ctx context.Context, in *pb.RequestAccessRequest,
+) (*pb.RequestAccessResponse, error) {
+	contact, err := userFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	grantor, errRequest := e.storage.RequestAccess(
+		ctx, model.EmergencyContact{OwnerLogin: in.OwnerLogin, ContactID: *contact.ID},
+	)
+	if errRequest != nil {
+		if errors.Is(errRequest, pgx.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "you are not a trusted contact of this user")
+		}
+
+		return nil, status.Error(codes.Internal, errRequest.Error())
+	}
+
+	return &pb.RequestAccessResponse{Grantor: castEmergencyContact(grantor, in.OwnerLogin, time.Now())}, nil
+}
+
+// DenyAccess - denies access requested by trusted contact of authorized user.
+func (e *EmergencyGrpc) DenyAccess(ctx context.Context, in *pb.DenyAccessRequest) (*pb.DenyAccessResponse, error) {
+	owner, err := userFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	_, errDeny := e.storage.DenyAccess(ctx, model.EmergencyContact{OwnerID: *owner.ID, ContactLogin: in.Login})
+	if errDeny != nil {
+		if errors.Is(errDeny, pgx.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "contact not found")
+		}
+
+		return nil, status.Error(codes.Internal, errDeny.Error())
+	}
+
+	return &pb.DenyAccessResponse{}, nil
+}
+
+// GetEmergencyAccess - returns personal key of the owner wrapped for authorized user together with encrypted
+// personal secrets of the owner.
+//
+// Access must be requested and waiting period must be over, otherwise codes.PermissionDenied is returned.
+func (e *EmergencyGrpc) GetEmergencyAccess(
+	ctx context.Context, in *pb.GetEmergencyAccessRequest,
+) (*pb.GetEmergencyAccessResponse, error) {
+	contact, err := userFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	grantor, errGrantor := e.storage.GetGrantor(
+		ctx, model.EmergencyContact{OwnerLogin: in.OwnerLogin, ContactID: *contact.ID},
+	)
+	if errGrantor != nil {
+		if errors.Is(errGrantor, pgx.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "you are not a trusted contact of this user")
+		}
+
+		return nil, status.Error(codes.Internal, errGrantor.Error())
+	}
+
+	switch grantor.CurrentStatus(time.Now()) {
+	case model.EmergencyGranted:
+	case model.EmergencyRequested:
+		return nil, status.Errorf(
+			codes.PermissionDenied, "access will be granted at %s", grantor.AvailableAt().Format(time.RFC3339),
+		)
+	case model.EmergencyDenied:
+		return nil, status.Error(codes.PermissionDenied, "access has been denied by the owner")
+	default:
+		return nil, status.Error(codes.PermissionDenied, "access has to be requested first")
+	}
+
+	secrets, errSecrets := e.storage.GetPersonalSecrets(ctx, model.User{ID: &grantor.OwnerID})
+	if errSecrets != nil {
+		return nil, status.Error(codes.Internal, errSecrets.Error())
+	}
+
+	resp := &pb.GetEmergencyAccessResponse{WrappedKey: grantor.WrappedKey}
+	for _, secret := range secrets {
+		resp.Secrets = append(resp.Secrets, &pb.EmergencySecret{
+			Id:        uint32(secret.ID),
+			Title:     secret.Title,
+			Type:      uint32(secret.TypeID),
+			Content:   secret.Content,
+			UpdatedAt: timestamppb.New(secret.UpdatedAt),
+		})
+	}
+
+	return resp, nil
+}
+
+// castEmergencyContact - casts model.EmergencyContact to *pb.EmergencyContact with provided login of other side.
+func castEmergencyContact(contact model.EmergencyContact, login string, now time.Time) *pb.EmergencyContact {
+	item := &pb.EmergencyContact{
+		Login:     login,
+		WaitHours: uint32(contact.WaitHours),
+		Status:    string(contact.CurrentStatus(now)),
+	}
+
+	if contact.RequestedAt != nil {
+		item.RequestedAt = timestamppb.New(*contact.RequestedAt)
+	}
+
+	if at := contact.AvailableAt(); at != nil {
+		item.AvailableAt = timestamppb.New(*at)
+	}
+
+	return item
+}
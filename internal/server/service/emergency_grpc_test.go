@@ -0,0 +1,299 @@
+package service
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	grpcauth "github.com/grpc-ecosystem/go-grpc-middleware/auth"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"secretKeeper/internal/server/middleware/auth"
+	"secretKeeper/internal/server/model"
+	storagemock "secretKeeper/internal/server/storage/mock"
+	cryptmock "secretKeeper/pkg/crypt/mock"
+	jwtmock "secretKeeper/pkg/jwt/mock"
+	pb "secretKeeper/proto"
+)
+
+func TestEmergencyGrpc_RegisterService(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	emergencyMock := storagemock.NewMockEmergencyServerStorage(ctl)
+	userMock := storagemock.NewMockUserServerStorage(ctl)
+
+	tests := []struct {
+		name string
+	}{
+		{
+			name: "Registrar can be called without errors",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewEmergencyGrpc(emergencyMock, userMock)
+
+			server := grpc.NewServer()
+
+			s.RegisterService(server)
+		})
+	}
+}
+
+func TestEmergencyGrpc_LookupContact(t *testing.T) {
+	uid := uuid.New()
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer token")
+
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	client, done := emergencyTestClient(t, ctl, uid)
+	defer close(done)
+
+	res, err := client.LookupContact(ctx, &pb.LookupContactRequest{Login: "contact"})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("public"), res.PublicKey)
+
+	_, err = client.LookupContact(ctx, &pb.LookupContactRequest{Login: "without-key"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = client.LookupContact(ctx, &pb.LookupContactRequest{Login: "unknown"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestEmergencyGrpc_AddContact(t *testing.T) {
+	uid := uuid.New()
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer token")
+
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	client, done := emergencyTestClient(t, ctl, uid)
+	defer close(done)
+
+	res, err := client.AddContact(ctx, &pb.AddContactRequest{Login: "contact", WaitHours: 48, WrappedKey: []byte{1}})
+	assert.NoError(t, err)
+	assert.Equal(t, string(model.EmergencyIdle), res.Contact.Status)
+
+	_, err = client.AddContact(ctx, &pb.AddContactRequest{Login: "contact", WrappedKey: []byte{1}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.AddContact(ctx, &pb.AddContactRequest{Login: "contact", WaitHours: 48})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.AddContact(ctx, &pb.AddContactRequest{Login: "unknown", WaitHours: 48, WrappedKey: []byte{1}})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestEmergencyGrpc_ListGrantors(t *testing.T) {
+	uid := uuid.New()
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer token")
+
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	client, done := emergencyTestClient(t, ctl, uid)
+	defer close(done)
+
+	res, err := client.ListGrantors(ctx, &pb.ListGrantorsRequest{})
+	assert.NoError(t, err)
+	assert.Len(t, res.Grantors, 3)
+
+	statuses := map[string]string{}
+	for _, grantor := range res.Grantors {
+		statuses[grantor.Login] = grantor.Status
+	}
+
+	assert.Equal(t, string(model.EmergencyGranted), statuses["granted"])
+	assert.Equal(t, string(model.EmergencyRequested), statuses["waiting"])
+	assert.Equal(t, string(model.EmergencyDenied), statuses["denied"])
+}
+
+func TestEmergencyGrpc_RequestAccess(t *testing.T) {
+	uid := uuid.New()
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer token")
+
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	client, done := emergencyTestClient(t, ctl, uid)
+	defer close(done)
+
+	res, err := client.RequestAccess(ctx, &pb.RequestAccessRequest{OwnerLogin: "waiting"})
+	assert.NoError(t, err)
+	assert.Equal(t, string(model.EmergencyRequested), res.Grantor.Status)
+	assert.NotNil(t, res.Grantor.AvailableAt)
+
+	_, err = client.RequestAccess(ctx, &pb.RequestAccessRequest{OwnerLogin: "stranger"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestEmergencyGrpc_GetEmergencyAccess(t *testing.T) {
+	uid := uuid.New()
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer token")
+
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	client, done := emergencyTestClient(t, ctl, uid)
+	defer close(done)
+
+	res, err := client.GetEmergencyAccess(ctx, &pb.GetEmergencyAccessRequest{OwnerLogin: "granted"})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("wrapped"), res.WrappedKey)
+	assert.Len(t, res.Secrets, 1)
+
+	_, err = client.GetEmergencyAccess(ctx, &pb.GetEmergencyAccessRequest{OwnerLogin: "waiting"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = client.GetEmergencyAccess(ctx, &pb.GetEmergencyAccessRequest{OwnerLogin: "denied"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = client.GetEmergencyAccess(ctx, &pb.GetEmergencyAccessRequest{OwnerLogin: "stranger"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func emergencyTestClient(
+	t *testing.T, ctl *gomock.Controller, uid uuid.UUID,
+) (pb.EmergencyClient, chan<- struct{}) {
+	done := make(chan struct{})
+
+	ownerID := uuid.New()
+	longAgo := time.Now().Add(-72 * time.Hour)
+	justNow := time.Now()
+
+	grantors := map[string]model.EmergencyContact{
+		"granted": {
+			OwnerID: ownerID, OwnerLogin: "granted", ContactID: uid, WaitHours: 48,
+			Status: model.EmergencyRequested, RequestedAt: &longAgo, WrappedKey: []byte("wrapped"),
+		},
+		"waiting": {
+			OwnerID: ownerID, OwnerLogin: "waiting", ContactID: uid, WaitHours: 48,
+			Status: model.EmergencyRequested, RequestedAt: &justNow, WrappedKey: []byte("wrapped"),
+		},
+		"denied": {
+			OwnerID: ownerID, OwnerLogin: "denied", ContactID: uid, WaitHours: 48,
+			Status: model.EmergencyDenied, WrappedKey: []byte("wrapped"),
+		},
+	}
+
+	userStorageMock := storagemock.NewMockUserServerStorage(ctl)
+	userStorageMock.EXPECT().
+		GetByLogin(gomock.Any(), gomock.Eq(model.User{Login: "contact"})).
+		AnyTimes().
+		Return(model.User{ID: &uid, Login: "contact", PublicKey: []byte("public")}, nil)
+	userStorageMock.EXPECT().
+		GetByLogin(gomock.Any(), gomock.Eq(model.User{Login: "without-key"})).
+		AnyTimes().
+		Return(model.User{ID: &uid, Login: "without-key"}, nil)
+	userStorageMock.EXPECT().
+		GetByLogin(gomock.Any(), gomock.Eq(model.User{Login: "unknown"})).
+		AnyTimes().
+		Return(model.User{}, pgx.ErrNoRows)
+
+	emergencyStorageMock := storagemock.NewMockEmergencyServerStorage(ctl)
+	emergencyStorageMock.EXPECT().
+		AddContact(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		DoAndReturn(func(ctx context.Context, contact model.EmergencyContact) (model.EmergencyContact, error) {
+			if contact.ContactLogin != "contact" {
+				return contact, pgx.ErrNoRows
+			}
+
+			contact.Status = model.EmergencyIdle
+
+			return contact, nil
+		})
+	emergencyStorageMock.EXPECT().
+		GetGrantors(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		Return([]model.EmergencyContact{grantors["granted"], grantors["waiting"], grantors["denied"]}, nil)
+	emergencyStorageMock.EXPECT().
+		RequestAccess(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		DoAndReturn(func(ctx context.Context, contact model.EmergencyContact) (model.EmergencyContact, error) {
+			grantor, ok := grantors[contact.OwnerLogin]
+			if !ok {
+				return contact, pgx.ErrNoRows
+			}
+
+			return grantor, nil
+		})
+	emergencyStorageMock.EXPECT().
+		GetGrantor(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		DoAndReturn(func(ctx context.Context, contact model.EmergencyContact) (model.EmergencyContact, error) {
+			grantor, ok := grantors[contact.OwnerLogin]
+			if !ok {
+				return contact, pgx.ErrNoRows
+			}
+
+			return grantor, nil
+		})
+	emergencyStorageMock.EXPECT().
+		GetPersonalSecrets(gomock.Any(), gomock.Eq(model.User{ID: &ownerID})).
+		AnyTimes().
+		Return([]model.Secret{{ID: 1, UserID: ownerID, TypeID: 2, Title: "note", Content: []byte("encrypted")}}, nil)
+
+	organizationStorageMock := storagemock.NewMockOrganizationServerStorage(ctl)
+
+	jwtM := jwtmock.NewMockManager(ctl)
+	jwtM.EXPECT().Decode(gomock.Any()).AnyTimes().Return(uid.String(), nil)
+
+	cryptM := cryptmock.NewMockCrypter(ctl)
+	cryptM.EXPECT().Decode(gomock.Any()).AnyTimes().Return(uid.String(), nil)
+
+	l, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	emergencyRpc := NewEmergencyGrpc(emergencyStorageMock, userStorageMock)
+
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(
+			grpc_middleware.ChainUnaryServer(
+				grpcauth.UnaryServerInterceptor(auth.NewJwtMiddleware(jwtM, cryptM).Auth),
+				auth.NewRoleMiddleware(organizationStorageMock).Unary(),
+			)),
+	)
+
+	pb.RegisterEmergencyServer(server, emergencyRpc)
+
+	go func() {
+		if err = server.Serve(l); err != nil && err != grpc.ErrServerStopped {
+			panic(err)
+		}
+	}()
+
+	conn, err := grpc.Dial(l.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	go func() {
+		for {
+			select {
+			case <-done:
+				server.GracefulStop()
+				conn.Close()
+			default:
+			}
+		}
+	}()
+
+	client := pb.NewEmergencyClient(conn)
+
+	return client, done
+}
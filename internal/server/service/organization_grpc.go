@@ -0,0 +1,355 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"secretKeeper/internal/server/middleware/auth"
+	"secretKeeper/internal/server/model"
+	"secretKeeper/internal/server/storage"
+	"secretKeeper/pkg/apperr"
+	pb "secretKeeper/proto"
+)
+
+type OrganizationGrpc struct {
+	pb.UnimplementedOrganizationServer
+
+	storage storage.OrganizationServerStorage
+}
+
+// NewOrganizationGrpc - creates new organization grpc service.
+func NewOrganizationGrpc(s storage.OrganizationServerStorage) *OrganizationGrpc {
+	return &OrganizationGrpc{storage: s}
+}
+
+// RegisterService - registers service via grpc server.
+func (o *OrganizationGrpc) RegisterService(r grpc.ServiceRegistrar) {
+	pb.RegisterOrganizationServer(r, o)
+}
+
+// CreateOrganization - creates new organization, where authorized user becomes an owner.
+func (o *OrganizationGrpc) CreateOrganization(
+	ctx context.Context, in *pb.CreateOrganizationRequest,
+) (*pb.CreateOrganizationResponse, error) {
+	user, err := userFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if in.Title == "" {
+		return nil, status.Error(codes.InvalidArgument, "title is required")
+	}
+
+	org, errCreate := o.storage.CreateOrganization(ctx, model.Organization{Title: in.Title}, user)
+	if errCreate != nil {
+		return nil, status.Error(codes.Internal, errCreate.Error())
+	}
+
+	return &pb.CreateOrganizationResponse{Organization: castOrganization(org)}, nil
+}
+
+// ListOrganizations - returns list of organizations where authorized user is a member.
+func (o *OrganizationGrpc) ListOrganizations(
+	ctx context.Context, in *pb.ListOrganizationsRequest,
+) (*pb.ListOrganizationsResponse, error) {
+	user, err := userFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	list, errList := o.storage.GetOrganizations(ctx, user)
+	if errList != nil {
+		return nil, status.Error(codes.Internal, errList.Error())
+	}
+
+	resp := &pb.ListOrganizationsResponse{}
+	for _, org := range list {
+		resp.Organizations = append(resp.Organizations, castOrganization(org))
+	}
+
+	return resp, nil
+}
+
+// AddMember - adds a user by login to organization.
+//
+// Caller can't grant a role higher than their own. Every vault of organization is marked for key rotation and returned,
+// so caller could rotate vault keys including the new member.
+func (o *OrganizationGrpc) AddMember(ctx context.Context, in *pb.AddMemberRequest) (*pb.AddMemberResponse, error) {
+	role, errRole := model.ParseRole(in.Role)
+	if errRole != nil {
+		return nil, status.Error(codes.InvalidArgument, errRole.Error())
+	}
+
+	if role > ctx.Value(auth.RoleCtx{}).(model.Role) {
+		return nil, status.Error(codes.PermissionDenied, "you can't grant role higher than yours")
+	}
+
+	_, vaults, err := o.storage.AddMember(ctx, model.Member{
+		OrganizationID: int(in.OrganizationId),
+		Login:          in.Login,
+		Role:           role,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "user not found or has never logged in to publish public key")
+		}
+
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.AddMemberResponse{RotateVaultIds: vaultIDs(vaults)}, nil
+}
+
+// RemoveMember - removes a user by login from organization together with their vault keys.
+//
+// Caller can't remove a member with a role higher than their own. Every vault of organization is marked for key
+// rotation and returned, so caller could rotate vault keys excluding removed member.
+func (o *OrganizationGrpc) RemoveMember(
+	ctx context.Context, in *pb.RemoveMemberRequest,
+) (*pb.RemoveMemberResponse, error) {
+	org := model.Organization{ID: int(in.OrganizationId)}
+
+	members, err := o.storage.GetMembers(ctx, org)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	for _, member := range members {
+		if member.Login == in.Login && member.Role > ctx.Value(auth.RoleCtx{}).(model.Role) {
+			return nil, status.Error(codes.PermissionDenied, "you can't remove member with role higher than yours")
+		}
+	}
+
+	_, vaults, errRemove := o.storage.RemoveMember(ctx, model.Member{OrganizationID: org.ID, Login: in.Login})
+	if errRemove != nil {
+		if errors.Is(errRemove, pgx.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, errRemove.Error())
+		}
+
+		return nil, status.Error(codes.Internal, errRemove.Error())
+	}
+
+	return &pb.RemoveMemberResponse{RotateVaultIds: vaultIDs(vaults)}, nil
+}
+
+// ListMembers - returns list of organization members with their public keys.
+func (o *OrganizationGrpc) ListMembers(
+	ctx context.Context, in *pb.ListMembersRequest,
+) (*pb.ListMembersResponse, error) {
+	members, err := o.storage.GetMembers(ctx, model.Organization{ID: int(in.OrganizationId)})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &pb.ListMembersResponse{}
+	for _, member := range members {
+		resp.Members = append(resp.Members, &pb.Member{
+			UserId:    member.UserID.String(),
+			Login:     member.Login,
+			Role:      member.Role.String(),
+			PublicKey: member.PublicKey,
+		})
+	}
+
+	return resp, nil
+}
+
+// CreateVault - creates new vault in organization.
+//
+// Vault key must be wrapped for every member of organization.
+func (o *OrganizationGrpc) CreateVault(
+	ctx context.Context, in *pb.CreateVaultRequest,
+) (*pb.CreateVaultResponse, error) {
+	if in.Title == "" {
+		return nil, status.Error(codes.InvalidArgument, "title is required")
+	}
+
+	keys, err := o.memberKeys(ctx, int(in.OrganizationId), in.Keys)
+	if err != nil {
+		return nil, err
+	}
+
+	vault, errCreate := o.storage.CreateVault(
+		ctx, model.Vault{OrganizationID: int(in.OrganizationId), Title: in.Title}, keys,
+	)
+	if errCreate != nil {
+		return nil, status.Error(codes.Internal, errCreate.Error())
+	}
+
+	return &pb.CreateVaultResponse{Vault: castVault(vault)}, nil
+}
+
+// ListVaults - returns list of organization vaults.
+func (o *OrganizationGrpc) ListVaults(ctx context.Context, in *pb.ListVaultsRequest) (*pb.ListVaultsResponse, error) {
+	vaults, err := o.storage.GetVaults(ctx, model.Organization{ID: int(in.OrganizationId)})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &pb.ListVaultsResponse{}
+	for _, vault := range vaults {
+		resp.Vaults = append(resp.Vaults, castVault(vault))
+	}
+
+	return resp, nil
+}
+
+// GetVaultKey - returns vault key wrapped for authorized user.
+func (o *OrganizationGrpc) GetVaultKey(
+	ctx context.Context, in *pb.GetVaultKeyRequest,
+) (*pb.GetVaultKeyResponse, error) {
+	user, err := userFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	vault, errVault := o.storage.GetVault(ctx, model.Vault{ID: int(in.VaultId)})
+	if errVault != nil {
+		if errors.Is(errVault, pgx.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, errVault.Error())
+		}
+
+		return nil, status.Error(codes.Internal, errVault.Error())
+	}
+
+	key, errKey := o.storage.GetVaultKey(ctx, model.VaultKey{VaultID: vault.ID, UserID: *user.ID})
+	if errKey != nil {
+		if errors.Is(errKey, pgx.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "vault key is not wrapped for you yet, vault has to be rotated")
+		}
+
+		return nil, status.Error(codes.Internal, errKey.Error())
+	}
+
+	return &pb.GetVaultKeyResponse{
+		VaultId:        uint32(vault.ID),
+		OrganizationId: uint32(vault.OrganizationID),
+		KeyVersion:     uint32(key.KeyVersion),
+		WrappedKey:     key.WrappedKey,
+	}, nil
+}
+
+// RotateVaultKey - replaces vault key of every member and re-encrypted content of every vault secret.
+func (o *OrganizationGrpc) RotateVaultKey(
+	ctx context.Context, in *pb.RotateVaultKeyRequest,
+) (*pb.RotateVaultKeyResponse, error) {
+	vault, errVault := o.storage.GetVault(ctx, model.Vault{ID: int(in.VaultId)})
+	if errVault != nil {
+		if errors.Is(errVault, pgx.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, errVault.Error())
+		}
+
+		return nil, status.Error(codes.Internal, errVault.Error())
+	}
+
+	keys, err := o.memberKeys(ctx, vault.OrganizationID, in.Keys)
+	if err != nil {
+		return nil, err
+	}
+
+	secrets := make([]model.Secret, 0, len(in.Secrets))
+	for _, secret := range in.Secrets {
+		secrets = append(secrets, model.Secret{ID: int(secret.Id), VaultID: vault.ID, Content: secret.Content})
+	}
+
+	vault.KeyVersion = int(in.KeyVersion)
+
+	rotated, errRotate := o.storage.RotateVaultKey(ctx, vault, keys, secrets)
+	if errRotate != nil {
+		if errors.Is(errRotate, apperr.ErrKeyVersionMismatch) || errors.Is(errRotate, apperr.ErrRotationIncomplete) {
+			return nil, status.Error(codes.FailedPrecondition, errRotate.Error())
+		}
+
+		return nil, status.Error(codes.Internal, errRotate.Error())
+	}
+
+	return &pb.RotateVaultKeyResponse{Vault: castVault(rotated)}, nil
+}
+
+// memberKeys - validates that provided keys are wrapped exactly for every member of organization and casts them
+// to []model.VaultKey.
+func (o *OrganizationGrpc) memberKeys(
+	ctx context.Context, organizationID int, in []*pb.MemberKey,
+) ([]model.VaultKey, error) {
+	members, err := o.storage.GetMembers(ctx, model.Organization{ID: organizationID})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	wrapped := make(map[uuid.UUID][]byte, len(in))
+	for _, key := range in {
+		uid, errParse := uuid.Parse(key.UserId)
+		if errParse != nil {
+			return nil, status.Error(codes.InvalidArgument, errParse.Error())
+		}
+
+		wrapped[uid] = key.WrappedKey
+	}
+
+	if len(wrapped) != len(members) {
+		return nil, status.Error(codes.FailedPrecondition, apperr.ErrRotationIncomplete.Error())
+	}
+
+	keys := make([]model.VaultKey, 0, len(members))
+	for _, member := range members {
+		key, ok := wrapped[member.UserID]
+		if !ok {
+			return nil, status.Error(codes.FailedPrecondition, apperr.ErrRotationIncomplete.Error())
+		}
+
+		keys = append(keys, model.VaultKey{UserID: member.UserID, WrappedKey: key})
+	}
+
+	return keys, nil
+}
+
+// userFromCtx - returns authorized model.User by token attached to context by JwtMiddleware.
+func userFromCtx(ctx context.Context) (model.User, error) {
+	token := ctx.Value(auth.JwtTokenCtx{}).(string)
+
+	uid, err := uuid.Parse(token)
+	if err != nil {
+		return model.User{}, status.Error(codes.Internal, err.Error())
+	}
+
+	return model.User{ID: &uid}, nil
+}
+
+// castOrganization - casts model.Organization to *pb.OrganizationItem.
+func castOrganization(org model.Organization) *pb.OrganizationItem {
+	return &pb.OrganizationItem{
+		Id:        uint32(org.ID),
+		Title:     org.Title,
+		Role:      org.Role.String(),
+		CreatedAt: timestamppb.New(org.CreatedAt),
+	}
+}
+
+// castVault - casts model.Vault to *pb.VaultItem.
+func castVault(vault model.Vault) *pb.VaultItem {
+	return &pb.VaultItem{
+		Id:               uint32(vault.ID),
+		OrganizationId:   uint32(vault.OrganizationID),
+		Title:            vault.Title,
+		KeyVersion:       uint32(vault.KeyVersion),
+		RotationRequired: vault.RotationRequired,
+		CreatedAt:        timestamppb.New(vault.CreatedAt),
+	}
+}
+
+// vaultIDs - returns ids of provided vaults.
+func vaultIDs(vaults []model.Vault) []uint32 {
+	ids := make([]uint32, 0, len(vaults))
+	for _, vault := range vaults {
+		ids = append(ids, uint32(vault.ID))
+	}
+
+	return ids
+}
@@ -0,0 +1,328 @@
+package service
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	grpcauth "github.com/grpc-ecosystem/go-grpc-middleware/auth"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"secretKeeper/internal/server/middleware/auth"
+	"secretKeeper/internal/server/model"
+	storagemock "secretKeeper/internal/server/storage/mock"
+	"secretKeeper/pkg/apperr"
+	cryptmock "secretKeeper/pkg/crypt/mock"
+	jwtmock "secretKeeper/pkg/jwt/mock"
+	pb "secretKeeper/proto"
+)
+
+const (
+	adminOrganizationID  = 1
+	writerOrganizationID = 2
+	foreignOrganization  = 3
+)
+
+func TestOrganizationGrpc_RegisterService(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	organizationMock := storagemock.NewMockOrganizationServerStorage(ctl)
+
+	tests := []struct {
+		name string
+	}{
+		{
+			name: "Registrar can be called without errors",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewOrganizationGrpc(organizationMock)
+
+			server := grpc.NewServer()
+
+			s.RegisterService(server)
+		})
+	}
+}
+
+func TestOrganizationGrpc_CreateOrganization(t *testing.T) {
+	uid := uuid.New()
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer token")
+
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	client, done := organizationTestClient(t, ctl, uid)
+	defer close(done)
+
+	res, err := client.CreateOrganization(ctx, &pb.CreateOrganizationRequest{Title: "team"})
+	assert.NoError(t, err)
+	assert.Equal(t, model.RoleOwner.String(), res.Organization.Role)
+
+	_, err = client.CreateOrganization(ctx, &pb.CreateOrganizationRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestOrganizationGrpc_AddMember(t *testing.T) {
+	uid := uuid.New()
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer token")
+
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	client, done := organizationTestClient(t, ctl, uid)
+	defer close(done)
+
+	res, err := client.AddMember(ctx, &pb.AddMemberRequest{
+		OrganizationId: adminOrganizationID, Login: "member", Role: "writer",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []uint32{1}, res.RotateVaultIds)
+
+	_, err = client.AddMember(ctx, &pb.AddMemberRequest{
+		OrganizationId: adminOrganizationID, Login: "member", Role: "owner",
+	})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = client.AddMember(ctx, &pb.AddMemberRequest{
+		OrganizationId: adminOrganizationID, Login: "member", Role: "unknown",
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.AddMember(ctx, &pb.AddMemberRequest{
+		OrganizationId: writerOrganizationID, Login: "member", Role: "reader",
+	})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = client.AddMember(ctx, &pb.AddMemberRequest{
+		OrganizationId: foreignOrganization, Login: "member", Role: "reader",
+	})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = client.AddMember(ctx, &pb.AddMemberRequest{Login: "member", Role: "reader"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestOrganizationGrpc_RemoveMember(t *testing.T) {
+	uid := uuid.New()
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer token")
+
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	client, done := organizationTestClient(t, ctl, uid)
+	defer close(done)
+
+	res, err := client.RemoveMember(ctx, &pb.RemoveMemberRequest{OrganizationId: adminOrganizationID, Login: "member"})
+	assert.NoError(t, err)
+	assert.Equal(t, []uint32{1}, res.RotateVaultIds)
+
+	_, err = client.RemoveMember(ctx, &pb.RemoveMemberRequest{OrganizationId: adminOrganizationID, Login: "owner"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestOrganizationGrpc_CreateVault(t *testing.T) {
+	uid := uuid.New()
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer token")
+
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	client, done := organizationTestClient(t, ctl, uid)
+	defer close(done)
+
+	keys := []*pb.MemberKey{
+		{UserId: uid.String(), WrappedKey: []byte{1}},
+		{UserId: memberID.String(), WrappedKey: []byte{2}},
+		{UserId: ownerID.String(), WrappedKey: []byte{3}},
+	}
+
+	_, err := client.CreateVault(ctx, &pb.CreateVaultRequest{
+		OrganizationId: adminOrganizationID, Title: "prod", Keys: keys,
+	})
+	assert.NoError(t, err)
+
+	_, err = client.CreateVault(ctx, &pb.CreateVaultRequest{
+		OrganizationId: adminOrganizationID, Title: "prod", Keys: keys[:1],
+	})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestOrganizationGrpc_GetVaultKey(t *testing.T) {
+	uid := uuid.New()
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer token")
+
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	client, done := organizationTestClient(t, ctl, uid)
+	defer close(done)
+
+	res, err := client.GetVaultKey(ctx, &pb.GetVaultKeyRequest{VaultId: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(adminOrganizationID), res.OrganizationId)
+	assert.Equal(t, []byte{1}, res.WrappedKey)
+}
+
+func TestOrganizationGrpc_RotateVaultKey(t *testing.T) {
+	uid := uuid.New()
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer token")
+
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	client, done := organizationTestClient(t, ctl, uid)
+	defer close(done)
+
+	keys := []*pb.MemberKey{
+		{UserId: uid.String(), WrappedKey: []byte{1}},
+		{UserId: memberID.String(), WrappedKey: []byte{2}},
+		{UserId: ownerID.String(), WrappedKey: []byte{3}},
+	}
+
+	res, err := client.RotateVaultKey(ctx, &pb.RotateVaultKeyRequest{VaultId: 1, KeyVersion: 2, Keys: keys})
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(2), res.Vault.KeyVersion)
+
+	_, err = client.RotateVaultKey(ctx, &pb.RotateVaultKeyRequest{VaultId: 1, KeyVersion: 5, Keys: keys})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+var (
+	memberID = uuid.New()
+	ownerID  = uuid.New()
+)
+
+func organizationTestClient(
+	t *testing.T, ctl *gomock.Controller, uid uuid.UUID,
+) (pb.OrganizationClient, chan<- struct{}) {
+	done := make(chan struct{})
+
+	organizationStorageMock := storagemock.NewMockOrganizationServerStorage(ctl)
+
+	organizationStorageMock.EXPECT().
+		CreateOrganization(gomock.Any(), gomock.Any(), gomock.Any()).
+		AnyTimes().
+		Return(model.Organization{ID: 1, Title: "team", Role: model.RoleOwner}, nil)
+
+	organizationStorageMock.EXPECT().
+		GetMemberRole(gomock.Any(), gomock.Eq(model.Organization{ID: adminOrganizationID}), gomock.Any()).
+		AnyTimes().
+		Return(model.RoleAdmin, nil)
+	organizationStorageMock.EXPECT().
+		GetMemberRole(gomock.Any(), gomock.Eq(model.Organization{ID: writerOrganizationID}), gomock.Any()).
+		AnyTimes().
+		Return(model.RoleWriter, nil)
+	organizationStorageMock.EXPECT().
+		GetMemberRole(gomock.Any(), gomock.Eq(model.Organization{ID: foreignOrganization}), gomock.Any()).
+		AnyTimes().
+		Return(model.RoleNone, pgx.ErrNoRows)
+	organizationStorageMock.EXPECT().
+		GetVaultRole(gomock.Any(), gomock.Eq(model.Vault{ID: 1}), gomock.Any()).
+		AnyTimes().
+		Return(model.RoleAdmin, nil)
+
+	organizationStorageMock.EXPECT().
+		GetMembers(gomock.Any(), gomock.Eq(model.Organization{ID: adminOrganizationID})).
+		AnyTimes().
+		Return([]model.Member{
+			{OrganizationID: adminOrganizationID, UserID: uid, Login: "admin", Role: model.RoleAdmin},
+			{OrganizationID: adminOrganizationID, UserID: memberID, Login: "member", Role: model.RoleWriter},
+			{OrganizationID: adminOrganizationID, UserID: ownerID, Login: "owner", Role: model.RoleOwner},
+		}, nil)
+
+	organizationStorageMock.EXPECT().
+		AddMember(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		Return(model.Member{UserID: memberID}, []model.Vault{{ID: 1}}, nil)
+	organizationStorageMock.EXPECT().
+		RemoveMember(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		Return(model.Member{UserID: memberID}, []model.Vault{{ID: 1}}, nil)
+
+	organizationStorageMock.EXPECT().
+		CreateVault(gomock.Any(), gomock.Any(), gomock.Any()).
+		AnyTimes().
+		Return(model.Vault{ID: 1, OrganizationID: adminOrganizationID, KeyVersion: 1}, nil)
+	organizationStorageMock.EXPECT().
+		GetVault(gomock.Any(), gomock.Eq(model.Vault{ID: 1})).
+		AnyTimes().
+		Return(model.Vault{ID: 1, OrganizationID: adminOrganizationID, KeyVersion: 1}, nil)
+	organizationStorageMock.EXPECT().
+		GetVaultKey(gomock.Any(), gomock.Eq(model.VaultKey{VaultID: 1, UserID: uid})).
+		AnyTimes().
+		Return(model.VaultKey{VaultID: 1, UserID: uid, KeyVersion: 1, WrappedKey: []byte{1}}, nil)
+
+	organizationStorageMock.EXPECT().
+		RotateVaultKey(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		AnyTimes().
+		DoAndReturn(func(
+			ctx context.Context, vault model.Vault, keys []model.VaultKey, secrets []model.Secret,
+		) (model.Vault, error) {
+			if vault.KeyVersion != 2 {
+				return vault, apperr.ErrKeyVersionMismatch
+			}
+
+			return vault, nil
+		})
+
+	jwtM := jwtmock.NewMockManager(ctl)
+	jwtM.EXPECT().Decode(gomock.Any()).AnyTimes().Return(uid.String(), nil)
+
+	cryptM := cryptmock.NewMockCrypter(ctl)
+	cryptM.EXPECT().Decode(gomock.Any()).AnyTimes().Return(uid.String(), nil)
+
+	l, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	organizationRpc := NewOrganizationGrpc(organizationStorageMock)
+
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(
+			grpc_middleware.ChainUnaryServer(
+				grpcauth.UnaryServerInterceptor(auth.NewJwtMiddleware(jwtM, cryptM).Auth),
+				auth.NewRoleMiddleware(organizationStorageMock).Unary(),
+			)),
+	)
+
+	pb.RegisterOrganizationServer(server, organizationRpc)
+
+	go func() {
+		if err = server.Serve(l); err != nil && err != grpc.ErrServerStopped {
+			panic(err)
+		}
+	}()
+
+	conn, err := grpc.Dial(l.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	go func() {
+		for {
+			select {
+			case <-done:
+				server.GracefulStop()
+				conn.Close()
+			default:
+			}
+		}
+	}()
+
+	client := pb.NewOrganizationClient(conn)
+
+	return client, done
+}
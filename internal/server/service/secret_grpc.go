@@ -41,14 +41,28 @@ func (s *SecretGrpc) RegisterService(r grpc.ServiceRegistrar) {
 func (s *SecretGrpc) CreateSecret(ctx context.Context, in *pb.CreateSecretRequest) (*pb.CreateSecretResponse, error) {
 	tok := ctx.Value(auth.JwtTokenCtx{}).(string)
 
+	createdAt, updatedAt, errTimes := secretTimes(in, time.Now())
+	if errTimes != nil {
+		return nil, status.Error(codes.InvalidArgument, errTimes.Error())
+	}
+
 	secret := model.Secret{
 		UserID:    uuid.MustParse(tok),
+		VaultID:   int(in.VaultId),
 		TypeID:    int(in.Type),
 		Title:     in.Title,
 		Content:   in.Content,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		CreatedAt: createdAt,
+		UpdatedAt: updatedAt,
 		IsDelited: false,
+
+		ExpiresAt:       optionalTime(in.ExpiresAt),
+		RotateEveryDays: int(in.RotateEveryDays),
+		AutoExpire:      in.AutoExpire,
+	}
+
+	if secret.AutoExpire && secret.ExpiresAt == nil {
+		return nil, status.Error(codes.InvalidArgument, "auto expire requires expiry time")
 	}
 
 	m, err := s.storage.CreateSecret(ctx, secret)
@@ -65,13 +79,80 @@ func (s *SecretGrpc) CreateSecret(ctx context.Context, in *pb.CreateSecretReques
 	}, nil
 }
 
+// maxBatchSecrets - is the most secrets CreateSecrets accepts, larger imports are split by client.
+const maxBatchSecrets = 1000
+
+// CreateSecrets - stores provided secrets via initialised storage at once, so import doesn't need a call per secret.
+//
+// Secrets are stored to vault of the request, none is stored if any is invalid.
+func (s *SecretGrpc) CreateSecrets(ctx context.Context, in *pb.CreateSecretsRequest) (*pb.CreateSecretsResponse, error) {
+	tok := ctx.Value(auth.JwtTokenCtx{}).(string)
+
+	if len(in.Secrets) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "no secrets to create")
+	}
+
+	if len(in.Secrets) > maxBatchSecrets {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d secrets can be created at once", maxBatchSecrets)
+	}
+
+	now := time.Now()
+	secrets := make([]model.Secret, 0, len(in.Secrets))
+
+	for i, item := range in.Secrets {
+		createdAt, updatedAt, errTimes := secretTimes(item, now)
+		if errTimes != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "secret %d: %v", i+1, errTimes)
+		}
+
+		secret := model.Secret{
+			UserID:    uuid.MustParse(tok),
+			VaultID:   int(in.VaultId),
+			TypeID:    int(item.Type),
+			Title:     item.Title,
+			Content:   item.Content,
+			CreatedAt: createdAt,
+			UpdatedAt: updatedAt,
+
+			ExpiresAt:       optionalTime(item.ExpiresAt),
+			RotateEveryDays: int(item.RotateEveryDays),
+			AutoExpire:      item.AutoExpire,
+		}
+
+		if secret.AutoExpire && secret.ExpiresAt == nil {
+			return nil, status.Errorf(codes.InvalidArgument, "secret %d: auto expire requires expiry time", i+1)
+		}
+
+		secrets = append(secrets, secret)
+	}
+
+	created, err := s.storage.CreateSecrets(ctx, secrets)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	res := &pb.CreateSecretsResponse{Secrets: make([]*pb.CreateSecretResponse, 0, len(created))}
+	for _, m := range created {
+		res.Secrets = append(res.Secrets, &pb.CreateSecretResponse{
+			Id:        uint32(m.ID),
+			Title:     m.Title,
+			Type:      uint32(m.TypeID),
+			CreatedAt: timestamppb.New(m.CreatedAt),
+			UpdatedAt: timestamppb.New(m.UpdatedAt),
+		})
+	}
+
+	return res, nil
+}
+
 // GetSecret - returns stored secret from storage.
 func (s *SecretGrpc) GetSecret(ctx context.Context, in *pb.GetSecretRequest) (*pb.GetSecretResponse, error) {
 	tok := ctx.Value(auth.JwtTokenCtx{}).(string)
 
 	secret := model.Secret{
-		UserID: uuid.MustParse(tok),
-		ID:     int(in.Id),
+		UserID:  uuid.MustParse(tok),
+		VaultID: int(in.VaultId),
+		ID:      int(in.Id),
 	}
 
 	m, err := s.storage.GetSecret(ctx, secret)
@@ -93,6 +174,11 @@ func (s *SecretGrpc) GetSecret(ctx context.Context, in *pb.GetSecretRequest) (*p
 		UpdatedAt: timestamppb.New(m.UpdatedAt),
 
 		IsDelited: m.IsDelited,
+		VaultId:   uint32(m.VaultID),
+
+		ExpiresAt:       optionalTimestamp(m.ExpiresAt),
+		RotateEveryDays: uint32(m.RotateEveryDays),
+		AutoExpire:      m.AutoExpire,
 	}, nil
 
 }
@@ -102,8 +188,9 @@ func (s *SecretGrpc) DeleteSecret(ctx context.Context, in *pb.DeleteSecretReques
 	tok := ctx.Value(auth.JwtTokenCtx{}).(string)
 
 	secret := model.Secret{
-		UserID: uuid.MustParse(tok),
-		ID:     int(in.Id),
+		UserID:  uuid.MustParse(tok),
+		VaultID: int(in.VaultId),
+		ID:      int(in.Id),
 	}
 
 	_, err := s.storage.DeleteSecret(ctx, secret)
@@ -123,6 +210,7 @@ func (s *SecretGrpc) EditSecret(ctx context.Context, in *pb.EditSecretRequest) (
 	secret := model.Secret{
 		ID:        int(in.Id),
 		UserID:    uuid.MustParse(token),
+		VaultID:   int(in.VaultId),
 		Title:     in.Title,
 		TypeID:    int(in.Type),
 		Content:   in.Content,
@@ -163,8 +251,9 @@ func (s *SecretGrpc) GetListOfSecretsByType(
 
 	user := model.User{ID: &userId}
 	secretType := model.SecretType{ID: uint(in.TypeId)}
+	vault := model.Vault{ID: int(in.VaultId)}
 
-	secrets, err := s.storage.GetListOfSecretByType(ctx, secretType, user)
+	secrets, err := s.storage.GetListOfSecretByType(ctx, secretType, user, vault)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
@@ -176,14 +265,122 @@ func (s *SecretGrpc) GetListOfSecretsByType(
 		castedSecrets = append(castedSecrets, &pb.SecretList{
 			Id:        uint32(val.ID),
 			UserId:    val.UserID.String(),
+			VaultId:   uint32(val.VaultID),
 			TypeId:    uint32(val.TypeID),
 			Title:     val.Title,
 			Content:   val.Content,
 			CreatedAt: timestamppb.New(val.CreatedAt),
 			UpdatedAt: timestamppb.New(val.UpdatedAt),
 			IsDelited: val.IsDelited,
+
+			ExpiresAt:       optionalTimestamp(val.ExpiresAt),
+			RotateEveryDays: uint32(val.RotateEveryDays),
+			AutoExpire:      val.AutoExpire,
 		})
 	}
 
 	return &pb.GetListOfSecretsByTypeResponse{SecretLists: castedSecrets}, nil
 }
+
+// SetSecretExpiry - sets expiry time, rotation period and auto expire flag of a secret, zero values disable them.
+func (s *SecretGrpc) SetSecretExpiry(
+	ctx context.Context, in *pb.SetSecretExpiryRequest,
+) (*pb.SetSecretExpiryResponse, error) {
+	user, err := userFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	secret := model.Secret{
+		ID:              int(in.Id),
+		UserID:          *user.ID,
+		VaultID:         int(in.VaultId),
+		ExpiresAt:       optionalTime(in.ExpiresAt),
+		RotateEveryDays: int(in.RotateEveryDays),
+		AutoExpire:      in.AutoExpire,
+	}
+
+	if secret.AutoExpire && secret.ExpiresAt == nil {
+		return nil, status.Error(codes.InvalidArgument, "auto expire requires expiry time")
+	}
+
+	if _, errSet := s.storage.SetExpiry(ctx, secret); errSet != nil {
+		if errors.Is(errSet, pgx.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "secret not found")
+		}
+
+		return nil, status.Error(codes.Internal, errSet.Error())
+	}
+
+	return &pb.SetSecretExpiryResponse{}, nil
+}
+
+// GetDueEvents - returns reminders about personal secrets of authorized user and secrets of vaults available to
+// the user, which expire soon, have expired or need rotation.
+func (s *SecretGrpc) GetDueEvents(ctx context.Context, in *pb.GetDueEventsRequest) (*pb.GetDueEventsResponse, error) {
+	user, err := userFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	events, errEvents := s.storage.GetDueEvents(ctx, user)
+	if errEvents != nil {
+		return nil, status.Error(codes.Internal, errEvents.Error())
+	}
+
+	resp := &pb.GetDueEventsResponse{}
+	for _, event := range events {
+		resp.Events = append(resp.Events, &pb.DueEvent{
+			SecretId: uint32(event.SecretID),
+			VaultId:  uint32(event.VaultID),
+			Title:    event.Title,
+			Kind:     string(event.Kind),
+			DueAt:    timestamppb.New(event.DueAt),
+		})
+	}
+
+	return resp, nil
+}
+
+// secretTimes - returns creation and update time of secret being created, restored secret keeps its own ones.
+func secretTimes(in *pb.CreateSecretRequest, now time.Time) (time.Time, time.Time, error) {
+	createdAt, updatedAt := now, now
+
+	if in.CreatedAt != nil {
+		createdAt, updatedAt = in.CreatedAt.AsTime(), in.CreatedAt.AsTime()
+	}
+
+	if in.UpdatedAt != nil {
+		updatedAt = in.UpdatedAt.AsTime()
+	}
+
+	if createdAt.After(now) || updatedAt.After(now) {
+		return time.Time{}, time.Time{}, errors.New("secret can't be created in the future")
+	}
+
+	if updatedAt.Before(createdAt) {
+		return time.Time{}, time.Time{}, errors.New("secret can't be updated before it is created")
+	}
+
+	return createdAt, updatedAt, nil
+}
+
+// optionalTime - converts optional *timestamppb.Timestamp to *time.Time.
+func optionalTime(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+
+	t := ts.AsTime()
+
+	return &t
+}
+
+// optionalTimestamp - converts optional *time.Time to *timestamppb.Timestamp.
+func optionalTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+
+	return timestamppb.New(*t)
+}
@@ -11,10 +11,13 @@ import (
 	"github.com/google/uuid"
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	grpcauth "github.com/grpc-ecosystem/go-grpc-middleware/auth"
+	"github.com/jackc/pgx/v4"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"secretKeeper/internal/server/middleware/auth"
@@ -64,6 +67,48 @@ func TestSecretGrpc_CreateSecret(t *testing.T) {
 
 	_, err := client.CreateSecret(ctx, &pb.CreateSecretRequest{})
 	assert.NoError(t, err)
+
+	_, err = client.CreateSecret(ctx, &pb.CreateSecretRequest{
+		CreatedAt: timestamppb.New(now.Add(-time.Hour)),
+		UpdatedAt: timestamppb.New(now.Add(-time.Minute)),
+	})
+	assert.NoError(t, err)
+
+	_, err = client.CreateSecret(ctx, &pb.CreateSecretRequest{CreatedAt: timestamppb.New(time.Now().Add(time.Hour))})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.CreateSecret(ctx, &pb.CreateSecretRequest{
+		CreatedAt: timestamppb.New(now.Add(-time.Minute)),
+		UpdatedAt: timestamppb.New(now.Add(-time.Hour)),
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestSecretGrpc_CreateSecrets(t *testing.T) {
+	uid := uuid.New()
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer token")
+
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	client, done := secretTestClient(t, ctl, uid)
+	defer close(done)
+
+	res, err := client.CreateSecrets(ctx, &pb.CreateSecretsRequest{Secrets: []*pb.CreateSecretRequest{
+		{Title: "first", Type: 1},
+		{Title: "second", Type: 2},
+	}})
+	assert.NoError(t, err)
+	assert.Len(t, res.Secrets, 2)
+	assert.Equal(t, "second", res.Secrets[1].Title)
+
+	_, err = client.CreateSecrets(ctx, &pb.CreateSecretsRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.CreateSecrets(ctx, &pb.CreateSecretsRequest{Secrets: []*pb.CreateSecretRequest{
+		{Title: "expiring", Type: 1, AutoExpire: true},
+	}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
 }
 
 func TestSecretGrpc_GetSecret(t *testing.T) {
@@ -132,12 +177,56 @@ func TestSecretGrpc_GetListOfSecretsByType(t *testing.T) {
 	assert.Len(t, res.SecretLists, 2)
 }
 
+func TestSecretGrpc_SetSecretExpiry(t *testing.T) {
+	uid := uuid.New()
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer token")
+
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	client, done := secretTestClient(t, ctl, uid)
+	defer close(done)
+
+	_, err := client.SetSecretExpiry(ctx, &pb.SetSecretExpiryRequest{Id: 1, RotateEveryDays: 90})
+	assert.NoError(t, err)
+
+	_, err = client.SetSecretExpiry(ctx, &pb.SetSecretExpiryRequest{Id: 1, AutoExpire: true})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.SetSecretExpiry(ctx, &pb.SetSecretExpiryRequest{Id: 2, RotateEveryDays: 90})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestSecretGrpc_GetDueEvents(t *testing.T) {
+	uid := uuid.New()
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer token")
+
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	client, done := secretTestClient(t, ctl, uid)
+	defer close(done)
+
+	res, err := client.GetDueEvents(ctx, &pb.GetDueEventsRequest{})
+	assert.NoError(t, err)
+	assert.Len(t, res.Events, 2)
+	assert.Equal(t, string(model.DueRotation), res.Events[1].Kind)
+}
+
 func secretTestClient(t *testing.T, ctl *gomock.Controller, uid uuid.UUID) (pb.SecretClient, chan<- struct{}) {
 	done := make(chan struct{})
 
 	secretStorageMock := storagemock.NewMockSecretServerStorage(ctl)
 
 	secretStorageMock.EXPECT().CreateSecret(gomock.Any(), gomock.Any()).AnyTimes().Return(model.Secret{}, nil)
+	secretStorageMock.EXPECT().CreateSecrets(gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(_ context.Context, secrets []model.Secret) ([]model.Secret, error) {
+			for i := range secrets {
+				secrets[i].ID = i + 1
+			}
+
+			return secrets, nil
+		})
 
 	secretStorageMock.EXPECT().
 		GetSecret(gomock.Any(), gomock.Eq(model.Secret{ID: 1, UserID: uid})).
@@ -166,18 +255,33 @@ func secretTestClient(t *testing.T, ctl *gomock.Controller, uid uuid.UUID) (pb.S
 		AnyTimes().
 		Return(model.Secret{}, errors.New("test"))
 
-	secretStorageMock.EXPECT().GetListOfSecretByType(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().
+	secretStorageMock.EXPECT().GetListOfSecretByType(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().
 		Return([]model.Secret{
 			{ID: 1},
 			{ID: 2},
 		}, nil)
 
+	secretStorageMock.EXPECT().
+		SetExpiry(gomock.Any(), gomock.Eq(model.Secret{ID: 1, UserID: uid, RotateEveryDays: 90})).
+		AnyTimes().
+		Return(model.Secret{}, nil)
+	secretStorageMock.EXPECT().
+		SetExpiry(gomock.Any(), gomock.Eq(model.Secret{ID: 2, UserID: uid, RotateEveryDays: 90})).
+		AnyTimes().
+		Return(model.Secret{}, pgx.ErrNoRows)
+
+	secretStorageMock.EXPECT().GetDueEvents(gomock.Any(), gomock.Eq(model.User{ID: &uid})).AnyTimes().
+		Return([]model.DueEvent{
+			{SecretID: 1, Title: "card", Kind: model.DueExpiring, DueAt: now},
+			{SecretID: 2, Title: "password", Kind: model.DueRotation, DueAt: now},
+		}, nil)
+
 	jwtM := jwtmock.NewMockManager(ctl)
 	jwtM.EXPECT().Issue(uid.String()).AnyTimes().Return("token", nil)
 	jwtM.EXPECT().Decode(gomock.Any()).AnyTimes().Return(uid.String(), nil)
 
 	cryptM := cryptmock.NewMockCrypter(ctl)
-	cryptM.EXPECT().Encode(gomock.Any()).AnyTimes().Return("token")
+	cryptM.EXPECT().Encode(gomock.Any()).AnyTimes().Return("token", nil)
 	cryptM.EXPECT().Decode(gomock.Any()).AnyTimes().Return(uid.String(), nil)
 
 	l, err := net.Listen("tcp", "localhost:0")
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"secretKeeper/internal/server/model"
+	"secretKeeper/internal/server/storage"
+	pb "secretKeeper/proto"
+)
+
+const (
+	// defaultSendTTL - is used when sender doesn't provide time to live of a send.
+	defaultSendTTL = 24 * time.Hour
+	// maxSendTTLMinutes - is the longest time a send can live.
+	maxSendTTLMinutes = 60 * 24 * 30
+	// maxSendViews - is the largest number of views a send can have.
+	maxSendViews = 100
+	// maxSendSize - is the largest encrypted content of a send in bytes.
+	maxSendSize = 1 << 20
+)
+
+type SendGrpc struct {
+	pb.UnimplementedSendServer
+
+	storage storage.SendServerStorage
+}
+
+// NewSendGrpc - creates new one-time send grpc service.
+func NewSendGrpc(s storage.SendServerStorage) *SendGrpc {
+	return &SendGrpc{storage: s}
+}
+
+// RegisterService - registers service via grpc server.
+func (s *SendGrpc) RegisterService(r grpc.ServiceRegistrar) {
+	pb.RegisterSendServer(r, s)
+}
+
+// SendSecret - stores content encrypted by authorized user and returns id of the send.
+//
+// The key of content never reaches the server, sender passes it to recipient together with the id.
+func (s *SendGrpc) SendSecret(ctx context.Context, in *pb.SendSecretRequest) (*pb.SendSecretResponse, error) {
+	user, err := userFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(in.Content) == 0 || len(in.Content) > maxSendSize {
+		return nil, status.Errorf(codes.InvalidArgument, "content must be from 1 to %d bytes", maxSendSize)
+	}
+
+	if in.TtlMinutes > maxSendTTLMinutes {
+		return nil, status.Errorf(codes.InvalidArgument, "time to live must be at most %d minutes", maxSendTTLMinutes)
+	}
+
+	if in.MaxViews > maxSendViews {
+		return nil, status.Errorf(codes.InvalidArgument, "views must be at most %d", maxSendViews)
+	}
+
+	ttl := defaultSendTTL
+	if in.TtlMinutes > 0 {
+		ttl = time.Duration(in.TtlMinutes) * time.Minute
+	}
+
+	views := int(in.MaxViews)
+	if views == 0 {
+		views = 1
+	}
+
+	send, errCreate := s.storage.CreateSend(ctx, model.Send{
+		UserID:    *user.ID,
+		Content:   in.Content,
+		ViewsLeft: views,
+		ExpiresAt: time.Now().Add(ttl),
+	})
+	if errCreate != nil {
+		return nil, status.Error(codes.Internal, errCreate.Error())
+	}
+
+	return &pb.SendSecretResponse{Id: send.ID.String(), ExpiresAt: timestamppb.New(send.ExpiresAt)}, nil
+}
+
+// OpenSend - returns encrypted content of a send and consumes one of its views, it doesn't require authorization.
+//
+// Send which doesn't exist, has expired or has been used up is reported the same way with codes.NotFound.
+func (s *SendGrpc) OpenSend(ctx context.Context, in *pb.OpenSendRequest) (*pb.OpenSendResponse, error) {
+	id, err := uuid.Parse(in.Id)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "send not found or has expired")
+	}
+
+	send, errOpen := s.storage.OpenSend(ctx, model.Send{ID: id}, time.Now())
+	if errOpen != nil {
+		if errors.Is(errOpen, pgx.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "send not found or has expired")
+		}
+
+		return nil, status.Error(codes.Internal, errOpen.Error())
+	}
+
+	return &pb.OpenSendResponse{Content: send.Content, ViewsLeft: uint32(send.ViewsLeft)}, nil
+}
@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	grpcauth "github.com/grpc-ecosystem/go-grpc-middleware/auth"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"secretKeeper/internal/server/middleware/auth"
+	"secretKeeper/internal/server/model"
+	storagemock "secretKeeper/internal/server/storage/mock"
+	cryptmock "secretKeeper/pkg/crypt/mock"
+	jwtmock "secretKeeper/pkg/jwt/mock"
+	pb "secretKeeper/proto"
+)
+
+func TestSendGrpc_RegisterService(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	sendMock := storagemock.NewMockSendServerStorage(ctl)
+
+	tests := []struct {
+		name string
+	}{
+		{
+			name: "Registrar can be called without errors",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSendGrpc(sendMock)
+
+			server := grpc.NewServer()
+
+			s.RegisterService(server)
+		})
+	}
+}
+
+func TestSendGrpc_SendSecret(t *testing.T) {
+	uid := uuid.New()
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer token")
+
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	client, done := sendTestClient(t, ctl, uid, uuid.New())
+	defer close(done)
+
+	res, err := client.SendSecret(ctx, &pb.SendSecretRequest{Content: []byte("encrypted")})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, res.Id)
+	assert.NotNil(t, res.ExpiresAt)
+
+	_, err = client.SendSecret(ctx, &pb.SendSecretRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.SendSecret(ctx, &pb.SendSecretRequest{Content: []byte("encrypted"), MaxViews: maxSendViews + 1})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.SendSecret(context.Background(), &pb.SendSecretRequest{Content: []byte("encrypted")})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestSendGrpc_OpenSend(t *testing.T) {
+	uid := uuid.New()
+	sendID := uuid.New()
+
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	client, done := sendTestClient(t, ctl, uid, sendID)
+	defer close(done)
+
+	res, err := client.OpenSend(context.Background(), &pb.OpenSendRequest{Id: sendID.String()})
+	assert.NoError(t, err, "send can be opened without authorization")
+	assert.Equal(t, []byte("encrypted"), res.Content)
+
+	_, err = client.OpenSend(context.Background(), &pb.OpenSendRequest{Id: uuid.NewString()})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = client.OpenSend(context.Background(), &pb.OpenSendRequest{Id: "malformed"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func sendTestClient(
+	t *testing.T, ctl *gomock.Controller, uid uuid.UUID, sendID uuid.UUID,
+) (pb.SendClient, chan<- struct{}) {
+	done := make(chan struct{})
+
+	sendStorageMock := storagemock.NewMockSendServerStorage(ctl)
+	sendStorageMock.EXPECT().
+		CreateSend(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		DoAndReturn(func(ctx context.Context, send model.Send) (model.Send, error) {
+			send.ID = uuid.New()
+
+			return send, nil
+		})
+	sendStorageMock.EXPECT().
+		OpenSend(gomock.Any(), gomock.Any(), gomock.Any()).
+		AnyTimes().
+		DoAndReturn(func(ctx context.Context, send model.Send, now time.Time) (model.Send, error) {
+			if send.ID != sendID {
+				return send, pgx.ErrNoRows
+			}
+
+			send.Content = []byte("encrypted")
+
+			return send, nil
+		})
+
+	organizationStorageMock := storagemock.NewMockOrganizationServerStorage(ctl)
+
+	jwtM := jwtmock.NewMockManager(ctl)
+	jwtM.EXPECT().Decode(gomock.Any()).AnyTimes().Return(uid.String(), nil)
+
+	cryptM := cryptmock.NewMockCrypter(ctl)
+	cryptM.EXPECT().Decode(gomock.Any()).AnyTimes().Return(uid.String(), nil)
+
+	l, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sendRpc := NewSendGrpc(sendStorageMock)
+
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(
+			grpc_middleware.ChainUnaryServer(
+				grpcauth.UnaryServerInterceptor(auth.NewJwtMiddleware(jwtM, cryptM).Auth),
+				auth.NewRoleMiddleware(organizationStorageMock).Unary(),
+			)),
+	)
+
+	pb.RegisterSendServer(server, sendRpc)
+
+	go func() {
+		if err = server.Serve(l); err != nil && err != grpc.ErrServerStopped {
+			panic(err)
+		}
+	}()
+
+	conn, err := grpc.Dial(l.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	go func() {
+		for {
+			select {
+			case <-done:
+				server.GracefulStop()
+				conn.Close()
+			default:
+			}
+		}
+	}()
+
+	client := pb.NewSendClient(conn)
+
+	return client, done
+}
@@ -2,6 +2,8 @@ package service
 
 import (
 	"context"
+	"crypto/sha256"
+	"crypto/subtle"
 	"errors"
 
 	"github.com/go-playground/validator/v10"
@@ -68,7 +70,12 @@ func (u *userGrpc) Register(ctx context.Context, in *pb.RegisterRequest) (*pb.Re
 		return nil, status.Error(codes.Internal, errToken.Error())
 	}
 
-	return &pb.RegisterResponse{Token: u.crypter.Encode(token)}, nil
+	encoded, errEncode := u.crypter.Encode(token)
+	if errEncode != nil {
+		return nil, status.Error(codes.Internal, errEncode.Error())
+	}
+
+	return &pb.RegisterResponse{Token: encoded}, nil
 }
 
 // Login - Will return JwtToken on successful authentication via provided login and password.
@@ -88,7 +95,12 @@ func (u *userGrpc) Login(ctx context.Context, in *pb.LoginRequest) (*pb.LoginRes
 		return nil, status.Error(codes.Internal, errToken.Error())
 	}
 
-	return &pb.LoginResponse{Token: u.crypter.Encode(token)}, nil
+	encoded, errEncode := u.crypter.Encode(token)
+	if errEncode != nil {
+		return nil, status.Error(codes.Internal, errEncode.Error())
+	}
+
+	return &pb.LoginResponse{Token: encoded}, nil
 }
 
 // Delete - will delete a user from storage by provided ID.
@@ -110,3 +122,176 @@ func (u *userGrpc) Delete(ctx context.Context, in *pb.DeleteRequest) (*pb.Delete
 
 	return &pb.DeleteResponse{}, nil
 }
+
+// SetPublicKey - stores public key of authorized user, so vault keys could be wrapped for them.
+func (u *userGrpc) SetPublicKey(ctx context.Context, in *pb.SetPublicKeyRequest) (*pb.SetPublicKeyResponse, error) {
+	token := ctx.Value(auth.JwtTokenCtx{}).(string)
+
+	uid, err := uuid.Parse(token)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	if len(in.PublicKey) != crypt.KeySize {
+		return nil, status.Error(codes.InvalidArgument, "public key has invalid size")
+	}
+
+	_, errSet := u.storage.SetPublicKey(ctx, model.User{ID: &uid, PublicKey: in.PublicKey})
+	if errSet != nil {
+		if errors.Is(errSet, pgx.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, errSet.Error())
+		}
+		return nil, status.Error(codes.Internal, errSet.Error())
+	}
+
+	return &pb.SetPublicKeyResponse{}, nil
+}
+
+// SetPersonalKey - stores personal key of authorized user wrapped for their own public key.
+func (u *userGrpc) SetPersonalKey(
+	ctx context.Context, in *pb.SetPersonalKeyRequest,
+) (*pb.SetPersonalKeyResponse, error) {
+	token := ctx.Value(auth.JwtTokenCtx{}).(string)
+
+	uid, err := uuid.Parse(token)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	if len(in.WrappedKey) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "wrapped key is required")
+	}
+
+	_, errSet := u.storage.SetPersonalKey(ctx, model.User{ID: &uid, PersonalKey: in.WrappedKey})
+	if errSet != nil {
+		if errors.Is(errSet, pgx.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, errSet.Error())
+		}
+		return nil, status.Error(codes.Internal, errSet.Error())
+	}
+
+	return &pb.SetPersonalKeyResponse{}, nil
+}
+
+// GetPersonalKey - returns wrapped personal key of authorized user, which is empty if it was never set.
+func (u *userGrpc) GetPersonalKey(
+	ctx context.Context, in *pb.GetPersonalKeyRequest,
+) (*pb.GetPersonalKeyResponse, error) {
+	token := ctx.Value(auth.JwtTokenCtx{}).(string)
+
+	uid, err := uuid.Parse(token)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	user, errGet := u.storage.GetPersonalKey(ctx, model.User{ID: &uid})
+	if errGet != nil {
+		if errors.Is(errGet, pgx.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, errGet.Error())
+		}
+		return nil, status.Error(codes.Internal, errGet.Error())
+	}
+
+	return &pb.GetPersonalKeyResponse{WrappedKey: user.PersonalKey}, nil
+}
+
+// SetRecovery - stores personal key of authorized user wrapped for recovery key together with hash of recovery
+// token, which later proves possession of recovery key.
+func (u *userGrpc) SetRecovery(ctx context.Context, in *pb.SetRecoveryRequest) (*pb.SetRecoveryResponse, error) {
+	token := ctx.Value(auth.JwtTokenCtx{}).(string)
+
+	uid, err := uuid.Parse(token)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	if len(in.WrappedKey) == 0 || len(in.TokenHash) != sha256.Size {
+		return nil, status.Error(codes.InvalidArgument, "wrapped key and token hash are required")
+	}
+
+	_, errSet := u.storage.SetRecovery(
+		ctx, model.User{ID: &uid, RecoveryKey: in.WrappedKey, RecoveryTokenHash: in.TokenHash},
+	)
+	if errSet != nil {
+		if errors.Is(errSet, pgx.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, errSet.Error())
+		}
+		return nil, status.Error(codes.Internal, errSet.Error())
+	}
+
+	return &pb.SetRecoveryResponse{}, nil
+}
+
+// GetRecoveryKey - returns personal key of a user wrapped for recovery key, if provided recovery token is valid.
+func (u *userGrpc) GetRecoveryKey(
+	ctx context.Context, in *pb.GetRecoveryKeyRequest,
+) (*pb.GetRecoveryKeyResponse, error) {
+	user, err := u.verifyRecovery(ctx, in.Login, in.RecoveryToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetRecoveryKeyResponse{WrappedKey: user.RecoveryKey}, nil
+}
+
+// Recover - sets new password, public key and wrapped personal key of a user, if provided recovery token is valid.
+//
+// On success returns JwtToken.
+func (u *userGrpc) Recover(ctx context.Context, in *pb.RecoverRequest) (*pb.RecoverResponse, error) {
+	user, err := u.verifyRecovery(ctx, in.Login, in.RecoveryToken)
+	if err != nil {
+		return nil, err
+	}
+
+	user.Login, user.Password = in.Login, in.Password
+
+	validate := validator.New()
+	if errV := validate.Struct(user); errV != nil {
+		return nil, status.Error(codes.InvalidArgument, errV.Error())
+	}
+
+	if len(in.PublicKey) != crypt.KeySize || len(in.WrappedPersonalKey) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "public key and wrapped personal key are required")
+	}
+
+	user.PublicKey, user.PersonalKey = in.PublicKey, in.WrappedPersonalKey
+
+	if _, errRecover := u.storage.Recover(ctx, user); errRecover != nil {
+		return nil, status.Error(codes.Internal, errRecover.Error())
+	}
+
+	token, errToken := u.jwtManager.Issue(user.ID.String())
+	if errToken != nil {
+		return nil, status.Error(codes.Internal, errToken.Error())
+	}
+
+	encoded, errEncode := u.crypter.Encode(token)
+	if errEncode != nil {
+		return nil, status.Error(codes.Internal, errEncode.Error())
+	}
+
+	return &pb.RecoverResponse{Token: encoded}, nil
+}
+
+// verifyRecovery - returns model.User with recovery data found by login, if hash of provided recovery token matches
+// the stored one.
+//
+// The same error is returned for unknown login, missing recovery and wrong token, so they can't be told apart.
+func (u *userGrpc) verifyRecovery(ctx context.Context, login string, recoveryToken []byte) (model.User, error) {
+	errInvalid := status.Error(codes.PermissionDenied, "recovery data is invalid")
+
+	user, err := u.storage.GetRecovery(ctx, model.User{Login: login})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return user, errInvalid
+		}
+		return user, status.Error(codes.Internal, err.Error())
+	}
+
+	hash := sha256.Sum256(recoveryToken)
+	if len(user.RecoveryTokenHash) == 0 || subtle.ConstantTimeCompare(hash[:], user.RecoveryTokenHash) != 1 {
+		return user, errInvalid
+	}
+
+	return user, nil
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"crypto/sha256"
 	"errors"
 	"net"
 	"testing"
@@ -10,10 +11,13 @@ import (
 	"github.com/google/uuid"
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	grpcauth "github.com/grpc-ecosystem/go-grpc-middleware/auth"
+	"github.com/jackc/pgx/v4"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 
 	"secretKeeper/internal/server/middleware/auth"
 	"secretKeeper/internal/server/model"
@@ -111,6 +115,101 @@ func Test_userGrpc_Delete(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func Test_userGrpc_SetPublicKey(t *testing.T) {
+	uid := uuid.New()
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer token")
+
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	client, done := userTestClient(t, ctl, uid)
+	defer close(done)
+
+	_, err := client.SetPublicKey(ctx, &pb.SetPublicKeyRequest{PublicKey: make([]byte, 32)})
+	assert.NoError(t, err)
+
+	_, err = client.SetPublicKey(ctx, &pb.SetPublicKeyRequest{PublicKey: []byte{1}})
+	assert.Error(t, err)
+}
+
+func Test_userGrpc_PersonalKey(t *testing.T) {
+	uid := uuid.New()
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer token")
+
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	client, done := userTestClient(t, ctl, uid)
+	defer close(done)
+
+	_, err := client.SetPersonalKey(ctx, &pb.SetPersonalKeyRequest{WrappedKey: []byte("wrapped")})
+	assert.NoError(t, err)
+
+	_, err = client.SetPersonalKey(ctx, &pb.SetPersonalKeyRequest{})
+	assert.Error(t, err)
+
+	res, errGet := client.GetPersonalKey(ctx, &pb.GetPersonalKeyRequest{})
+	assert.NoError(t, errGet)
+	assert.Equal(t, []byte("wrapped"), res.WrappedKey)
+}
+
+func Test_userGrpc_Recovery(t *testing.T) {
+	uid := uuid.New()
+
+	authCtx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer token")
+
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	client, done := userTestClient(t, ctl, uid)
+	defer close(done)
+
+	tokenHash := sha256.Sum256([]byte("recovery token"))
+
+	_, err := client.SetRecovery(authCtx, &pb.SetRecoveryRequest{WrappedKey: []byte{1}, TokenHash: tokenHash[:]})
+	assert.NoError(t, err)
+
+	_, err = client.SetRecovery(authCtx, &pb.SetRecoveryRequest{WrappedKey: []byte{1}, TokenHash: []byte{1}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	res, errKey := client.GetRecoveryKey(context.Background(), &pb.GetRecoveryKeyRequest{
+		Login: "recoverable", RecoveryToken: []byte("recovery token"),
+	})
+	assert.NoError(t, errKey)
+	assert.Equal(t, []byte("wrapped recovery"), res.WrappedKey)
+
+	_, errKey = client.GetRecoveryKey(context.Background(), &pb.GetRecoveryKeyRequest{
+		Login: "recoverable", RecoveryToken: []byte("wrong token"),
+	})
+	assert.Equal(t, codes.PermissionDenied, status.Code(errKey))
+
+	_, errKey = client.GetRecoveryKey(context.Background(), &pb.GetRecoveryKeyRequest{
+		Login: "unknown", RecoveryToken: []byte("recovery token"),
+	})
+	assert.Equal(t, codes.PermissionDenied, status.Code(errKey))
+
+	recovered, errRecover := client.Recover(context.Background(), &pb.RecoverRequest{
+		Login:              "recoverable",
+		RecoveryToken:      []byte("recovery token"),
+		Password:           "new password",
+		PublicKey:          make([]byte, 32),
+		WrappedPersonalKey: []byte{1},
+	})
+	assert.NoError(t, errRecover)
+	assert.Equal(t, "token", recovered.Token)
+
+	_, errRecover = client.Recover(context.Background(), &pb.RecoverRequest{
+		Login:              "recoverable",
+		RecoveryToken:      []byte("recovery token"),
+		Password:           "new password",
+		PublicKey:          []byte{1},
+		WrappedPersonalKey: []byte{1},
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(errRecover))
+}
+
 func userTestClient(t *testing.T, ctl *gomock.Controller, uid uuid.UUID) (pb.UserClient, chan<- struct{}) {
 	done := make(chan struct{})
 
@@ -148,12 +247,58 @@ func userTestClient(t *testing.T, ctl *gomock.Controller, uid uuid.UUID) (pb.Use
 		AnyTimes().
 		Return(model.User{}, nil)
 
+	userStorageMock.
+		EXPECT().
+		SetPublicKey(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		Return(model.User{ID: &uid, Login: "test"}, nil)
+
+	userStorageMock.
+		EXPECT().
+		SetPersonalKey(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		Return(model.User{ID: &uid, Login: "test"}, nil)
+
+	tokenHash := sha256.Sum256([]byte("recovery token"))
+
+	userStorageMock.
+		EXPECT().
+		SetRecovery(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		Return(model.User{ID: &uid, Login: "test"}, nil)
+
+	userStorageMock.
+		EXPECT().
+		GetRecovery(gomock.Any(), gomock.Eq(model.User{Login: "recoverable"})).
+		AnyTimes().
+		Return(model.User{
+			ID: &uid, Login: "recoverable", RecoveryKey: []byte("wrapped recovery"), RecoveryTokenHash: tokenHash[:],
+		}, nil)
+
+	userStorageMock.
+		EXPECT().
+		GetRecovery(gomock.Any(), gomock.Eq(model.User{Login: "unknown"})).
+		AnyTimes().
+		Return(model.User{Login: "unknown"}, pgx.ErrNoRows)
+
+	userStorageMock.
+		EXPECT().
+		Recover(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		Return(model.User{ID: &uid, Login: "recoverable"}, nil)
+
+	userStorageMock.
+		EXPECT().
+		GetPersonalKey(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		Return(model.User{ID: &uid, Login: "test", PersonalKey: []byte("wrapped")}, nil)
+
 	jwtM := jwtmock.NewMockManager(ctl)
 	jwtM.EXPECT().Issue(uid.String()).AnyTimes().Return("token", nil)
 	jwtM.EXPECT().Decode(gomock.Any()).AnyTimes().Return(uid.String(), nil)
 
 	cryptM := cryptmock.NewMockCrypter(ctl)
-	cryptM.EXPECT().Encode(gomock.Any()).AnyTimes().Return("token")
+	cryptM.EXPECT().Encode(gomock.Any()).AnyTimes().Return("token", nil)
 	cryptM.EXPECT().Decode(gomock.Any()).AnyTimes().Return(uid.String(), nil)
 
 	l, err := net.Listen("tcp", "localhost:0")
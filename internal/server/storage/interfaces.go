@@ -3,6 +3,7 @@ package storage
 
 import (
 	"context"
+	"time"
 
 	"secretKeeper/internal/server/model"
 )
@@ -14,6 +15,20 @@ type UserServerStorage interface {
 	GetByLoginAndPassword(ctx context.Context, user model.User) (model.User, error)
 	// DeleteUser - deletes a user from storage.
 	DeleteUser(ctx context.Context, user model.User) (model.User, error)
+	// SetPublicKey - stores public key of model.User in storage.
+	SetPublicKey(ctx context.Context, user model.User) (model.User, error)
+	// SetPersonalKey - stores wrapped personal key of model.User in storage.
+	SetPersonalKey(ctx context.Context, user model.User) (model.User, error)
+	// GetPersonalKey - returns model.User with wrapped personal key from storage.
+	GetPersonalKey(ctx context.Context, user model.User) (model.User, error)
+	// GetByLogin - returns model.User with public key from storage by login.
+	GetByLogin(ctx context.Context, user model.User) (model.User, error)
+	// SetRecovery - stores personal key of model.User wrapped for recovery key and hash of recovery token in storage.
+	SetRecovery(ctx context.Context, user model.User) (model.User, error)
+	// GetRecovery - returns model.User with recovery data from storage by login.
+	GetRecovery(ctx context.Context, user model.User) (model.User, error)
+	// Recover - replaces password, public key and wrapped personal key of model.User in storage.
+	Recover(ctx context.Context, user model.User) (model.User, error)
 }
 
 type SecretTypeServerStorage interface {
@@ -24,6 +39,8 @@ type SecretTypeServerStorage interface {
 type SecretServerStorage interface {
 	// CreateSecret - creates new model.Secret in storage.
 	CreateSecret(ctx context.Context, secret model.Secret) (model.Secret, error)
+	// CreateSecrets - creates []model.Secret in storage at once, none is created if any fails.
+	CreateSecrets(ctx context.Context, secrets []model.Secret) ([]model.Secret, error)
 	// GetSecret - gets a model.Secret from storage.
 	GetSecret(ctx context.Context, secret model.Secret) (model.Secret, error)
 	// DeleteSecret - deletes a model.Secret from storage.
@@ -31,5 +48,73 @@ type SecretServerStorage interface {
 	// EditSecret - updates a model.Secret in storage.
 	EditSecret(ctx context.Context, secret model.Secret, isForce bool) (model.Secret, error)
 	// GetListOfSecretByType - returns a list of []model.Secret from storage.
-	GetListOfSecretByType(ctx context.Context, secretType model.SecretType, user model.User) ([]model.Secret, error)
+	GetListOfSecretByType(
+		ctx context.Context, secretType model.SecretType, user model.User, vault model.Vault,
+	) ([]model.Secret, error)
+	// SetExpiry - stores expiry and rotation period of a model.Secret in storage.
+	SetExpiry(ctx context.Context, secret model.Secret) (model.Secret, error)
+	// RefreshDueEvents - moves auto expiring secrets to trash and renews []model.DueEvent of secrets which expire or
+	// need rotation until horizon. Returns number of secrets moved to trash.
+	RefreshDueEvents(ctx context.Context, now time.Time, horizon time.Time) (int, error)
+	// GetDueEvents - returns []model.DueEvent of personal secrets of model.User and of vaults available to the user.
+	GetDueEvents(ctx context.Context, user model.User) ([]model.DueEvent, error)
+}
+
+type OrganizationServerStorage interface {
+	// CreateOrganization - creates new model.Organization in storage with provided model.User as its owner.
+	CreateOrganization(ctx context.Context, org model.Organization, owner model.User) (model.Organization, error)
+	// GetOrganizations - returns list of model.Organization the model.User is member of.
+	GetOrganizations(ctx context.Context, user model.User) ([]model.Organization, error)
+	// AddMember - adds model.Member found by login to organization and marks its vaults for key rotation.
+	AddMember(ctx context.Context, member model.Member) (model.Member, []model.Vault, error)
+	// RemoveMember - removes model.Member found by login from organization, drops its vault keys and marks
+	// organization vaults for key rotation.
+	RemoveMember(ctx context.Context, member model.Member) (model.Member, []model.Vault, error)
+	// GetMembers - returns list of model.Member of organization.
+	GetMembers(ctx context.Context, org model.Organization) ([]model.Member, error)
+	// GetMemberRole - returns model.Role of a user inside organization.
+	GetMemberRole(ctx context.Context, org model.Organization, user model.User) (model.Role, error)
+	// GetVaultRole - returns model.Role of a user inside organization which owns the vault.
+	GetVaultRole(ctx context.Context, vault model.Vault, user model.User) (model.Role, error)
+	// CreateVault - creates new model.Vault with its wrapped keys in storage.
+	CreateVault(ctx context.Context, vault model.Vault, keys []model.VaultKey) (model.Vault, error)
+	// GetVault - returns model.Vault from storage.
+	GetVault(ctx context.Context, vault model.Vault) (model.Vault, error)
+	// GetVaults - returns list of model.Vault of organization.
+	GetVaults(ctx context.Context, org model.Organization) ([]model.Vault, error)
+	// GetVaultKey - returns model.VaultKey of a user.
+	GetVaultKey(ctx context.Context, key model.VaultKey) (model.VaultKey, error)
+	// RotateVaultKey - replaces all vault keys and re-encrypted content of vault secrets in storage.
+	RotateVaultKey(
+		ctx context.Context, vault model.Vault, keys []model.VaultKey, secrets []model.Secret,
+	) (model.Vault, error)
+}
+
+type EmergencyServerStorage interface {
+	// AddContact - stores model.EmergencyContact found by contact login in storage, replacing existing one.
+	AddContact(ctx context.Context, contact model.EmergencyContact) (model.EmergencyContact, error)
+	// RemoveContact - removes model.EmergencyContact found by contact login from storage.
+	RemoveContact(ctx context.Context, contact model.EmergencyContact) (model.EmergencyContact, error)
+	// GetContacts - returns list of model.EmergencyContact designated by the owner.
+	GetContacts(ctx context.Context, owner model.User) ([]model.EmergencyContact, error)
+	// GetGrantors - returns list of model.EmergencyContact where model.User is designated as a contact.
+	GetGrantors(ctx context.Context, contact model.User) ([]model.EmergencyContact, error)
+	// RequestAccess - marks model.EmergencyContact found by owner login as requested.
+	RequestAccess(ctx context.Context, contact model.EmergencyContact) (model.EmergencyContact, error)
+	// DenyAccess - marks model.EmergencyContact found by contact login as denied.
+	DenyAccess(ctx context.Context, contact model.EmergencyContact) (model.EmergencyContact, error)
+	// GetGrantor - returns model.EmergencyContact with wrapped key found by owner login.
+	GetGrantor(ctx context.Context, contact model.EmergencyContact) (model.EmergencyContact, error)
+	// GetPersonalSecrets - returns list of personal model.Secret of the owner, which are not deleted.
+	GetPersonalSecrets(ctx context.Context, owner model.User) ([]model.Secret, error)
+}
+
+type SendServerStorage interface {
+	// CreateSend - stores new model.Send in storage and returns it with generated id.
+	CreateSend(ctx context.Context, send model.Send) (model.Send, error)
+	// OpenSend - returns content of model.Send which is not expired and still has views, consuming one view.
+	// Send without views left is purged.
+	OpenSend(ctx context.Context, send model.Send, now time.Time) (model.Send, error)
+	// PurgeSends - removes expired model.Send from storage and returns number of removed ones.
+	PurgeSends(ctx context.Context, now time.Time) (int, error)
 }
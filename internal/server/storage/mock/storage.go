@@ -7,9 +7,10 @@ package storagemock
 import (
 	context "context"
 	reflect "reflect"
+	model "secretKeeper/internal/server/model"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
-	model "secretKeeper/internal/server/model"
 )
 
 // MockUserServerStorage is a mock of UserServerStorage interface.
@@ -65,6 +66,21 @@ func (mr *MockUserServerStorageMockRecorder) DeleteUser(ctx, user interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockUserServerStorage)(nil).DeleteUser), ctx, user)
 }
 
+// GetByLogin mocks base method.
+func (m *MockUserServerStorage) GetByLogin(ctx context.Context, user model.User) (model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByLogin", ctx, user)
+	ret0, _ := ret[0].(model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByLogin indicates an expected call of GetByLogin.
+func (mr *MockUserServerStorageMockRecorder) GetByLogin(ctx, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByLogin", reflect.TypeOf((*MockUserServerStorage)(nil).GetByLogin), ctx, user)
+}
+
 // GetByLoginAndPassword mocks base method.
 func (m *MockUserServerStorage) GetByLoginAndPassword(ctx context.Context, user model.User) (model.User, error) {
 	m.ctrl.T.Helper()
@@ -80,6 +96,96 @@ func (mr *MockUserServerStorageMockRecorder) GetByLoginAndPassword(ctx, user int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByLoginAndPassword", reflect.TypeOf((*MockUserServerStorage)(nil).GetByLoginAndPassword), ctx, user)
 }
 
+// GetPersonalKey mocks base method.
+func (m *MockUserServerStorage) GetPersonalKey(ctx context.Context, user model.User) (model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPersonalKey", ctx, user)
+	ret0, _ := ret[0].(model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPersonalKey indicates an expected call of GetPersonalKey.
+func (mr *MockUserServerStorageMockRecorder) GetPersonalKey(ctx, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPersonalKey", reflect.TypeOf((*MockUserServerStorage)(nil).GetPersonalKey), ctx, user)
+}
+
+// GetRecovery mocks base method.
+func (m *MockUserServerStorage) GetRecovery(ctx context.Context, user model.User) (model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecovery", ctx, user)
+	ret0, _ := ret[0].(model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecovery indicates an expected call of GetRecovery.
+func (mr *MockUserServerStorageMockRecorder) GetRecovery(ctx, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecovery", reflect.TypeOf((*MockUserServerStorage)(nil).GetRecovery), ctx, user)
+}
+
+// Recover mocks base method.
+func (m *MockUserServerStorage) Recover(ctx context.Context, user model.User) (model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recover", ctx, user)
+	ret0, _ := ret[0].(model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recover indicates an expected call of Recover.
+func (mr *MockUserServerStorageMockRecorder) Recover(ctx, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recover", reflect.TypeOf((*MockUserServerStorage)(nil).Recover), ctx, user)
+}
+
+// SetPersonalKey mocks base method.
+func (m *MockUserServerStorage) SetPersonalKey(ctx context.Context, user model.User) (model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPersonalKey", ctx, user)
+	ret0, _ := ret[0].(model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetPersonalKey indicates an expected call of SetPersonalKey.
+func (mr *MockUserServerStorageMockRecorder) SetPersonalKey(ctx, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPersonalKey", reflect.TypeOf((*MockUserServerStorage)(nil).SetPersonalKey), ctx, user)
+}
+
+// SetPublicKey mocks base method.
+func (m *MockUserServerStorage) SetPublicKey(ctx context.Context, user model.User) (model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPublicKey", ctx, user)
+	ret0, _ := ret[0].(model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetPublicKey indicates an expected call of SetPublicKey.
+func (mr *MockUserServerStorageMockRecorder) SetPublicKey(ctx, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPublicKey", reflect.TypeOf((*MockUserServerStorage)(nil).SetPublicKey), ctx, user)
+}
+
+// SetRecovery mocks base method.
+func (m *MockUserServerStorage) SetRecovery(ctx context.Context, user model.User) (model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRecovery", ctx, user)
+	ret0, _ := ret[0].(model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetRecovery indicates an expected call of SetRecovery.
+func (mr *MockUserServerStorageMockRecorder) SetRecovery(ctx, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRecovery", reflect.TypeOf((*MockUserServerStorage)(nil).SetRecovery), ctx, user)
+}
+
 // MockSecretTypeServerStorage is a mock of SecretTypeServerStorage interface.
 type MockSecretTypeServerStorage struct {
 	ctrl     *gomock.Controller
@@ -156,6 +262,21 @@ func (mr *MockSecretServerStorageMockRecorder) CreateSecret(ctx, secret interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSecret", reflect.TypeOf((*MockSecretServerStorage)(nil).CreateSecret), ctx, secret)
 }
 
+// CreateSecrets mocks base method.
+func (m *MockSecretServerStorage) CreateSecrets(ctx context.Context, secrets []model.Secret) ([]model.Secret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSecrets", ctx, secrets)
+	ret0, _ := ret[0].([]model.Secret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSecrets indicates an expected call of CreateSecrets.
+func (mr *MockSecretServerStorageMockRecorder) CreateSecrets(ctx, secrets interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSecrets", reflect.TypeOf((*MockSecretServerStorage)(nil).CreateSecrets), ctx, secrets)
+}
+
 // DeleteSecret mocks base method.
 func (m *MockSecretServerStorage) DeleteSecret(ctx context.Context, secret model.Secret) (model.Secret, error) {
 	m.ctrl.T.Helper()
@@ -186,19 +307,34 @@ func (mr *MockSecretServerStorageMockRecorder) EditSecret(ctx, secret, isForce i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditSecret", reflect.TypeOf((*MockSecretServerStorage)(nil).EditSecret), ctx, secret, isForce)
 }
 
+// GetDueEvents mocks base method.
+func (m *MockSecretServerStorage) GetDueEvents(ctx context.Context, user model.User) ([]model.DueEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDueEvents", ctx, user)
+	ret0, _ := ret[0].([]model.DueEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDueEvents indicates an expected call of GetDueEvents.
+func (mr *MockSecretServerStorageMockRecorder) GetDueEvents(ctx, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDueEvents", reflect.TypeOf((*MockSecretServerStorage)(nil).GetDueEvents), ctx, user)
+}
+
 // GetListOfSecretByType mocks base method.
-func (m *MockSecretServerStorage) GetListOfSecretByType(ctx context.Context, secretType model.SecretType, user model.User) ([]model.Secret, error) {
+func (m *MockSecretServerStorage) GetListOfSecretByType(ctx context.Context, secretType model.SecretType, user model.User, vault model.Vault) ([]model.Secret, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetListOfSecretByType", ctx, secretType, user)
+	ret := m.ctrl.Call(m, "GetListOfSecretByType", ctx, secretType, user, vault)
 	ret0, _ := ret[0].([]model.Secret)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetListOfSecretByType indicates an expected call of GetListOfSecretByType.
-func (mr *MockSecretServerStorageMockRecorder) GetListOfSecretByType(ctx, secretType, user interface{}) *gomock.Call {
+func (mr *MockSecretServerStorageMockRecorder) GetListOfSecretByType(ctx, secretType, user, vault interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListOfSecretByType", reflect.TypeOf((*MockSecretServerStorage)(nil).GetListOfSecretByType), ctx, secretType, user)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListOfSecretByType", reflect.TypeOf((*MockSecretServerStorage)(nil).GetListOfSecretByType), ctx, secretType, user, vault)
 }
 
 // GetSecret mocks base method.
@@ -215,3 +351,449 @@ func (mr *MockSecretServerStorageMockRecorder) GetSecret(ctx, secret interface{}
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecret", reflect.TypeOf((*MockSecretServerStorage)(nil).GetSecret), ctx, secret)
 }
+
+// RefreshDueEvents mocks base method.
+func (m *MockSecretServerStorage) RefreshDueEvents(ctx context.Context, now, horizon time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshDueEvents", ctx, now, horizon)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefreshDueEvents indicates an expected call of RefreshDueEvents.
+func (mr *MockSecretServerStorageMockRecorder) RefreshDueEvents(ctx, now, horizon interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshDueEvents", reflect.TypeOf((*MockSecretServerStorage)(nil).RefreshDueEvents), ctx, now, horizon)
+}
+
+// SetExpiry mocks base method.
+func (m *MockSecretServerStorage) SetExpiry(ctx context.Context, secret model.Secret) (model.Secret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetExpiry", ctx, secret)
+	ret0, _ := ret[0].(model.Secret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetExpiry indicates an expected call of SetExpiry.
+func (mr *MockSecretServerStorageMockRecorder) SetExpiry(ctx, secret interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetExpiry", reflect.TypeOf((*MockSecretServerStorage)(nil).SetExpiry), ctx, secret)
+}
+
+// MockOrganizationServerStorage is a mock of OrganizationServerStorage interface.
+type MockOrganizationServerStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrganizationServerStorageMockRecorder
+}
+
+// MockOrganizationServerStorageMockRecorder is the mock recorder for MockOrganizationServerStorage.
+type MockOrganizationServerStorageMockRecorder struct {
+	mock *MockOrganizationServerStorage
+}
+
+// NewMockOrganizationServerStorage creates a new mock instance.
+func NewMockOrganizationServerStorage(ctrl *gomock.Controller) *MockOrganizationServerStorage {
+	mock := &MockOrganizationServerStorage{ctrl: ctrl}
+	mock.recorder = &MockOrganizationServerStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrganizationServerStorage) EXPECT() *MockOrganizationServerStorageMockRecorder {
+	return m.recorder
+}
+
+// AddMember mocks base method.
+func (m *MockOrganizationServerStorage) AddMember(ctx context.Context, member model.Member) (model.Member, []model.Vault, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMember", ctx, member)
+	ret0, _ := ret[0].(model.Member)
+	ret1, _ := ret[1].([]model.Vault)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// AddMember indicates an expected call of AddMember.
+func (mr *MockOrganizationServerStorageMockRecorder) AddMember(ctx, member interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMember", reflect.TypeOf((*MockOrganizationServerStorage)(nil).AddMember), ctx, member)
+}
+
+// CreateOrganization mocks base method.
+func (m *MockOrganizationServerStorage) CreateOrganization(ctx context.Context, org model.Organization, owner model.User) (model.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrganization", ctx, org, owner)
+	ret0, _ := ret[0].(model.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrganization indicates an expected call of CreateOrganization.
+func (mr *MockOrganizationServerStorageMockRecorder) CreateOrganization(ctx, org, owner interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrganization", reflect.TypeOf((*MockOrganizationServerStorage)(nil).CreateOrganization), ctx, org, owner)
+}
+
+// CreateVault mocks base method.
+func (m *MockOrganizationServerStorage) CreateVault(ctx context.Context, vault model.Vault, keys []model.VaultKey) (model.Vault, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVault", ctx, vault, keys)
+	ret0, _ := ret[0].(model.Vault)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateVault indicates an expected call of CreateVault.
+func (mr *MockOrganizationServerStorageMockRecorder) CreateVault(ctx, vault, keys interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVault", reflect.TypeOf((*MockOrganizationServerStorage)(nil).CreateVault), ctx, vault, keys)
+}
+
+// GetMemberRole mocks base method.
+func (m *MockOrganizationServerStorage) GetMemberRole(ctx context.Context, org model.Organization, user model.User) (model.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMemberRole", ctx, org, user)
+	ret0, _ := ret[0].(model.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMemberRole indicates an expected call of GetMemberRole.
+func (mr *MockOrganizationServerStorageMockRecorder) GetMemberRole(ctx, org, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMemberRole", reflect.TypeOf((*MockOrganizationServerStorage)(nil).GetMemberRole), ctx, org, user)
+}
+
+// GetMembers mocks base method.
+func (m *MockOrganizationServerStorage) GetMembers(ctx context.Context, org model.Organization) ([]model.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMembers", ctx, org)
+	ret0, _ := ret[0].([]model.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMembers indicates an expected call of GetMembers.
+func (mr *MockOrganizationServerStorageMockRecorder) GetMembers(ctx, org interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembers", reflect.TypeOf((*MockOrganizationServerStorage)(nil).GetMembers), ctx, org)
+}
+
+// GetOrganizations mocks base method.
+func (m *MockOrganizationServerStorage) GetOrganizations(ctx context.Context, user model.User) ([]model.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrganizations", ctx, user)
+	ret0, _ := ret[0].([]model.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrganizations indicates an expected call of GetOrganizations.
+func (mr *MockOrganizationServerStorageMockRecorder) GetOrganizations(ctx, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrganizations", reflect.TypeOf((*MockOrganizationServerStorage)(nil).GetOrganizations), ctx, user)
+}
+
+// GetVault mocks base method.
+func (m *MockOrganizationServerStorage) GetVault(ctx context.Context, vault model.Vault) (model.Vault, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVault", ctx, vault)
+	ret0, _ := ret[0].(model.Vault)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVault indicates an expected call of GetVault.
+func (mr *MockOrganizationServerStorageMockRecorder) GetVault(ctx, vault interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVault", reflect.TypeOf((*MockOrganizationServerStorage)(nil).GetVault), ctx, vault)
+}
+
+// GetVaultKey mocks base method.
+func (m *MockOrganizationServerStorage) GetVaultKey(ctx context.Context, key model.VaultKey) (model.VaultKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVaultKey", ctx, key)
+	ret0, _ := ret[0].(model.VaultKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVaultKey indicates an expected call of GetVaultKey.
+func (mr *MockOrganizationServerStorageMockRecorder) GetVaultKey(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVaultKey", reflect.TypeOf((*MockOrganizationServerStorage)(nil).GetVaultKey), ctx, key)
+}
+
+// GetVaultRole mocks base method.
+func (m *MockOrganizationServerStorage) GetVaultRole(ctx context.Context, vault model.Vault, user model.User) (model.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVaultRole", ctx, vault, user)
+	ret0, _ := ret[0].(model.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVaultRole indicates an expected call of GetVaultRole.
+func (mr *MockOrganizationServerStorageMockRecorder) GetVaultRole(ctx, vault, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVaultRole", reflect.TypeOf((*MockOrganizationServerStorage)(nil).GetVaultRole), ctx, vault, user)
+}
+
+// GetVaults mocks base method.
+func (m *MockOrganizationServerStorage) GetVaults(ctx context.Context, org model.Organization) ([]model.Vault, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVaults", ctx, org)
+	ret0, _ := ret[0].([]model.Vault)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVaults indicates an expected call of GetVaults.
+func (mr *MockOrganizationServerStorageMockRecorder) GetVaults(ctx, org interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVaults", reflect.TypeOf((*MockOrganizationServerStorage)(nil).GetVaults), ctx, org)
+}
+
+// RemoveMember mocks base method.
+func (m *MockOrganizationServerStorage) RemoveMember(ctx context.Context, member model.Member) (model.Member, []model.Vault, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMember", ctx, member)
+	ret0, _ := ret[0].(model.Member)
+	ret1, _ := ret[1].([]model.Vault)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RemoveMember indicates an expected call of RemoveMember.
+func (mr *MockOrganizationServerStorageMockRecorder) RemoveMember(ctx, member interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMember", reflect.TypeOf((*MockOrganizationServerStorage)(nil).RemoveMember), ctx, member)
+}
+
+// RotateVaultKey mocks base method.
+func (m *MockOrganizationServerStorage) RotateVaultKey(ctx context.Context, vault model.Vault, keys []model.VaultKey, secrets []model.Secret) (model.Vault, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateVaultKey", ctx, vault, keys, secrets)
+	ret0, _ := ret[0].(model.Vault)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateVaultKey indicates an expected call of RotateVaultKey.
+func (mr *MockOrganizationServerStorageMockRecorder) RotateVaultKey(ctx, vault, keys, secrets interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateVaultKey", reflect.TypeOf((*MockOrganizationServerStorage)(nil).RotateVaultKey), ctx, vault, keys, secrets)
+}
+
+// MockEmergencyServerStorage is a mock of EmergencyServerStorage interface.
+type MockEmergencyServerStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockEmergencyServerStorageMockRecorder
+}
+
+// MockEmergencyServerStorageMockRecorder is the mock recorder for MockEmergencyServerStorage.
+type MockEmergencyServerStorageMockRecorder struct {
+	mock *MockEmergencyServerStorage
+}
+
+// NewMockEmergencyServerStorage creates a new mock instance.
+func NewMockEmergencyServerStorage(ctrl *gomock.Controller) *MockEmergencyServerStorage {
+	mock := &MockEmergencyServerStorage{ctrl: ctrl}
+	mock.recorder = &MockEmergencyServerStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEmergencyServerStorage) EXPECT() *MockEmergencyServerStorageMockRecorder {
+	return m.recorder
+}
+
+// AddContact mocks base method.
+func (m *MockEmergencyServerStorage) AddContact(ctx context.Context, contact model.EmergencyContact) (model.EmergencyContact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddContact", ctx, contact)
+	ret0, _ := ret[0].(model.EmergencyContact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddContact indicates an expected call of AddContact.
+func (mr *MockEmergencyServerStorageMockRecorder) AddContact(ctx, contact interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddContact", reflect.TypeOf((*MockEmergencyServerStorage)(nil).AddContact), ctx, contact)
+}
+
+// DenyAccess mocks base method.
+func (m *MockEmergencyServerStorage) DenyAccess(ctx context.Context, contact model.EmergencyContact) (model.EmergencyContact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DenyAccess", ctx, contact)
+	ret0, _ := ret[0].(model.EmergencyContact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DenyAccess indicates an expected call of DenyAccess.
+func (mr *MockEmergencyServerStorageMockRecorder) DenyAccess(ctx, contact interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DenyAccess", reflect.TypeOf((*MockEmergencyServerStorage)(nil).DenyAccess), ctx, contact)
+}
+
+// GetContacts mocks base method.
+func (m *MockEmergencyServerStorage) GetContacts(ctx context.Context, owner model.User) ([]model.EmergencyContact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContacts", ctx, owner)
+	ret0, _ := ret[0].([]model.EmergencyContact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContacts indicates an expected call of GetContacts.
+func (mr *MockEmergencyServerStorageMockRecorder) GetContacts(ctx, owner interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContacts", reflect.TypeOf((*MockEmergencyServerStorage)(nil).GetContacts), ctx, owner)
+}
+
+// GetGrantor mocks base method.
+func (m *MockEmergencyServerStorage) GetGrantor(ctx context.Context, contact model.EmergencyContact) (model.EmergencyContact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGrantor", ctx, contact)
+	ret0, _ := ret[0].(model.EmergencyContact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGrantor indicates an expected call of GetGrantor.
+func (mr *MockEmergencyServerStorageMockRecorder) GetGrantor(ctx, contact interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGrantor", reflect.TypeOf((*MockEmergencyServerStorage)(nil).GetGrantor), ctx, contact)
+}
+
+// GetGrantors mocks base method.
+func (m *MockEmergencyServerStorage) GetGrantors(ctx context.Context, contact model.User) ([]model.EmergencyContact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGrantors", ctx, contact)
+	ret0, _ := ret[0].([]model.EmergencyContact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGrantors indicates an expected call of GetGrantors.
+func (mr *MockEmergencyServerStorageMockRecorder) GetGrantors(ctx, contact interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGrantors", reflect.TypeOf((*MockEmergencyServerStorage)(nil).GetGrantors), ctx, contact)
+}
+
+// GetPersonalSecrets mocks base method.
+func (m *MockEmergencyServerStorage) GetPersonalSecrets(ctx context.Context, owner model.User) ([]model.Secret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPersonalSecrets", ctx, owner)
+	ret0, _ := ret[0].([]model.Secret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPersonalSecrets indicates an expected call of GetPersonalSecrets.
+func (mr *MockEmergencyServerStorageMockRecorder) GetPersonalSecrets(ctx, owner interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPersonalSecrets", reflect.TypeOf((*MockEmergencyServerStorage)(nil).GetPersonalSecrets), ctx, owner)
+}
+
+// RemoveContact mocks base method.
+func (m *MockEmergencyServerStorage) RemoveContact(ctx context.Context, contact model.EmergencyContact) (model.EmergencyContact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveContact", ctx, contact)
+	ret0, _ := ret[0].(model.EmergencyContact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveContact indicates an expected call of RemoveContact.
+func (mr *MockEmergencyServerStorageMockRecorder) RemoveContact(ctx, contact interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveContact", reflect.TypeOf((*MockEmergencyServerStorage)(nil).RemoveContact), ctx, contact)
+}
+
+// RequestAccess mocks base method.
+func (m *MockEmergencyServerStorage) RequestAccess(ctx context.Context, contact model.EmergencyContact) (model.EmergencyContact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestAccess", ctx, contact)
+	ret0, _ := ret[0].(model.EmergencyContact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestAccess indicates an expected call of RequestAccess.
+func (mr *MockEmergencyServerStorageMockRecorder) RequestAccess(ctx, contact interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestAccess", reflect.TypeOf((*MockEmergencyServerStorage)(nil).RequestAccess), ctx, contact)
+}
+
+// MockSendServerStorage is a mock of SendServerStorage interface.
+type MockSendServerStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockSendServerStorageMockRecorder
+}
+
+// MockSendServerStorageMockRecorder is the mock recorder for MockSendServerStorage.
+type MockSendServerStorageMockRecorder struct {
+	mock *MockSendServerStorage
+}
+
+// NewMockSendServerStorage creates a new mock instance.
+func NewMockSendServerStorage(ctrl *gomock.Controller) *MockSendServerStorage {
+	mock := &MockSendServerStorage{ctrl: ctrl}
+	mock.recorder = &MockSendServerStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSendServerStorage) EXPECT() *MockSendServerStorageMockRecorder {
+	return m.recorder
+}
+
+// CreateSend mocks base method.
+func (m *MockSendServerStorage) CreateSend(ctx context.Context, send model.Send) (model.Send, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSend", ctx, send)
+	ret0, _ := ret[0].(model.Send)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSend indicates an expected call of CreateSend.
+func (mr *MockSendServerStorageMockRecorder) CreateSend(ctx, send interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSend", reflect.TypeOf((*MockSendServerStorage)(nil).CreateSend), ctx, send)
+}
+
+// OpenSend mocks base method.
+func (m *MockSendServerStorage) OpenSend(ctx context.Context, send model.Send, now time.Time) (model.Send, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenSend", ctx, send, now)
+	ret0, _ := ret[0].(model.Send)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OpenSend indicates an expected call of OpenSend.
+func (mr *MockSendServerStorageMockRecorder) OpenSend(ctx, send, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenSend", reflect.TypeOf((*MockSendServerStorage)(nil).OpenSend), ctx, send, now)
+}
+
+// PurgeSends mocks base method.
+func (m *MockSendServerStorage) PurgeSends(ctx context.Context, now time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeSends", ctx, now)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeSends indicates an expected call of PurgeSends.
+func (mr *MockSendServerStorageMockRecorder) PurgeSends(ctx, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeSends", reflect.TypeOf((*MockSendServerStorage)(nil).PurgeSends), ctx, now)
+}
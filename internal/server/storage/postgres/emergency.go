@@ -0,0 +1,278 @@
+package postgres
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+
+	"secretKeeper/internal/server/model"
+	"secretKeeper/internal/server/storage"
+)
+
+var _ storage.EmergencyServerStorage = (*EmergencyPostgresStorage)(nil)
+
+type EmergencyPostgresStorage struct {
+	conn *pgx.Conn
+}
+
+const (
+	AddEmergencyContact = `insert into emergency_contacts (owner_id, contact_id, wait_hours, wrapped_key)
+						   select $1, id, $3, $4 from users where login = $2 and public_key is not null and id <> $1
+						   on conflict (owner_id, contact_id) do update
+						   set wait_hours = excluded.wait_hours, wrapped_key = excluded.wrapped_key,
+							   status = 'idle', requested_at = null
+						   returning contact_id, status, created_at
+`
+	RemoveEmergencyContact = `delete from emergency_contacts c
+							  using users u
+							  where c.contact_id = u.id and c.owner_id = $1 and u.login = $2
+							  returning c.contact_id
+`
+	emergencyContactFields = `select c.owner_id, o.login, c.contact_id, u.login, c.wait_hours, c.status,
+							  c.requested_at, c.created_at
+							  from emergency_contacts c
+							  join users o on o.id = c.owner_id
+							  join users u on u.id = c.contact_id
+`
+	EmergencyContactsByOwner   = emergencyContactFields + `where c.owner_id = $1`
+	EmergencyContactsByContact = emergencyContactFields + `where c.contact_id = $1`
+	RequestEmergencyAccess     = `update emergency_contacts c
+								  set status = 'requested',
+									  requested_at = case when c.status = 'requested' then c.requested_at else now() end
+								  from users o
+								  where o.id = c.owner_id and o.login = $1 and c.contact_id = $2
+								  returning c.owner_id, c.wait_hours, c.status, c.requested_at, c.created_at
+`
+	DenyEmergencyAccess = `update emergency_contacts c
+						   set status = 'denied', requested_at = null
+						   from users u
+						   where u.id = c.contact_id and c.owner_id = $1 and u.login = $2
+						   returning c.contact_id, c.wait_hours, c.status, c.created_at
+`
+	GetEmergencyGrantor = `select c.owner_id, c.wait_hours, c.status, c.requested_at, c.wrapped_key, c.created_at
+						   from emergency_contacts c
+						   join users o on o.id = c.owner_id
+						   where o.login = $1 and c.contact_id = $2
+`
+	PersonalSecrets = `select id, user_id, type_id, title, content, created_at, updated_at
+					   from secrets
+					   where user_id = $1 and vault_id is null and is_deleted = false
+`
+)
+
+// NewEmergencyPostgresStorage - creates a postgres storage for emergency contacts.
+func NewEmergencyPostgresStorage(c *pgx.Conn) *EmergencyPostgresStorage {
+	return &EmergencyPostgresStorage{conn: c}
+}
+
+// AddContact - stores provided model.EmergencyContact, where contact is found by ContactLogin.
+//
+// Contact must have a public key and can't be the owner, otherwise pgx.ErrNoRows is returned. If contact is already
+// designated, then wrapped key and waiting period are replaced and previous access request is reset.
+func (e *EmergencyPostgresStorage) AddContact(
+	ctx context.Context, contact model.EmergencyContact,
+) (model.EmergencyContact, error) {
+	ctxWithTimeOut, cancel := context.WithTimeout(ctx, time.Second*5)
+	defer cancel()
+
+	var st string
+	err := e.conn.QueryRow(
+		ctxWithTimeOut, AddEmergencyContact, contact.OwnerID, contact.ContactLogin, contact.WaitHours, contact.WrappedKey,
+	).Scan(&contact.ContactID, &st, &contact.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return contact, err
+		}
+
+		return contact, fmt.Errorf("emergency contact insertion err: %w", err)
+	}
+
+	contact.Status = model.EmergencyStatus(st)
+	contact.RequestedAt = nil
+
+	return contact, nil
+}
+
+// RemoveContact - removes model.EmergencyContact of the owner, where contact is found by ContactLogin.
+func (e *EmergencyPostgresStorage) RemoveContact(
+	ctx context.Context, contact model.EmergencyContact,
+) (model.EmergencyContact, error) {
+	ctxWithTimeOut, cancel := context.WithTimeout(ctx, time.Second*5)
+	defer cancel()
+
+	err := e.conn.QueryRow(ctxWithTimeOut, RemoveEmergencyContact, contact.OwnerID, contact.ContactLogin).
+		Scan(&contact.ContactID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return contact, err
+		}
+
+		return contact, fmt.Errorf("emergency contact deletion err: %w", err)
+	}
+
+	return contact, nil
+}
+
+// GetContacts - returns a []model.EmergencyContact designated by provided model.User from database.
+func (e *EmergencyPostgresStorage) GetContacts(
+	ctx context.Context, owner model.User,
+) ([]model.EmergencyContact, error) {
+	return e.getContacts(ctx, EmergencyContactsByOwner, owner)
+}
+
+// GetGrantors - returns a []model.EmergencyContact where provided model.User is designated as a contact.
+func (e *EmergencyPostgresStorage) GetGrantors(
+	ctx context.Context, contact model.User,
+) ([]model.EmergencyContact, error) {
+	return e.getContacts(ctx, EmergencyContactsByContact, contact)
+}
+
+// RequestAccess - marks model.EmergencyContact, where owner is found by OwnerLogin, as requested.
+//
+// Repeated request keeps the time of the first one, so waiting period is not restarted.
+func (e *EmergencyPostgresStorage) RequestAccess(
+	ctx context.Context, contact model.EmergencyContact,
+) (model.EmergencyContact, error) {
+	ctxWithTimeOut, cancel := context.WithTimeout(ctx, time.Second*5)
+	defer cancel()
+
+	var st string
+	err := e.conn.QueryRow(ctxWithTimeOut, RequestEmergencyAccess, contact.OwnerLogin, contact.ContactID).
+		Scan(&contact.OwnerID, &contact.WaitHours, &st, &contact.RequestedAt, &contact.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return contact, err
+		}
+
+		return contact, fmt.Errorf("emergency access request err: %w", err)
+	}
+
+	contact.Status = model.EmergencyStatus(st)
+
+	return contact, nil
+}
+
+// DenyAccess - marks model.EmergencyContact of the owner, where contact is found by ContactLogin, as denied.
+func (e *EmergencyPostgresStorage) DenyAccess(
+	ctx context.Context, contact model.EmergencyContact,
+) (model.EmergencyContact, error) {
+	ctxWithTimeOut, cancel := context.WithTimeout(ctx, time.Second*5)
+	defer cancel()
+
+	var st string
+	err := e.conn.QueryRow(ctxWithTimeOut, DenyEmergencyAccess, contact.OwnerID, contact.ContactLogin).
+		Scan(&contact.ContactID, &contact.WaitHours, &st, &contact.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return contact, err
+		}
+
+		return contact, fmt.Errorf("emergency access denial err: %w", err)
+	}
+
+	contact.Status = model.EmergencyStatus(st)
+	contact.RequestedAt = nil
+
+	return contact, nil
+}
+
+// GetGrantor - returns model.EmergencyContact together with wrapped key, where owner is found by OwnerLogin.
+func (e *EmergencyPostgresStorage) GetGrantor(
+	ctx context.Context, contact model.EmergencyContact,
+) (model.EmergencyContact, error) {
+	ctxWithTimeOut, cancel := context.WithTimeout(ctx, time.Second*5)
+	defer cancel()
+
+	var st string
+	err := e.conn.QueryRow(ctxWithTimeOut, GetEmergencyGrantor, contact.OwnerLogin, contact.ContactID).Scan(
+		&contact.OwnerID, &contact.WaitHours, &st, &contact.RequestedAt, &contact.WrappedKey, &contact.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return contact, err
+		}
+
+		return contact, fmt.Errorf("emergency grantor select err: %w", err)
+	}
+
+	contact.Status = model.EmergencyStatus(st)
+
+	return contact, nil
+}
+
+// GetPersonalSecrets - returns a []model.Secret of provided model.User, which are neither deleted nor stored
+// in organization vaults.
+//
+// Values of key Content of model.Secret are being hex decoded.
+func (e *EmergencyPostgresStorage) GetPersonalSecrets(ctx context.Context, owner model.User) ([]model.Secret, error) {
+	ctxWithTimeOut, cancel := context.WithTimeout(ctx, time.Second*5)
+	defer cancel()
+
+	var list []model.Secret
+
+	rows, err := e.conn.Query(ctxWithTimeOut, PersonalSecrets, owner.ID)
+	if err != nil {
+		return list, fmt.Errorf("getting list of secrets error: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var secret model.Secret
+
+		if err = rows.Scan(
+			&secret.ID, &secret.UserID, &secret.TypeID, &secret.Title, &secret.Content,
+			&secret.CreatedAt, &secret.UpdatedAt,
+		); err != nil {
+			return list, fmt.Errorf("error in scanning gotten row: %w", err)
+		}
+
+		secret.Content, err = hex.DecodeString(string(secret.Content))
+		if err != nil {
+			return list, fmt.Errorf("hex decoding error: %w", err)
+		}
+
+		list = append(list, secret)
+	}
+
+	return list, rows.Err()
+}
+
+// getContacts - runs provided contacts query for provided model.User.
+func (e *EmergencyPostgresStorage) getContacts(
+	ctx context.Context, query string, user model.User,
+) ([]model.EmergencyContact, error) {
+	ctxWithTimeOut, cancel := context.WithTimeout(ctx, time.Second*5)
+	defer cancel()
+
+	var list []model.EmergencyContact
+
+	rows, err := e.conn.Query(ctxWithTimeOut, query, user.ID)
+	if err != nil {
+		return list, fmt.Errorf("getting list of emergency contacts error: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			contact model.EmergencyContact
+			st      string
+		)
+
+		if err = rows.Scan(
+			&contact.OwnerID, &contact.OwnerLogin, &contact.ContactID, &contact.ContactLogin, &contact.WaitHours, &st,
+			&contact.RequestedAt, &contact.CreatedAt,
+		); err != nil {
+			return list, fmt.Errorf("error in scanning gotten row: %w", err)
+		}
+
+		contact.Status = model.EmergencyStatus(st)
+
+		list = append(list, contact)
+	}
+
+	return list, rows.Err()
+}
@@ -0,0 +1,88 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+
+	"secretKeeper/internal/server/model"
+	"secretKeeper/pkg/utils"
+)
+
+func TestNewEmergencyPostgresStorage(t *testing.T) {
+	tests := []struct {
+		name string
+		want *EmergencyPostgresStorage
+	}{
+		{
+			name: "New Postgres Emergency Storage can be created",
+			want: &EmergencyPostgresStorage{conn: &pgx.Conn{}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equalf(t, tt.want, NewEmergencyPostgresStorage(&pgx.Conn{}), "NewEmergencyPostgresStorage()")
+		})
+	}
+}
+
+func TestEmergencyPostgresStorage_Access(t *testing.T) {
+	utils.RefreshTestDatabase()
+
+	ctx := context.Background()
+
+	con := utils.CreatePostgresTestConn()
+	defer con.Close(ctx)
+
+	users := UserPostgresStorage{conn: con}
+	storage := NewEmergencyPostgresStorage(con)
+
+	owner, _ := users.Create(ctx, model.User{Login: "owner", Password: "test"})
+	contact, _ := users.Create(ctx, model.User{Login: "contact", Password: "test"})
+
+	_, err := storage.AddContact(ctx, model.EmergencyContact{
+		OwnerID: *owner.ID, ContactLogin: "contact", WaitHours: 48, WrappedKey: []byte("wrapped"),
+	})
+	assert.ErrorIs(t, err, pgx.ErrNoRows, "contact without public key can't be added")
+
+	contact.PublicKey = []byte("public")
+	users.SetPublicKey(ctx, contact)
+
+	added, errAdd := storage.AddContact(ctx, model.EmergencyContact{
+		OwnerID: *owner.ID, ContactLogin: "contact", WaitHours: 48, WrappedKey: []byte("wrapped"),
+	})
+	assert.NoError(t, errAdd)
+	assert.Equal(t, *contact.ID, added.ContactID)
+	assert.Equal(t, model.EmergencyIdle, added.Status)
+
+	grantors, errGrantors := storage.GetGrantors(ctx, contact)
+	assert.NoError(t, errGrantors)
+	assert.Len(t, grantors, 1)
+	assert.Equal(t, "owner", grantors[0].OwnerLogin)
+
+	requested, errRequest := storage.RequestAccess(
+		ctx, model.EmergencyContact{OwnerLogin: "owner", ContactID: *contact.ID},
+	)
+	assert.NoError(t, errRequest)
+	assert.Equal(t, model.EmergencyRequested, requested.Status)
+	assert.NotNil(t, requested.RequestedAt)
+
+	again, _ := storage.RequestAccess(ctx, model.EmergencyContact{OwnerLogin: "owner", ContactID: *contact.ID})
+	assert.Equal(t, requested.RequestedAt, again.RequestedAt, "repeated request doesn't restart waiting period")
+
+	grantor, errGrantor := storage.GetGrantor(ctx, model.EmergencyContact{OwnerLogin: "owner", ContactID: *contact.ID})
+	assert.NoError(t, errGrantor)
+	assert.Equal(t, []byte("wrapped"), grantor.WrappedKey)
+
+	denied, errDeny := storage.DenyAccess(ctx, model.EmergencyContact{OwnerID: *owner.ID, ContactLogin: "contact"})
+	assert.NoError(t, errDeny)
+	assert.Equal(t, model.EmergencyDenied, denied.Status)
+
+	_, errRemove := storage.RemoveContact(ctx, model.EmergencyContact{OwnerID: *owner.ID, ContactLogin: "contact"})
+	assert.NoError(t, errRemove)
+
+	contacts, _ := storage.GetContacts(ctx, owner)
+	assert.Empty(t, contacts)
+}
@@ -0,0 +1,570 @@
+package postgres
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+
+	"secretKeeper/internal/server/model"
+	"secretKeeper/internal/server/storage"
+	"secretKeeper/pkg/apperr"
+)
+
+var _ storage.OrganizationServerStorage = (*OrganizationPostgresStorage)(nil)
+
+type OrganizationPostgresStorage struct {
+	conn *pgx.Conn
+}
+
+const (
+	CreateOrganization = `insert into organizations (title) values ($1) returning id, created_at`
+	CreateMember       = `insert into organization_members (organization_id, user_id, role) values ($1, $2, $3)`
+	AddMemberByLogin   = `insert into organization_members (organization_id, user_id, role)
+						  select $1, id, $3 from users where login = $2 and public_key is not null
+						  returning user_id
+`
+	RemoveMemberByLogin = `delete from organization_members m
+						   using users u
+						   where m.user_id = u.id and m.organization_id = $1 and u.login = $2
+						   returning m.user_id, m.role
+`
+	DeleteMemberVaultKeys = `delete from vault_keys k
+							 using vaults v
+							 where k.vault_id = v.id and v.organization_id = $1 and k.user_id = $2
+`
+	MarkVaultsForRotation = `update vaults set rotation_required = true
+							 where organization_id = $1
+							 returning id, organization_id, title, key_version, rotation_required, created_at
+`
+	OrganizationsByUser = `select o.id, o.title, m.role, o.created_at
+						   from organizations o
+						   join organization_members m on m.organization_id = o.id
+						   where m.user_id = $1
+`
+	MembersByOrganization = `select m.organization_id, m.user_id, u.login, m.role, coalesce(u.public_key, ''::bytea)
+							 from organization_members m
+							 join users u on u.id = m.user_id
+							 where m.organization_id = $1
+`
+	MemberRole      = `select role from organization_members where organization_id = $1 and user_id = $2`
+	VaultMemberRole = `select m.role
+					   from vaults v
+					   join organization_members m on m.organization_id = v.organization_id
+					   where v.id = $1 and m.user_id = $2
+`
+	CreateVault    = `insert into vaults (organization_id, title) values ($1, $2) returning id, key_version, created_at`
+	CreateVaultKey = `insert into vault_keys (vault_id, user_id, key_version, wrapped_key) values ($1, $2, $3, $4)`
+	GetVault       = `select id, organization_id, title, key_version, rotation_required, created_at
+					  from vaults
+					  where id = $1
+`
+	LockVault            = `select key_version from vaults where id = $1 for update`
+	VaultsByOrganization = `select id, organization_id, title, key_version, rotation_required, created_at
+							from vaults
+							where organization_id = $1
+`
+	GetVaultKey = `select vault_id, user_id, key_version, wrapped_key
+				   from vault_keys
+				   where vault_id = $1 and user_id = $2
+`
+	DeleteVaultKeys = `delete from vault_keys where vault_id = $1`
+	UpdateVaultKey  = `update vaults set key_version = $2, rotation_required = false where id = $1`
+	VaultMemberIDs  = `select m.user_id
+					  from vaults v
+					  join organization_members m on m.organization_id = v.organization_id
+					  where v.id = $1
+`
+	VaultSecretIDs     = `select id from secrets where vault_id = $1`
+	UpdateVaultSecrets = `update secrets set content = $1 where id = $2 and vault_id = $3`
+)
+
+// NewOrganizationPostgresStorage - creates a postgres storage for organizations and their vaults.
+func NewOrganizationPostgresStorage(c *pgx.Conn) *OrganizationPostgresStorage {
+	return &OrganizationPostgresStorage{conn: c}
+}
+
+// CreateOrganization - stores provided model.Organization in database and makes provided model.User its owner.
+func (o *OrganizationPostgresStorage) CreateOrganization(
+	ctx context.Context, org model.Organization, owner model.User,
+) (model.Organization, error) {
+	ctxWithTimeOut, cancel := context.WithTimeout(ctx, time.Second*5)
+	defer cancel()
+
+	tx, err := o.conn.Begin(ctxWithTimeOut)
+	if err != nil {
+		return org, fmt.Errorf("organization creating err: %w", err)
+	}
+	defer tx.Rollback(ctxWithTimeOut)
+
+	if err = tx.QueryRow(ctxWithTimeOut, CreateOrganization, org.Title).Scan(&org.ID, &org.CreatedAt); err != nil {
+		return org, fmt.Errorf("organization insertion err: %w", err)
+	}
+
+	if _, err = tx.Exec(ctxWithTimeOut, CreateMember, org.ID, owner.ID, model.RoleOwner.String()); err != nil {
+		return org, fmt.Errorf("organization owner insertion err: %w", err)
+	}
+
+	if err = tx.Commit(ctxWithTimeOut); err != nil {
+		return org, fmt.Errorf("organization creating err: %w", err)
+	}
+
+	org.Role = model.RoleOwner
+
+	return org, nil
+}
+
+// GetOrganizations - returns a []model.Organization from database, where provided model.User is a member.
+func (o *OrganizationPostgresStorage) GetOrganizations(
+	ctx context.Context, user model.User,
+) ([]model.Organization, error) {
+	ctxWithTimeOut, cancel := context.WithTimeout(ctx, time.Second*5)
+	defer cancel()
+
+	var list []model.Organization
+
+	rows, err := o.conn.Query(ctxWithTimeOut, OrganizationsByUser, user.ID)
+	if err != nil {
+		return list, fmt.Errorf("getting list of organizations error: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			org  model.Organization
+			role string
+		)
+
+		if err = rows.Scan(&org.ID, &org.Title, &role, &org.CreatedAt); err != nil {
+			return list, fmt.Errorf("error in scanning gotten row: %w", err)
+		}
+
+		if org.Role, err = model.ParseRole(role); err != nil {
+			return list, err
+		}
+
+		list = append(list, org)
+	}
+
+	return list, nil
+}
+
+// AddMember - adds a user found by login from model.Member to organization.
+//
+// User must have a public key, otherwise vault keys can't be wrapped for them and pgx.ErrNoRows is returned.
+//
+// All vaults of organization are marked for key rotation and returned.
+func (o *OrganizationPostgresStorage) AddMember(
+	ctx context.Context, member model.Member,
+) (model.Member, []model.Vault, error) {
+	ctxWithTimeOut, cancel := context.WithTimeout(ctx, time.Second*5)
+	defer cancel()
+
+	tx, err := o.conn.Begin(ctxWithTimeOut)
+	if err != nil {
+		return member, nil, fmt.Errorf("member adding err: %w", err)
+	}
+	defer tx.Rollback(ctxWithTimeOut)
+
+	err = tx.QueryRow(ctxWithTimeOut, AddMemberByLogin, member.OrganizationID, member.Login, member.Role.String()).
+		Scan(&member.UserID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return member, nil, err
+		}
+
+		return member, nil, fmt.Errorf("member insertion err: %w", err)
+	}
+
+	vaults, errMark := markVaultsForRotation(ctxWithTimeOut, tx, member.OrganizationID)
+	if errMark != nil {
+		return member, nil, errMark
+	}
+
+	if err = tx.Commit(ctxWithTimeOut); err != nil {
+		return member, nil, fmt.Errorf("member adding err: %w", err)
+	}
+
+	return member, vaults, nil
+}
+
+// RemoveMember - removes a user found by login from model.Member from organization together with their vault keys.
+//
+// All vaults of organization are marked for key rotation and returned.
+func (o *OrganizationPostgresStorage) RemoveMember(
+	ctx context.Context, member model.Member,
+) (model.Member, []model.Vault, error) {
+	ctxWithTimeOut, cancel := context.WithTimeout(ctx, time.Second*5)
+	defer cancel()
+
+	tx, err := o.conn.Begin(ctxWithTimeOut)
+	if err != nil {
+		return member, nil, fmt.Errorf("member removing err: %w", err)
+	}
+	defer tx.Rollback(ctxWithTimeOut)
+
+	var role string
+	err = tx.QueryRow(ctxWithTimeOut, RemoveMemberByLogin, member.OrganizationID, member.Login).
+		Scan(&member.UserID, &role)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return member, nil, err
+		}
+
+		return member, nil, fmt.Errorf("member deletion err: %w", err)
+	}
+
+	if member.Role, err = model.ParseRole(role); err != nil {
+		return member, nil, err
+	}
+
+	if _, err = tx.Exec(ctxWithTimeOut, DeleteMemberVaultKeys, member.OrganizationID, member.UserID); err != nil {
+		return member, nil, fmt.Errorf("member vault keys deletion err: %w", err)
+	}
+
+	vaults, errMark := markVaultsForRotation(ctxWithTimeOut, tx, member.OrganizationID)
+	if errMark != nil {
+		return member, nil, errMark
+	}
+
+	if err = tx.Commit(ctxWithTimeOut); err != nil {
+		return member, nil, fmt.Errorf("member removing err: %w", err)
+	}
+
+	return member, vaults, nil
+}
+
+// GetMembers - returns a []model.Member of organization from database.
+func (o *OrganizationPostgresStorage) GetMembers(ctx context.Context, org model.Organization) ([]model.Member, error) {
+	ctxWithTimeOut, cancel := context.WithTimeout(ctx, time.Second*5)
+	defer cancel()
+
+	var list []model.Member
+
+	rows, err := o.conn.Query(ctxWithTimeOut, MembersByOrganization, org.ID)
+	if err != nil {
+		return list, fmt.Errorf("getting list of members error: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			member model.Member
+			role   string
+		)
+
+		if err = rows.Scan(
+			&member.OrganizationID, &member.UserID, &member.Login, &role, &member.PublicKey,
+		); err != nil {
+			return list, fmt.Errorf("error in scanning gotten row: %w", err)
+		}
+
+		if member.Role, err = model.ParseRole(role); err != nil {
+			return list, err
+		}
+
+		list = append(list, member)
+	}
+
+	return list, nil
+}
+
+// GetMemberRole - returns model.Role of provided model.User inside organization.
+//
+// If user is not a member of organization, then pgx.ErrNoRows is returned.
+func (o *OrganizationPostgresStorage) GetMemberRole(
+	ctx context.Context, org model.Organization, user model.User,
+) (model.Role, error) {
+	return o.getRole(ctx, MemberRole, org.ID, user)
+}
+
+// GetVaultRole - returns model.Role of provided model.User inside organization which owns the vault.
+//
+// If user is not a member of organization, then pgx.ErrNoRows is returned.
+func (o *OrganizationPostgresStorage) GetVaultRole(
+	ctx context.Context, vault model.Vault, user model.User,
+) (model.Role, error) {
+	return o.getRole(ctx, VaultMemberRole, vault.ID, user)
+}
+
+// CreateVault - stores provided model.Vault in database together with vault keys wrapped for each member.
+func (o *OrganizationPostgresStorage) CreateVault(
+	ctx context.Context, vault model.Vault, keys []model.VaultKey,
+) (model.Vault, error) {
+	ctxWithTimeOut, cancel := context.WithTimeout(ctx, time.Second*5)
+	defer cancel()
+
+	tx, err := o.conn.Begin(ctxWithTimeOut)
+	if err != nil {
+		return vault, fmt.Errorf("vault creating err: %w", err)
+	}
+	defer tx.Rollback(ctxWithTimeOut)
+
+	err = tx.QueryRow(ctxWithTimeOut, CreateVault, vault.OrganizationID, vault.Title).
+		Scan(&vault.ID, &vault.KeyVersion, &vault.CreatedAt)
+	if err != nil {
+		return vault, fmt.Errorf("vault insertion err: %w", err)
+	}
+
+	if err = insertVaultKeys(ctxWithTimeOut, tx, vault, keys); err != nil {
+		return vault, err
+	}
+
+	if err = tx.Commit(ctxWithTimeOut); err != nil {
+		return vault, fmt.Errorf("vault creating err: %w", err)
+	}
+
+	return vault, nil
+}
+
+// GetVault - returns rehydrated model.Vault from database.
+func (o *OrganizationPostgresStorage) GetVault(ctx context.Context, vault model.Vault) (model.Vault, error) {
+	ctxWithTimeOut, cancel := context.WithTimeout(ctx, time.Second*5)
+	defer cancel()
+
+	var v model.Vault
+	err := o.conn.QueryRow(ctxWithTimeOut, GetVault, vault.ID).Scan(
+		&v.ID, &v.OrganizationID, &v.Title, &v.KeyVersion, &v.RotationRequired, &v.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return vault, err
+		}
+
+		return vault, fmt.Errorf("error in getting vault from db: %w", err)
+	}
+
+	return v, nil
+}
+
+// GetVaults - returns a []model.Vault of organization from database.
+func (o *OrganizationPostgresStorage) GetVaults(ctx context.Context, org model.Organization) ([]model.Vault, error) {
+	ctxWithTimeOut, cancel := context.WithTimeout(ctx, time.Second*5)
+	defer cancel()
+
+	rows, err := o.conn.Query(ctxWithTimeOut, VaultsByOrganization, org.ID)
+	if err != nil {
+		return nil, fmt.Errorf("getting list of vaults error: %w", err)
+	}
+
+	return scanVaults(rows)
+}
+
+// GetVaultKey - returns model.VaultKey of user from database.
+func (o *OrganizationPostgresStorage) GetVaultKey(ctx context.Context, key model.VaultKey) (model.VaultKey, error) {
+	ctxWithTimeOut, cancel := context.WithTimeout(ctx, time.Second*5)
+	defer cancel()
+
+	var k model.VaultKey
+	err := o.conn.QueryRow(ctxWithTimeOut, GetVaultKey, key.VaultID, key.UserID).Scan(
+		&k.VaultID, &k.UserID, &k.KeyVersion, &k.WrappedKey,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return key, err
+		}
+
+		return key, fmt.Errorf("error in getting vault key from db: %w", err)
+	}
+
+	return k, nil
+}
+
+// RotateVaultKey - replaces vault keys of all members and content of all vault secrets in one transaction.
+//
+// KeyVersion of provided model.Vault must be the next one after the stored version, otherwise
+// apperr.ErrKeyVersionMismatch is returned.
+//
+// Provided keys must cover every member and provided secrets must cover every secret of the vault, otherwise
+// apperr.ErrRotationIncomplete is returned.
+func (o *OrganizationPostgresStorage) RotateVaultKey(
+	ctx context.Context, vault model.Vault, keys []model.VaultKey, secrets []model.Secret,
+) (model.Vault, error) {
+	ctxWithTimeOut, cancel := context.WithTimeout(ctx, time.Second*30)
+	defer cancel()
+
+	tx, err := o.conn.Begin(ctxWithTimeOut)
+	if err != nil {
+		return vault, fmt.Errorf("vault rotation err: %w", err)
+	}
+	defer tx.Rollback(ctxWithTimeOut)
+
+	var currentVersion int
+	if err = tx.QueryRow(ctxWithTimeOut, LockVault, vault.ID).Scan(&currentVersion); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return vault, err
+		}
+
+		return vault, fmt.Errorf("vault locking err: %w", err)
+	}
+
+	if vault.KeyVersion != currentVersion+1 {
+		return vault, apperr.ErrKeyVersionMismatch
+	}
+
+	// every member gets exactly one key and every secret is re-encrypted exactly once, a repeated ID would leave
+	// another secret under the old key
+	memberIDs, err := queryIDs[uuid.UUID](ctxWithTimeOut, tx, VaultMemberIDs, vault.ID)
+	if err != nil {
+		return vault, fmt.Errorf("vault members selecting err: %w", err)
+	}
+
+	secretIDs, err := queryIDs[int](ctxWithTimeOut, tx, VaultSecretIDs, vault.ID)
+	if err != nil {
+		return vault, fmt.Errorf("vault secrets selecting err: %w", err)
+	}
+
+	submittedMembers := make([]uuid.UUID, 0, len(keys))
+	for _, key := range keys {
+		submittedMembers = append(submittedMembers, key.UserID)
+	}
+
+	submittedSecrets := make([]int, 0, len(secrets))
+	for _, secret := range secrets {
+		submittedSecrets = append(submittedSecrets, secret.ID)
+	}
+
+	if !sameIDs(memberIDs, submittedMembers) || !sameIDs(secretIDs, submittedSecrets) {
+		return vault, apperr.ErrRotationIncomplete
+	}
+
+	if _, err = tx.Exec(ctxWithTimeOut, DeleteVaultKeys, vault.ID); err != nil {
+		return vault, fmt.Errorf("vault keys deletion err: %w", err)
+	}
+
+	if err = insertVaultKeys(ctxWithTimeOut, tx, vault, keys); err != nil {
+		return vault, err
+	}
+
+	for _, secret := range secrets {
+		tag, errUpdate := tx.Exec(
+			ctxWithTimeOut, UpdateVaultSecrets, hex.EncodeToString(secret.Content), secret.ID, vault.ID,
+		)
+		if errUpdate != nil {
+			return vault, fmt.Errorf("vault secret updating err: %w", errUpdate)
+		}
+
+		if tag.RowsAffected() == 0 {
+			return vault, apperr.ErrRotationIncomplete
+		}
+	}
+
+	if _, err = tx.Exec(ctxWithTimeOut, UpdateVaultKey, vault.ID, vault.KeyVersion); err != nil {
+		return vault, fmt.Errorf("vault key version updating err: %w", err)
+	}
+
+	if err = tx.Commit(ctxWithTimeOut); err != nil {
+		return vault, fmt.Errorf("vault rotation err: %w", err)
+	}
+
+	return o.GetVault(ctx, vault)
+}
+
+// getRole - runs provided role query for provided id and user.
+func (o *OrganizationPostgresStorage) getRole(
+	ctx context.Context, query string, id int, user model.User,
+) (model.Role, error) {
+	ctxWithTimeOut, cancel := context.WithTimeout(ctx, time.Second*5)
+	defer cancel()
+
+	var role string
+	if err := o.conn.QueryRow(ctxWithTimeOut, query, id, user.ID).Scan(&role); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.RoleNone, err
+		}
+
+		return model.RoleNone, fmt.Errorf("error in getting member role from db: %w", err)
+	}
+
+	return model.ParseRole(role)
+}
+
+// markVaultsForRotation - marks all vaults of organization as requiring key rotation and returns them.
+func markVaultsForRotation(ctx context.Context, tx pgx.Tx, organizationID int) ([]model.Vault, error) {
+	rows, err := tx.Query(ctx, MarkVaultsForRotation, organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("marking vaults for rotation err: %w", err)
+	}
+
+	return scanVaults(rows)
+}
+
+// insertVaultKeys - stores wrapped keys of the vault with key version of the vault.
+func insertVaultKeys(ctx context.Context, tx pgx.Tx, vault model.Vault, keys []model.VaultKey) error {
+	for _, key := range keys {
+		if _, err := tx.Exec(ctx, CreateVaultKey, vault.ID, key.UserID, vault.KeyVersion, key.WrappedKey); err != nil {
+			return fmt.Errorf("vault key insertion err: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// queryIDs - runs provided query for provided id and scans IDs it returns.
+func queryIDs[T any](ctx context.Context, tx pgx.Tx, query string, id int) ([]T, error) {
+	rows, err := tx.Query(ctx, query, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []T
+	for rows.Next() {
+		var value T
+		if errScan := rows.Scan(&value); errScan != nil {
+			return nil, fmt.Errorf("error in scanning gotten row: %w", errScan)
+		}
+
+		ids = append(ids, value)
+	}
+
+	return ids, rows.Err()
+}
+
+// sameIDs - reports whether submitted IDs are exactly stored ones, each of them once.
+func sameIDs[T comparable](stored, submitted []T) bool {
+	if len(stored) != len(submitted) {
+		return false
+	}
+
+	seen := make(map[T]bool, len(submitted))
+	for _, id := range submitted {
+		if seen[id] {
+			return false
+		}
+
+		seen[id] = true
+	}
+
+	for _, id := range stored {
+		if !seen[id] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// scanVaults - scans all rows to []model.Vault and closes rows.
+func scanVaults(rows pgx.Rows) ([]model.Vault, error) {
+	defer rows.Close()
+
+	var list []model.Vault
+	for rows.Next() {
+		var v model.Vault
+
+		if err := rows.Scan(
+			&v.ID, &v.OrganizationID, &v.Title, &v.KeyVersion, &v.RotationRequired, &v.CreatedAt,
+		); err != nil {
+			return list, fmt.Errorf("error in scanning gotten row: %w", err)
+		}
+
+		list = append(list, v)
+	}
+
+	return list, rows.Err()
+}
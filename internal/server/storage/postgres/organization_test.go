@@ -0,0 +1,145 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+
+	"secretKeeper/internal/server/model"
+	"secretKeeper/pkg/apperr"
+	"secretKeeper/pkg/utils"
+)
+
+func TestNewOrganizationPostgresStorage(t *testing.T) {
+	tests := []struct {
+		name string
+		want *OrganizationPostgresStorage
+	}{
+		{
+			name: "New Postgres Organization Storage can be created",
+			want: &OrganizationPostgresStorage{conn: &pgx.Conn{}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equalf(t, tt.want, NewOrganizationPostgresStorage(&pgx.Conn{}), "NewOrganizationPostgresStorage()")
+		})
+	}
+}
+
+func TestOrganizationPostgresStorage_Membership(t *testing.T) {
+	utils.RefreshTestDatabase()
+
+	ctx := context.Background()
+
+	con := utils.CreatePostgresTestConn()
+	defer con.Close(ctx)
+
+	users := UserPostgresStorage{conn: con}
+	storage := NewOrganizationPostgresStorage(con)
+
+	owner, _ := users.Create(ctx, model.User{Login: "owner", Password: "test"})
+	owner.PublicKey = []byte("owner key")
+	users.SetPublicKey(ctx, owner)
+
+	member, _ := users.Create(ctx, model.User{Login: "member", Password: "test"})
+
+	org, err := storage.CreateOrganization(ctx, model.Organization{Title: "team"}, owner)
+	assert.NoError(t, err)
+	assert.Equal(t, model.RoleOwner, org.Role)
+
+	role, errRole := storage.GetMemberRole(ctx, org, owner)
+	assert.NoError(t, errRole)
+	assert.Equal(t, model.RoleOwner, role)
+
+	_, _, err = storage.AddMember(ctx, model.Member{OrganizationID: org.ID, Login: "member", Role: model.RoleReader})
+	assert.ErrorIs(t, err, pgx.ErrNoRows, "user without public key can't be added")
+
+	member.PublicKey = []byte("member key")
+	users.SetPublicKey(ctx, member)
+
+	vault, errVault := storage.CreateVault(
+		ctx,
+		model.Vault{OrganizationID: org.ID, Title: "prod"},
+		[]model.VaultKey{{UserID: *owner.ID, WrappedKey: []byte("wrapped")}},
+	)
+	assert.NoError(t, errVault)
+	assert.Equal(t, 1, vault.KeyVersion)
+
+	_, vaults, errAdd := storage.AddMember(
+		ctx, model.Member{OrganizationID: org.ID, Login: "member", Role: model.RoleReader},
+	)
+	assert.NoError(t, errAdd)
+	assert.Len(t, vaults, 1)
+	assert.True(t, vaults[0].RotationRequired)
+
+	role, errRole = storage.GetVaultRole(ctx, vault, member)
+	assert.NoError(t, errRole)
+	assert.Equal(t, model.RoleReader, role)
+
+	members, errMembers := storage.GetMembers(ctx, org)
+	assert.NoError(t, errMembers)
+	assert.Len(t, members, 2)
+
+	_, errRotate := storage.RotateVaultKey(
+		ctx, model.Vault{ID: vault.ID, KeyVersion: 2}, []model.VaultKey{{UserID: *owner.ID}}, nil,
+	)
+	assert.ErrorIs(t, errRotate, apperr.ErrRotationIncomplete)
+
+	_, errRotate = storage.RotateVaultKey(
+		ctx,
+		model.Vault{ID: vault.ID, KeyVersion: 2},
+		[]model.VaultKey{{UserID: *owner.ID, WrappedKey: []byte("a")}, {UserID: *owner.ID, WrappedKey: []byte("a")}},
+		nil,
+	)
+	assert.ErrorIs(t, errRotate, apperr.ErrRotationIncomplete, "repeated member must not stand for another one")
+
+	_, errRotate = storage.RotateVaultKey(ctx, model.Vault{ID: vault.ID, KeyVersion: 3}, nil, nil)
+	assert.ErrorIs(t, errRotate, apperr.ErrKeyVersionMismatch)
+
+	rotated, errRotate := storage.RotateVaultKey(
+		ctx,
+		model.Vault{ID: vault.ID, KeyVersion: 2},
+		[]model.VaultKey{{UserID: *owner.ID, WrappedKey: []byte("a")}, {UserID: *member.ID, WrappedKey: []byte("b")}},
+		nil,
+	)
+	assert.NoError(t, errRotate)
+	assert.Equal(t, 2, rotated.KeyVersion)
+	assert.False(t, rotated.RotationRequired)
+
+	key, errKey := storage.GetVaultKey(ctx, model.VaultKey{VaultID: vault.ID, UserID: *member.ID})
+	assert.NoError(t, errKey)
+	assert.Equal(t, []byte("b"), key.WrappedKey)
+
+	secrets := NewSecretPostgresStorage(con)
+	now := time.Now()
+	first, _ := secrets.CreateSecret(ctx, model.Secret{
+		UserID: *owner.ID, VaultID: vault.ID, TypeID: 2, Title: "first", CreatedAt: now, UpdatedAt: now,
+	})
+	second, _ := secrets.CreateSecret(ctx, model.Secret{
+		UserID: *owner.ID, VaultID: vault.ID, TypeID: 2, Title: "second", CreatedAt: now, UpdatedAt: now,
+	})
+	keys := []model.VaultKey{{UserID: *owner.ID, WrappedKey: []byte("c")}, {UserID: *member.ID, WrappedKey: []byte("d")}}
+
+	_, errRotate = storage.RotateVaultKey(
+		ctx, model.Vault{ID: vault.ID, KeyVersion: 3}, keys,
+		[]model.Secret{{ID: first.ID, Content: []byte("x")}, {ID: first.ID, Content: []byte("x")}},
+	)
+	assert.ErrorIs(t, errRotate, apperr.ErrRotationIncomplete, "repeated secret must not stand for another one")
+
+	rotated, errRotate = storage.RotateVaultKey(
+		ctx, model.Vault{ID: vault.ID, KeyVersion: 3}, keys,
+		[]model.Secret{{ID: first.ID, Content: []byte("x")}, {ID: second.ID, Content: []byte("y")}},
+	)
+	assert.NoError(t, errRotate)
+	assert.Equal(t, 3, rotated.KeyVersion)
+
+	_, _, errRemove := storage.RemoveMember(ctx, model.Member{OrganizationID: org.ID, Login: "member"})
+	assert.NoError(t, errRemove)
+
+	_, errKey = storage.GetVaultKey(ctx, model.VaultKey{VaultID: vault.ID, UserID: *member.ID})
+	assert.ErrorIs(t, errKey, pgx.ErrNoRows)
+}
@@ -22,26 +22,88 @@ type SecretPostgresStorage struct {
 
 const (
 	CreateSecrete = `
-					insert into secrets (user_id, type_id, title, content, created_at, updated_at, is_deleted) 
-					values ($1,$2,$3,$4,$5,$6,$7) 
+					insert into secrets (user_id, type_id, title, content, created_at, updated_at, is_deleted, vault_id,
+					                     expires_at, rotate_every_days, auto_expire) 
+					values ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11) 
 					returning id
 `
-	GetSecret = `select id, user_id, type_id, title, content, created_at, updated_at, is_deleted
+	GetSecret = `select id, user_id, coalesce(vault_id, 0), type_id, title, content, created_at, updated_at, is_deleted,
+				 expires_at, coalesce(rotate_every_days, 0), auto_expire
 				 from secrets 
-				 where id = $1 and user_id = $2
+				 where id = $1 and ` + secretOwnerCondition + `
 `
 	DeleteSecret = `update secrets
-					set is_deleted = $3
-					where id = $1 and user_id = $2 returning id`
+					set is_deleted = $4
+					where id = $1 and ` + secretOwnerCondition + ` returning id`
 	UpdateSecret = `update secrets 
 					set title = $1, content = $2, updated_at = $3
-					where id = $4 and user_id = $5
-					returning type_id, updated_at, 
+					where id = $4 and ((vault_id is null and user_id = $5 and $6::bigint is null) or vault_id = $6)
+					returning type_id, updated_at
 `
-	SecretsByType = `select id, user_id, type_id, title, content, created_at, updated_at, is_deleted
+	SecretsByType = `select id, user_id, coalesce(vault_id, 0), type_id, title, content, created_at, updated_at, is_deleted,
+					 expires_at, coalesce(rotate_every_days, 0), auto_expire
 					 from secrets
-					 where type_id = $1 and user_id = $2 
+					 where type_id = $1 and ` + secretOwnerCondition + ` 
 `
+	SetSecretExpiry = `update secrets
+					   set expires_at = $4, rotate_every_days = $5, auto_expire = $6
+					   where id = $1 and not is_deleted and ` + secretOwnerCondition + `
+`
+	DeleteSecretEvents = `delete from secret_events where secret_id = $1`
+	ExpireSecrets      = `
+					with expired as (
+						update secrets
+						set is_deleted = true
+						where auto_expire and not is_deleted and expires_at <= $1
+						returning id, expires_at
+					)
+					insert into secret_events (secret_id, kind, due_at)
+					select id, 'expired', expires_at from expired
+					on conflict (secret_id, kind) do update set due_at = excluded.due_at
+`
+	DeleteResolvedEvents = `
+					delete from secret_events e
+					using secrets s
+					where e.secret_id = s.id and (
+						(e.kind = 'expiring' and (s.is_deleted or s.expires_at is null or s.expires_at > $2))
+						or (e.kind = 'rotation' and (s.is_deleted or coalesce(s.rotate_every_days, 0) = 0
+							or s.updated_at + make_interval(days => s.rotate_every_days) > $2))
+						or (e.kind = 'expired' and e.due_at < $1::timestamptz - ($2::timestamptz - $1::timestamptz))
+					)
+`
+	UpsertExpiringEvents = `
+					insert into secret_events (secret_id, kind, due_at)
+					select id, 'expiring', expires_at
+					from secrets
+					where not is_deleted and expires_at <= $1
+					on conflict (secret_id, kind) do update set due_at = excluded.due_at
+`
+	UpsertRotationEvents = `
+					insert into secret_events (secret_id, kind, due_at)
+					select id, 'rotation', updated_at + make_interval(days => rotate_every_days)
+					from secrets
+					where not is_deleted and rotate_every_days > 0
+					  and updated_at + make_interval(days => rotate_every_days) <= $1
+					on conflict (secret_id, kind) do update set due_at = excluded.due_at
+`
+	DueEventsOfUser = `
+					select e.secret_id, coalesce(s.vault_id, 0), s.title, e.kind, e.due_at
+					from secret_events e
+					join secrets s on s.id = e.secret_id
+					where (e.kind = 'expired' or not s.is_deleted) and (
+						(s.vault_id is null and s.user_id = $1)
+						or s.vault_id in (
+							select v.id
+							from vaults v
+							join organization_members m on m.organization_id = v.organization_id
+							where m.user_id = $1
+						)
+					)
+					order by e.due_at
+`
+
+	// secretOwnerCondition - matches personal secrets of user $2 when vault $3 is NULL, otherwise secrets of vault $3.
+	secretOwnerCondition = `((vault_id is null and user_id = $2 and $3::bigint is null) or vault_id = $3)`
 )
 
 func NewSecretPostgresStorage(c *pgx.Conn) *SecretPostgresStorage {
@@ -56,7 +118,8 @@ func (s *SecretPostgresStorage) CreateSecret(ctx context.Context, secret model.S
 	defer cancel()
 
 	err := s.conn.QueryRow(ctxWithTimeOut, CreateSecrete, secret.UserID, secret.TypeID, secret.Title,
-		hex.EncodeToString(secret.Content), secret.CreatedAt, secret.UpdatedAt, false,
+		hex.EncodeToString(secret.Content), secret.CreatedAt, secret.UpdatedAt, false, nullID(secret.VaultID),
+		secret.ExpiresAt, nullID(secret.RotateEveryDays), secret.AutoExpire,
 	).Scan(&secret.ID)
 	if err != nil {
 		return secret, fmt.Errorf("error in storing secret in db: %w", err)
@@ -65,16 +128,61 @@ func (s *SecretPostgresStorage) CreateSecret(ctx context.Context, secret model.S
 	return secret, nil
 }
 
+// CreateSecrets - stores provided []model.Secret in database in one transaction, inserts are sent as a single batch.
+//
+// Values of key Content of every model.Secret are being hex encoded.
+func (s *SecretPostgresStorage) CreateSecrets(ctx context.Context, secrets []model.Secret) ([]model.Secret, error) {
+	ctxWithTimeOut, cancel := context.WithTimeout(ctx, time.Second*30)
+	defer cancel()
+
+	tx, err := s.conn.Begin(ctxWithTimeOut)
+	if err != nil {
+		return nil, fmt.Errorf("error in storing secrets in db: %w", err)
+	}
+	defer tx.Rollback(ctxWithTimeOut)
+
+	batch := &pgx.Batch{}
+	for _, secret := range secrets {
+		batch.Queue(CreateSecrete, secret.UserID, secret.TypeID, secret.Title,
+			hex.EncodeToString(secret.Content), secret.CreatedAt, secret.UpdatedAt, false, nullID(secret.VaultID),
+			secret.ExpiresAt, nullID(secret.RotateEveryDays), secret.AutoExpire,
+		)
+	}
+
+	results := tx.SendBatch(ctxWithTimeOut, batch)
+
+	created := make([]model.Secret, 0, len(secrets))
+	for _, secret := range secrets {
+		if errScan := results.QueryRow().Scan(&secret.ID); errScan != nil {
+			results.Close()
+
+			return nil, fmt.Errorf("error in storing secret %q in db: %w", secret.Title, errScan)
+		}
+
+		created = append(created, secret)
+	}
+
+	if errClose := results.Close(); errClose != nil {
+		return nil, fmt.Errorf("error in storing secrets in db: %w", errClose)
+	}
+
+	if err = tx.Commit(ctxWithTimeOut); err != nil {
+		return nil, fmt.Errorf("error in storing secrets in db: %w", err)
+	}
+
+	return created, nil
+}
+
 // GetSecret - return rehydrated model.Secret from database.
 //
-// Searches by user_id and id from provided model.Secret
+// Searches by user_id and id from provided model.Secret, or by vault_id and id if secret belongs to a vault.
 func (s *SecretPostgresStorage) GetSecret(ctx context.Context, secret model.Secret) (model.Secret, error) {
 	ctxWithTimeOut, cancel := context.WithTimeout(ctx, time.Second*5)
 	defer cancel()
 	var sc model.Secret
-	err := s.conn.QueryRow(ctxWithTimeOut, GetSecret, secret.ID, secret.UserID).Scan(
-		&sc.ID, &sc.UserID, &sc.TypeID, &sc.Title, &sc.Content,
-		&sc.CreatedAt, &sc.UpdatedAt, &sc.IsDelited,
+	err := s.conn.QueryRow(ctxWithTimeOut, GetSecret, secret.ID, secret.UserID, nullID(secret.VaultID)).Scan(
+		&sc.ID, &sc.UserID, &sc.VaultID, &sc.TypeID, &sc.Title, &sc.Content,
+		&sc.CreatedAt, &sc.UpdatedAt, &sc.IsDelited, &sc.ExpiresAt, &sc.RotateEveryDays, &sc.AutoExpire,
 	)
 	if err != nil {
 		if !errors.Is(err, pgx.ErrNoRows) {
@@ -100,7 +208,9 @@ func (s *SecretPostgresStorage) DeleteSecret(ctx context.Context, secret model.S
 	defer cancel()
 
 	var deletedSecretId *int
-	err := s.conn.QueryRow(ctxWithTimeOut, DeleteSecret, secret.ID, secret.UserID, true).Scan(&deletedSecretId)
+	err := s.conn.QueryRow(
+		ctxWithTimeOut, DeleteSecret, secret.ID, secret.UserID, nullID(secret.VaultID), true,
+	).Scan(&deletedSecretId)
 	if err != nil {
 		if !errors.Is(err, pgx.ErrNoRows) {
 			return secret, fmt.Errorf("secret deletion err: %w", err)
@@ -124,7 +234,7 @@ func (s *SecretPostgresStorage) EditSecret(ctx context.Context, secret model.Sec
 	}
 
 	err := s.conn.QueryRow(ctxWithTimeOut, UpdateSecret, secret.Title, hex.EncodeToString(secret.Content),
-		time.Now(), secret.ID, secret.UserID,
+		time.Now(), secret.ID, secret.UserID, nullID(secret.VaultID),
 	).Scan(&secret.TypeID, &secret.UpdatedAt)
 	if err != nil {
 		if !errors.Is(err, pgx.ErrNoRows) {
@@ -138,16 +248,16 @@ func (s *SecretPostgresStorage) EditSecret(ctx context.Context, secret model.Sec
 }
 
 // GetListOfSecretByType - returns a []model.Secret from database by provided type_id via model.SecretType and user_id
-// via model.User.
+// via model.User. If model.Vault is provided, then secrets of that vault are returned instead of personal ones.
 func (s *SecretPostgresStorage) GetListOfSecretByType(
-	ctx context.Context, secretType model.SecretType, user model.User,
+	ctx context.Context, secretType model.SecretType, user model.User, vault model.Vault,
 ) ([]model.Secret, error) {
 	ctxWithTimeOut, cancel := context.WithTimeout(ctx, time.Second*5)
 	defer cancel()
 
 	var secrets []model.Secret
 
-	rows, err := s.conn.Query(ctxWithTimeOut, SecretsByType, secretType.ID, user.ID)
+	rows, err := s.conn.Query(ctxWithTimeOut, SecretsByType, secretType.ID, user.ID, nullID(vault.ID))
 	if err != nil {
 		return secrets, fmt.Errorf("getting list of secrets error: %w", err)
 	}
@@ -159,12 +269,16 @@ func (s *SecretPostgresStorage) GetListOfSecretByType(
 		if scanErr := rows.Scan(
 			&secret.ID,
 			&secret.UserID,
+			&secret.VaultID,
 			&secret.TypeID,
 			&secret.Title,
 			&secret.Content,
 			&secret.CreatedAt,
 			&secret.UpdatedAt,
 			&secret.IsDelited,
+			&secret.ExpiresAt,
+			&secret.RotateEveryDays,
+			&secret.AutoExpire,
 		); scanErr != nil {
 			return secrets, fmt.Errorf("error in scanning gotten row: %w", scanErr)
 		}
@@ -180,3 +294,104 @@ func (s *SecretPostgresStorage) GetListOfSecretByType(
 
 	return secrets, nil
 }
+
+// SetExpiry - updates expiry and rotation period of a model.Secret in database.
+//
+// Reminders about the secret are dropped, so they are emitted again according to new values on next refresh.
+func (s *SecretPostgresStorage) SetExpiry(ctx context.Context, secret model.Secret) (model.Secret, error) {
+	ctxWithTimeOut, cancel := context.WithTimeout(ctx, time.Second*5)
+	defer cancel()
+
+	tag, err := s.conn.Exec(ctxWithTimeOut, SetSecretExpiry, secret.ID, secret.UserID, nullID(secret.VaultID),
+		secret.ExpiresAt, nullID(secret.RotateEveryDays), secret.AutoExpire,
+	)
+	if err != nil {
+		return secret, fmt.Errorf("secret expiry updating error: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return secret, pgx.ErrNoRows
+	}
+
+	if _, err = s.conn.Exec(ctxWithTimeOut, DeleteSecretEvents, secret.ID); err != nil {
+		return secret, fmt.Errorf("error in deleting secret events: %w", err)
+	}
+
+	return secret, nil
+}
+
+// RefreshDueEvents - moves secrets with passed expiry and enabled auto expire to trash, then renews reminders about
+// secrets which expire or need rotation until horizon and drops reminders which are resolved.
+//
+// Everything happens in one transaction and the number of secrets moved to trash is returned.
+func (s *SecretPostgresStorage) RefreshDueEvents(ctx context.Context, now time.Time, horizon time.Time) (int, error) {
+	ctxWithTimeOut, cancel := context.WithTimeout(ctx, time.Second*30)
+	defer cancel()
+
+	tx, err := s.conn.Begin(ctxWithTimeOut)
+	if err != nil {
+		return 0, fmt.Errorf("error in starting transaction: %w", err)
+	}
+	defer tx.Rollback(ctxWithTimeOut)
+
+	tag, errExpire := tx.Exec(ctxWithTimeOut, ExpireSecrets, now)
+	if errExpire != nil {
+		return 0, fmt.Errorf("error in expiring secrets: %w", errExpire)
+	}
+
+	if _, err = tx.Exec(ctxWithTimeOut, DeleteResolvedEvents, now, horizon); err != nil {
+		return 0, fmt.Errorf("error in deleting resolved events: %w", err)
+	}
+
+	if _, err = tx.Exec(ctxWithTimeOut, UpsertExpiringEvents, horizon); err != nil {
+		return 0, fmt.Errorf("error in storing expiring events: %w", err)
+	}
+
+	if _, err = tx.Exec(ctxWithTimeOut, UpsertRotationEvents, horizon); err != nil {
+		return 0, fmt.Errorf("error in storing rotation events: %w", err)
+	}
+
+	if err = tx.Commit(ctxWithTimeOut); err != nil {
+		return 0, fmt.Errorf("error in committing transaction: %w", err)
+	}
+
+	return int(tag.RowsAffected()), nil
+}
+
+// GetDueEvents - returns []model.DueEvent of personal secrets of model.User and of secrets in vaults of organizations
+// the user is member of, ordered by due time.
+func (s *SecretPostgresStorage) GetDueEvents(ctx context.Context, user model.User) ([]model.DueEvent, error) {
+	ctxWithTimeOut, cancel := context.WithTimeout(ctx, time.Second*5)
+	defer cancel()
+
+	var events []model.DueEvent
+
+	rows, err := s.conn.Query(ctxWithTimeOut, DueEventsOfUser, user.ID)
+	if err != nil {
+		return events, fmt.Errorf("getting due events error: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var event model.DueEvent
+
+		if scanErr := rows.Scan(
+			&event.SecretID, &event.VaultID, &event.Title, &event.Kind, &event.DueAt,
+		); scanErr != nil {
+			return events, fmt.Errorf("error in scanning gotten row: %w", scanErr)
+		}
+
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
+
+// nullID - converts zero id to NULL, so it can be used in optional foreign key columns.
+func nullID(id int) *int {
+	if id == 0 {
+		return nil
+	}
+
+	return &id
+}
@@ -102,6 +102,85 @@ func TestSecretPostgresStorage_CreateSecret(t *testing.T) {
 	}
 }
 
+func TestSecretPostgresStorage_CreateSecrets(t *testing.T) {
+	ctx := context.Background()
+
+	con := utils.CreatePostgresTestConn()
+	defer con.Close(ctx)
+
+	uid := uuid.New()
+
+	type args struct {
+		ctx     context.Context
+		secrets []model.Secret
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []model.Secret
+		wantErr assert.ErrorAssertionFunc
+		do      func()
+	}{
+		{
+			name: "Secrets can be created at once",
+			args: args{
+				ctx: ctx,
+				secrets: []model.Secret{
+					{UserID: uid, TypeID: 1, Title: "First"},
+					{UserID: uid, TypeID: 2, Title: "Second"},
+				},
+			},
+			want: []model.Secret{
+				{ID: 1, UserID: uid, TypeID: 1, Title: "First"},
+				{ID: 2, UserID: uid, TypeID: 2, Title: "Second"},
+			},
+			wantErr: assert.NoError,
+			do: func() {
+				utils.RefreshTestDatabase()
+
+				row, _ := con.Query(
+					ctx, "insert into users (id, login, password) values ($1,$2,$3)", uid, "test", "test",
+				)
+				defer row.Close()
+			},
+		},
+		{
+			name: "None of secrets is created if one fails",
+			args: args{
+				ctx: ctx,
+				secrets: []model.Secret{
+					{UserID: uid, TypeID: 1, Title: "First"},
+					{UserID: uid, TypeID: 100, Title: "Unknown type"},
+				},
+			},
+			want:    nil,
+			wantErr: assert.Error,
+			do: func() {
+				utils.RefreshTestDatabase()
+
+				row, _ := con.Query(
+					ctx, "insert into users (id, login, password) values ($1,$2,$3)", uid, "test", "test",
+				)
+				defer row.Close()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.do()
+
+			s := &SecretPostgresStorage{
+				conn: con,
+			}
+
+			got, err := s.CreateSecrets(tt.args.ctx, tt.args.secrets)
+			tt.wantErr(t, err, fmt.Sprintf("CreateSecrets(%v, %v)", tt.args.ctx, tt.args.secrets))
+
+			assert.Equalf(t, tt.want, got, "CreateSecrets(%v, %v)", tt.args.ctx, tt.args.secrets)
+		})
+	}
+}
+
 func TestSecretPostgresStorage_GetSecret(t *testing.T) {
 	ctx := context.Background()
 
@@ -335,6 +414,7 @@ func TestSecretPostgresStorage_GetListOfSecretByType(t *testing.T) {
 		ctx        context.Context
 		secretType model.SecretType
 		user       model.User
+		vault      model.Vault
 	}
 	tests := []struct {
 		name    string
@@ -373,6 +453,44 @@ func TestSecretPostgresStorage_GetListOfSecretByType(t *testing.T) {
 				r3.Close()
 			},
 		},
+		{
+			name: "List of vault secrets doesn't include personal secrets",
+			args: args{
+				ctx:        ctx,
+				secretType: model.SecretType{ID: 1},
+				user:       model.User{ID: &uid},
+				vault:      model.Vault{ID: 1},
+			},
+			wantErr: assert.NoError,
+			wantLen: 1,
+			do: func() {
+				utils.RefreshTestDatabase()
+
+				row, _ := con.Query(
+					ctx, "insert into users (id, login, password) values ($1,$2,$3)", uid, "test", "test",
+				)
+				row.Close()
+
+				r2, _ := con.Query(ctx, "insert into organizations (title) values ($1)", "test")
+				r2.Close()
+
+				r3, _ := con.Query(ctx, "insert into vaults (organization_id, title) values ($1, $2)", 1, "test")
+				r3.Close()
+
+				r4, _ := con.Query(
+					ctx, "insert into secrets (user_id, title, content, type_id, is_deleted) values ($1,$2,$3,$4,$5)",
+					uid, "personal", hex.EncodeToString([]byte{10, 20}), 1, false,
+				)
+				r4.Close()
+
+				r5, _ := con.Query(
+					ctx,
+					"insert into secrets (user_id, title, content, type_id, is_deleted, vault_id) values ($1,$2,$3,$4,$5,$6)",
+					uid, "shared", hex.EncodeToString([]byte{1, 1}), 1, false, 1,
+				)
+				r5.Close()
+			},
+		},
 		{
 			name: "List of secrets will return error if no rows is found",
 			args: args{
@@ -392,7 +510,7 @@ func TestSecretPostgresStorage_GetListOfSecretByType(t *testing.T) {
 			tt.do()
 
 			s := &SecretPostgresStorage{conn: con}
-			got, err := s.GetListOfSecretByType(tt.args.ctx, tt.args.secretType, tt.args.user)
+			got, err := s.GetListOfSecretByType(tt.args.ctx, tt.args.secretType, tt.args.user, tt.args.vault)
 
 			tt.wantErr(t, err, fmt.Sprintf("GetListOfSecretByType(%v, %v, %v)", tt.args.ctx, tt.args.secretType, tt.args.user))
 
@@ -400,3 +518,66 @@ func TestSecretPostgresStorage_GetListOfSecretByType(t *testing.T) {
 		})
 	}
 }
+
+func TestSecretPostgresStorage_DueEvents(t *testing.T) {
+	utils.RefreshTestDatabase()
+
+	ctx := context.Background()
+
+	con := utils.CreatePostgresTestConn()
+	defer con.Close(ctx)
+
+	users := UserPostgresStorage{conn: con}
+	s := NewSecretPostgresStorage(con)
+
+	owner, _ := users.Create(ctx, model.User{Login: "owner", Password: "test"})
+
+	now := time.Now()
+	soon := now.Add(48 * time.Hour)
+	passed := now.Add(-time.Hour)
+
+	expiring, _ := s.CreateSecret(ctx, model.Secret{
+		UserID: *owner.ID, TypeID: 4, Title: "card", CreatedAt: now, UpdatedAt: now, ExpiresAt: &soon,
+	})
+	stale, _ := s.CreateSecret(ctx, model.Secret{
+		UserID: *owner.ID, TypeID: 1, Title: "password", CreatedAt: now, UpdatedAt: now.AddDate(0, 0, -100),
+		RotateEveryDays: 90,
+	})
+	oneTime, _ := s.CreateSecret(ctx, model.Secret{
+		UserID: *owner.ID, TypeID: 2, Title: "share", CreatedAt: now, UpdatedAt: now, ExpiresAt: &passed,
+		AutoExpire: true,
+	})
+	_, _ = s.CreateSecret(ctx, model.Secret{UserID: *owner.ID, TypeID: 2, Title: "note", CreatedAt: now, UpdatedAt: now})
+
+	expired, err := s.RefreshDueEvents(ctx, now, now.AddDate(0, 0, 7))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, expired)
+
+	trashed, _ := s.GetSecret(ctx, oneTime)
+	assert.True(t, trashed.IsDelited)
+
+	events, errEvents := s.GetDueEvents(ctx, owner)
+	assert.NoError(t, errEvents)
+
+	kinds := map[int]model.DueEventKind{}
+	for _, event := range events {
+		kinds[event.SecretID] = event.Kind
+	}
+
+	assert.Len(t, events, 3)
+	assert.Equal(t, model.DueExpiring, kinds[expiring.ID])
+	assert.Equal(t, model.DueRotation, kinds[stale.ID])
+	assert.Equal(t, model.DueExpired, kinds[oneTime.ID])
+
+	_, err = s.SetExpiry(ctx, model.Secret{ID: expiring.ID, UserID: *owner.ID})
+	assert.NoError(t, err)
+
+	_, err = s.RefreshDueEvents(ctx, now, now.AddDate(0, 0, 7))
+	assert.NoError(t, err)
+
+	events, _ = s.GetDueEvents(ctx, owner)
+	assert.Len(t, events, 2, "reminder is dropped once expiry is removed")
+
+	_, err = s.SetExpiry(ctx, model.Secret{ID: oneTime.ID, UserID: *owner.ID})
+	assert.ErrorIs(t, err, pgx.ErrNoRows, "expiry of trashed secret can't be changed")
+}
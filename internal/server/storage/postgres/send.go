@@ -0,0 +1,95 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+
+	"secretKeeper/internal/server/model"
+	"secretKeeper/internal/server/storage"
+)
+
+var _ storage.SendServerStorage = (*SendPostgresStorage)(nil)
+
+type SendPostgresStorage struct {
+	conn *pgx.Conn
+}
+
+const (
+	CreateSend = `insert into sends (user_id, content, views_left, expires_at)
+				  values ($1, $2, $3, $4)
+				  returning id, created_at
+`
+	OpenSend = `update sends
+				set views_left = views_left - 1
+				where id = $1 and views_left > 0 and expires_at > $2
+				returning user_id, content, views_left, expires_at, created_at
+`
+	DeleteUsedSend = `delete from sends where id = $1 and views_left <= 0`
+	PurgeSends     = `delete from sends where expires_at <= $1 or views_left <= 0`
+)
+
+// NewSendPostgresStorage - creates new SendPostgresStorage.
+func NewSendPostgresStorage(c *pgx.Conn) *SendPostgresStorage {
+	return &SendPostgresStorage{conn: c}
+}
+
+// CreateSend - stores model.Send in database, id is generated by database.
+func (s *SendPostgresStorage) CreateSend(ctx context.Context, send model.Send) (model.Send, error) {
+	ctxWithTimeOut, cancel := context.WithTimeout(ctx, time.Second*5)
+	defer cancel()
+
+	err := s.conn.QueryRow(ctxWithTimeOut, CreateSend, send.UserID, send.Content, send.ViewsLeft, send.ExpiresAt).
+		Scan(&send.ID, &send.CreatedAt)
+	if err != nil {
+		return send, fmt.Errorf("error in storing send in db: %w", err)
+	}
+
+	return send, nil
+}
+
+// OpenSend - consumes one view of model.Send found by id and returns its content.
+//
+// Send which is expired, used up or doesn't exist results in pgx.ErrNoRows. Send is deleted in the same transaction
+// once its last view is consumed.
+func (s *SendPostgresStorage) OpenSend(ctx context.Context, send model.Send, now time.Time) (model.Send, error) {
+	ctxWithTimeOut, cancel := context.WithTimeout(ctx, time.Second*5)
+	defer cancel()
+
+	tx, err := s.conn.Begin(ctxWithTimeOut)
+	if err != nil {
+		return send, fmt.Errorf("error in starting transaction: %w", err)
+	}
+	defer tx.Rollback(ctxWithTimeOut)
+
+	err = tx.QueryRow(ctxWithTimeOut, OpenSend, send.ID, now).
+		Scan(&send.UserID, &send.Content, &send.ViewsLeft, &send.ExpiresAt, &send.CreatedAt)
+	if err != nil {
+		return send, err
+	}
+
+	if _, err = tx.Exec(ctxWithTimeOut, DeleteUsedSend, send.ID); err != nil {
+		return send, fmt.Errorf("error in deleting used send: %w", err)
+	}
+
+	if err = tx.Commit(ctxWithTimeOut); err != nil {
+		return send, fmt.Errorf("error in committing transaction: %w", err)
+	}
+
+	return send, nil
+}
+
+// PurgeSends - deletes expired and used up sends from database.
+func (s *SendPostgresStorage) PurgeSends(ctx context.Context, now time.Time) (int, error) {
+	ctxWithTimeOut, cancel := context.WithTimeout(ctx, time.Second*30)
+	defer cancel()
+
+	tag, err := s.conn.Exec(ctxWithTimeOut, PurgeSends, now)
+	if err != nil {
+		return 0, fmt.Errorf("error in purging sends: %w", err)
+	}
+
+	return int(tag.RowsAffected()), nil
+}
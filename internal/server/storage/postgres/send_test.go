@@ -0,0 +1,76 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+
+	"secretKeeper/internal/server/model"
+	"secretKeeper/pkg/utils"
+)
+
+func TestNewSendPostgresStorage(t *testing.T) {
+	tests := []struct {
+		name string
+		want *SendPostgresStorage
+	}{
+		{
+			name: "New Postgres Send Storage can be created",
+			want: &SendPostgresStorage{conn: &pgx.Conn{}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equalf(t, tt.want, NewSendPostgresStorage(&pgx.Conn{}), "NewSendPostgresStorage()")
+		})
+	}
+}
+
+func TestSendPostgresStorage_OpenSend(t *testing.T) {
+	utils.RefreshTestDatabase()
+
+	ctx := context.Background()
+
+	con := utils.CreatePostgresTestConn()
+	defer con.Close(ctx)
+
+	users := UserPostgresStorage{conn: con}
+	storage := NewSendPostgresStorage(con)
+
+	sender, _ := users.Create(ctx, model.User{Login: "sender", Password: "test"})
+
+	now := time.Now()
+
+	send, err := storage.CreateSend(ctx, model.Send{
+		UserID: *sender.ID, Content: []byte("encrypted"), ViewsLeft: 2, ExpiresAt: now.Add(time.Hour),
+	})
+	assert.NoError(t, err)
+	assert.NotEqual(t, uuid.Nil, send.ID)
+
+	opened, errOpen := storage.OpenSend(ctx, model.Send{ID: send.ID}, now)
+	assert.NoError(t, errOpen)
+	assert.Equal(t, []byte("encrypted"), opened.Content)
+	assert.Equal(t, 1, opened.ViewsLeft)
+
+	opened, errOpen = storage.OpenSend(ctx, model.Send{ID: send.ID}, now)
+	assert.NoError(t, errOpen)
+	assert.Equal(t, 0, opened.ViewsLeft)
+
+	_, errOpen = storage.OpenSend(ctx, model.Send{ID: send.ID}, now)
+	assert.ErrorIs(t, errOpen, pgx.ErrNoRows, "used up send can't be opened")
+
+	expiring, _ := storage.CreateSend(ctx, model.Send{
+		UserID: *sender.ID, Content: []byte("encrypted"), ViewsLeft: 1, ExpiresAt: now.Add(time.Minute),
+	})
+
+	_, errOpen = storage.OpenSend(ctx, model.Send{ID: expiring.ID}, now.Add(time.Hour))
+	assert.ErrorIs(t, errOpen, pgx.ErrNoRows, "expired send can't be opened")
+
+	purged, errPurge := storage.PurgeSends(ctx, now.Add(time.Hour))
+	assert.NoError(t, errPurge)
+	assert.Equal(t, 1, purged)
+}
@@ -25,6 +25,18 @@ const (
 	CreateUser     = `INSERT INTO users (login, password) VALUES ($1, crypt($2, gen_salt('bf'))) returning id`
 	GetUserId      = `SELECT id FROM users WHERE login = $1 AND password = crypt($2, password)`
 	DeleteUserById = `DELETE from users where id = $1 returning login`
+	SetPublicKey   = `UPDATE users SET public_key = $2 WHERE id = $1 returning login`
+	SetPersonalKey = `UPDATE users SET personal_key = $2 WHERE id = $1 returning login`
+	GetPersonalKey = `SELECT login, coalesce(personal_key, ''::bytea) FROM users WHERE id = $1`
+	GetUserByLogin = `SELECT id, coalesce(public_key, ''::bytea) FROM users WHERE login = $1`
+	SetRecovery    = `UPDATE users SET recovery_key = $2, recovery_token_hash = $3 WHERE id = $1 returning login`
+	GetRecovery    = `SELECT id, coalesce(recovery_key, ''::bytea), coalesce(recovery_token_hash, ''::bytea)
+					  FROM users WHERE login = $1`
+	RecoverUser = `UPDATE users SET password = crypt($2, gen_salt('bf')), public_key = $3, personal_key = $4
+				   WHERE id = $1 returning login`
+	DeleteUserVaultKeys  = `DELETE FROM vault_keys WHERE user_id = $1`
+	MarkUserVaultsRotate = `UPDATE vaults SET rotation_required = true
+							WHERE organization_id IN (SELECT organization_id FROM organization_members WHERE user_id = $1)`
 )
 
 // NewPostgresUserStorage - Creates UserPostgresStorage instance.
@@ -83,3 +95,154 @@ func (u UserPostgresStorage) DeleteUser(ctx context.Context, user model.User) (m
 
 	return model.User{}, nil
 }
+
+// SetPublicKey - stores public key from provided model.User in DB, then returns model.User populated with login
+// from database.
+func (u UserPostgresStorage) SetPublicKey(ctx context.Context, user model.User) (model.User, error) {
+	ctxWithTimeOut, cancel := context.WithTimeout(ctx, time.Second*5)
+	defer cancel()
+
+	err := u.conn.QueryRow(ctxWithTimeOut, SetPublicKey, user.ID, user.PublicKey).Scan(&user.Login)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return user, err
+		}
+
+		return user, fmt.Errorf("public key update err: %w", err)
+	}
+
+	return user, nil
+}
+
+// SetPersonalKey - stores wrapped personal key from provided model.User in DB, then returns model.User populated
+// with login from database.
+func (u UserPostgresStorage) SetPersonalKey(ctx context.Context, user model.User) (model.User, error) {
+	ctxWithTimeOut, cancel := context.WithTimeout(ctx, time.Second*5)
+	defer cancel()
+
+	err := u.conn.QueryRow(ctxWithTimeOut, SetPersonalKey, user.ID, user.PersonalKey).Scan(&user.Login)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return user, err
+		}
+
+		return user, fmt.Errorf("personal key update err: %w", err)
+	}
+
+	return user, nil
+}
+
+// GetPersonalKey - returns model.User populated with login and wrapped personal key from database.
+//
+// If personal key is not stored yet, then PersonalKey of returned model.User is empty.
+func (u UserPostgresStorage) GetPersonalKey(ctx context.Context, user model.User) (model.User, error) {
+	ctxWithTimeOut, cancel := context.WithTimeout(ctx, time.Second*5)
+	defer cancel()
+
+	err := u.conn.QueryRow(ctxWithTimeOut, GetPersonalKey, user.ID).Scan(&user.Login, &user.PersonalKey)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return user, err
+		}
+
+		return user, fmt.Errorf("personal key select err: %w", err)
+	}
+
+	return user, nil
+}
+
+// GetByLogin - returns model.User populated with id and public key from database by login of provided model.User.
+func (u UserPostgresStorage) GetByLogin(ctx context.Context, user model.User) (model.User, error) {
+	ctxWithTimeOut, cancel := context.WithTimeout(ctx, time.Second*5)
+	defer cancel()
+
+	err := u.conn.QueryRow(ctxWithTimeOut, GetUserByLogin, user.Login).Scan(&user.ID, &user.PublicKey)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return user, err
+		}
+
+		return user, fmt.Errorf("user select err: %w", err)
+	}
+
+	return user, nil
+}
+
+// SetRecovery - stores recovery key and recovery token hash from provided model.User in DB, then returns model.User
+// populated with login from database.
+func (u UserPostgresStorage) SetRecovery(ctx context.Context, user model.User) (model.User, error) {
+	ctxWithTimeOut, cancel := context.WithTimeout(ctx, time.Second*5)
+	defer cancel()
+
+	err := u.conn.QueryRow(ctxWithTimeOut, SetRecovery, user.ID, user.RecoveryKey, user.RecoveryTokenHash).
+		Scan(&user.Login)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return user, err
+		}
+
+		return user, fmt.Errorf("recovery update err: %w", err)
+	}
+
+	return user, nil
+}
+
+// GetRecovery - returns model.User populated with id, recovery key and recovery token hash from database by login
+// of provided model.User.
+//
+// If recovery is not set up, then RecoveryKey and RecoveryTokenHash of returned model.User are empty.
+func (u UserPostgresStorage) GetRecovery(ctx context.Context, user model.User) (model.User, error) {
+	ctxWithTimeOut, cancel := context.WithTimeout(ctx, time.Second*5)
+	defer cancel()
+
+	err := u.conn.QueryRow(ctxWithTimeOut, GetRecovery, user.Login).
+		Scan(&user.ID, &user.RecoveryKey, &user.RecoveryTokenHash)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return user, err
+		}
+
+		return user, fmt.Errorf("recovery select err: %w", err)
+	}
+
+	return user, nil
+}
+
+// Recover - replaces password, public key and wrapped personal key of provided model.User in DB.
+//
+// Vault keys of the user are wrapped for the old public key, so they are deleted and all vaults of organizations
+// the user is member of are marked for key rotation.
+func (u UserPostgresStorage) Recover(ctx context.Context, user model.User) (model.User, error) {
+	ctxWithTimeOut, cancel := context.WithTimeout(ctx, time.Second*5)
+	defer cancel()
+
+	tx, err := u.conn.Begin(ctxWithTimeOut)
+	if err != nil {
+		return user, fmt.Errorf("recovery err: %w", err)
+	}
+	defer tx.Rollback(ctxWithTimeOut)
+
+	err = tx.QueryRow(ctxWithTimeOut, RecoverUser, user.ID, user.Password, user.PublicKey, user.PersonalKey).
+		Scan(&user.Login)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return user, err
+		}
+
+		return user, fmt.Errorf("user recovery update err: %w", err)
+	}
+
+	if _, err = tx.Exec(ctxWithTimeOut, DeleteUserVaultKeys, user.ID); err != nil {
+		return user, fmt.Errorf("user vault keys deletion err: %w", err)
+	}
+
+	if _, err = tx.Exec(ctxWithTimeOut, MarkUserVaultsRotate, user.ID); err != nil {
+		return user, fmt.Errorf("marking vaults for rotation err: %w", err)
+	}
+
+	if err = tx.Commit(ctxWithTimeOut); err != nil {
+		return user, fmt.Errorf("recovery err: %w", err)
+	}
+
+	return user, nil
+}
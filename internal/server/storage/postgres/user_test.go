@@ -213,3 +213,145 @@ func TestUserPostgresStorage_DeleteUser(t *testing.T) {
 		})
 	}
 }
+
+func TestUserPostgresStorage_SetPublicKey(t *testing.T) {
+	utils.RefreshTestDatabase()
+
+	ctx := context.Background()
+
+	con := utils.CreatePostgresTestConn()
+	defer con.Close(ctx)
+
+	tests := []struct {
+		name      string
+		errAssert assert.ErrorAssertionFunc
+		do        func(ctx context.Context, user model.User) model.User
+	}{
+		{
+			name: "Public key can be set to existing user",
+			do: func(ctx context.Context, user model.User) model.User {
+				con.QueryRow(
+					ctx, "insert into users (login, password) values ('test', 'test') returning id",
+				).Scan(&user.ID)
+
+				return user
+			},
+			errAssert: assert.NoError,
+		},
+		{
+			name: "Public key setting will return no rows error if non existing user_id is provided",
+			do: func(ctx context.Context, user model.User) model.User {
+				uid := uuid.New()
+				user.ID = &uid
+
+				return user
+			},
+			errAssert: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := model.User{PublicKey: []byte{1, 2, 3}}
+			u := UserPostgresStorage{conn: con}
+
+			user = tt.do(ctx, user)
+
+			got, err := u.SetPublicKey(ctx, user)
+
+			tt.errAssert(t, err, fmt.Sprintf("SetPublicKey(%v, %v)", ctx, user))
+
+			if err == nil {
+				assert.Equal(t, "test", got.Login)
+			}
+		})
+	}
+}
+
+func TestUserPostgresStorage_PersonalKey(t *testing.T) {
+	utils.RefreshTestDatabase()
+
+	ctx := context.Background()
+
+	con := utils.CreatePostgresTestConn()
+	defer con.Close(ctx)
+
+	u := UserPostgresStorage{conn: con}
+
+	user, _ := u.Create(ctx, model.User{Login: "test", Password: "test"})
+
+	got, err := u.GetPersonalKey(ctx, user)
+	assert.NoError(t, err)
+	assert.Empty(t, got.PersonalKey, "personal key is empty until it is set")
+
+	user.PersonalKey = []byte{1, 2, 3}
+	_, err = u.SetPersonalKey(ctx, user)
+	assert.NoError(t, err)
+
+	got, err = u.GetPersonalKey(ctx, model.User{ID: user.ID})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{1, 2, 3}, got.PersonalKey)
+
+	uid := uuid.New()
+	_, err = u.GetPersonalKey(ctx, model.User{ID: &uid})
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
+func TestUserPostgresStorage_GetByLogin(t *testing.T) {
+	utils.RefreshTestDatabase()
+
+	ctx := context.Background()
+
+	con := utils.CreatePostgresTestConn()
+	defer con.Close(ctx)
+
+	u := UserPostgresStorage{conn: con}
+
+	user, _ := u.Create(ctx, model.User{Login: "test", Password: "test"})
+	user.PublicKey = []byte{1, 2, 3}
+	u.SetPublicKey(ctx, user)
+
+	got, err := u.GetByLogin(ctx, model.User{Login: "test"})
+	assert.NoError(t, err)
+	assert.Equal(t, user.ID, got.ID)
+	assert.Equal(t, []byte{1, 2, 3}, got.PublicKey)
+
+	_, err = u.GetByLogin(ctx, model.User{Login: "unknown"})
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
+func TestUserPostgresStorage_Recovery(t *testing.T) {
+	utils.RefreshTestDatabase()
+
+	ctx := context.Background()
+
+	con := utils.CreatePostgresTestConn()
+	defer con.Close(ctx)
+
+	u := UserPostgresStorage{conn: con}
+
+	user, _ := u.Create(ctx, model.User{Login: "test", Password: "test"})
+
+	got, err := u.GetRecovery(ctx, model.User{Login: "test"})
+	assert.NoError(t, err)
+	assert.Empty(t, got.RecoveryTokenHash, "recovery is empty until it is set")
+
+	user.RecoveryKey, user.RecoveryTokenHash = []byte{1}, []byte{2}
+	_, err = u.SetRecovery(ctx, user)
+	assert.NoError(t, err)
+
+	got, err = u.GetRecovery(ctx, model.User{Login: "test"})
+	assert.NoError(t, err)
+	assert.Equal(t, user.ID, got.ID)
+	assert.Equal(t, []byte{1}, got.RecoveryKey)
+	assert.Equal(t, []byte{2}, got.RecoveryTokenHash)
+
+	got.Password, got.PublicKey, got.PersonalKey = "new password", []byte{3}, []byte{4}
+	_, err = u.Recover(ctx, got)
+	assert.NoError(t, err)
+
+	_, err = u.GetByLoginAndPassword(ctx, model.User{Login: "test", Password: "new password"})
+	assert.NoError(t, err, "user can login with new password")
+
+	_, err = u.GetByLoginAndPassword(ctx, model.User{Login: "test", Password: "test"})
+	assert.Error(t, err, "old password doesn't work anymore")
+}
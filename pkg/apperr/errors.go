@@ -10,4 +10,6 @@ var (
 	ErrConflict             = fmt.Errorf("conflict: %w", ErrInvalidInput)
 	ErrUpdatedAtDoesntMatch = errors.New("could not update secrete. Local data doesn't match with server")
 	ErrSecretNotFound       = errors.New("data not found")
+	ErrKeyVersionMismatch   = errors.New("vault key version doesn't match, vault has been rotated already")
+	ErrRotationIncomplete   = errors.New("vault key rotation must cover every member and every secret of the vault")
 )
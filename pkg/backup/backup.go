@@ -0,0 +1,172 @@
+// Package backup writes and reads encrypted backups of secrets.
+//
+// Backup file is a header followed by ciphertext, all integers are big-endian:
+//
+//	magic       4 bytes   "SKBK"
+//	version     1 byte    1
+//	salt        16 bytes  salt of argon2id
+//	time        4 bytes   iterations of argon2id
+//	memory      4 bytes   memory of argon2id in KiB
+//	threads     1 byte    parallelism of argon2id
+//	nonce       24 bytes  nonce of XChaCha20-Poly1305
+//	ciphertext  ...       XChaCha20-Poly1305 sealed gzip compressed JSON, header is its additional data
+//
+// Key is argon2id of the export passphrase. JSON is an object with "version", "created_at" and "secrets", every
+// secret has "title", "type", "content" (base64 of decrypted content), "created_at", "updated_at" and optional
+// "expires_at", "rotate_every_days" and "auto_expire". Types are IDs of secret types, 1 login/pass, 2 text,
+// 3 binary, 4 card, 5 TOTP and 6 SSH key, content of binary secret is the file, content of others is their JSON.
+package backup
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+// Version - is version of backup format written by Encrypt.
+const Version = 1
+
+const (
+	magic      = "SKBK"
+	saltSize   = 16
+	headerSize = len(magic) + 1 + saltSize + 4 + 4 + 1 + chacha20poly1305.NonceSizeX
+
+	// argon2id parameters of new backups, they are stored in header, so they may be raised later.
+	keyTime    = 3
+	keyMemory  = 64 * 1024
+	keyThreads = 4
+	// maxTime, maxMemory and maxThreads - limit argon2id parameters read from header, so a crafted file can't
+	// exhaust memory or CPU, they leave room to raise parameters of new backups.
+	maxTime    = 16
+	maxMemory  = 1024 * 1024
+	maxThreads = 16
+)
+
+var (
+	ErrFormat     = errors.New("file is not a SecretKeeper backup")
+	ErrVersion    = errors.New("backup is written by newer version of SecretKeeper")
+	ErrPassphrase = errors.New("passphrase is wrong or backup is corrupted")
+)
+
+// Secret - is a secret of backup with its metadata.
+type Secret struct {
+	Title           string     `json:"title"`
+	Type            int        `json:"type"`
+	Content         []byte     `json:"content"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+	ExpiresAt       *time.Time `json:"expires_at,omitempty"`
+	RotateEveryDays int        `json:"rotate_every_days,omitempty"`
+	AutoExpire      bool       `json:"auto_expire,omitempty"`
+}
+
+// Backup - is decrypted content of backup file.
+type Backup struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	Secrets   []Secret  `json:"secrets"`
+}
+
+// Encrypt - encrypts backup by passphrase and returns content of backup file.
+func Encrypt(b Backup, passphrase string) ([]byte, error) {
+	b.Version = Version
+
+	var plain bytes.Buffer
+
+	gz := gzip.NewWriter(&plain)
+	if err := json.NewEncoder(gz).Encode(b); err != nil {
+		return nil, fmt.Errorf("error in encoding backup: %w", err)
+	}
+
+	if err := gz.Close(); err != nil {
+		return nil, fmt.Errorf("error in compressing backup: %w", err)
+	}
+
+	header := make([]byte, headerSize)
+	copy(header, magic)
+	header[len(magic)] = Version
+
+	salt := header[len(magic)+1 : len(magic)+1+saltSize]
+	params := header[len(magic)+1+saltSize:]
+	binary.BigEndian.PutUint32(params[0:4], keyTime)
+	binary.BigEndian.PutUint32(params[4:8], keyMemory)
+	params[8] = keyThreads
+	nonce := params[9:]
+
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, fmt.Errorf("error in generating salt: %w", err)
+	}
+
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, fmt.Errorf("error in generating nonce: %w", err)
+	}
+
+	aead, err := chacha20poly1305.NewX(argon2.IDKey([]byte(passphrase), salt, keyTime, keyMemory, keyThreads,
+		chacha20poly1305.KeySize))
+	if err != nil {
+		return nil, fmt.Errorf("error in creating cipher: %w", err)
+	}
+
+	sealed := make([]byte, headerSize, headerSize+plain.Len()+aead.Overhead())
+	copy(sealed, header)
+
+	return aead.Seal(sealed, nonce, plain.Bytes(), header), nil
+}
+
+// Decrypt - decrypts content of backup file by passphrase, ciphertext is authenticated, so changed file isn't read.
+func Decrypt(data []byte, passphrase string) (Backup, error) {
+	if len(data) < headerSize || string(data[:len(magic)]) != magic {
+		return Backup{}, ErrFormat
+	}
+
+	switch version := data[len(magic)]; {
+	case version == 0:
+		return Backup{}, ErrFormat
+	case version > Version:
+		return Backup{}, ErrVersion
+	}
+
+	header := data[:headerSize]
+	salt := header[len(magic)+1 : len(magic)+1+saltSize]
+	params := header[len(magic)+1+saltSize:]
+	iterations, memory, threads := binary.BigEndian.Uint32(params[0:4]), binary.BigEndian.Uint32(params[4:8]), params[8]
+	nonce := params[9:]
+
+	if iterations == 0 || iterations > maxTime || memory == 0 || memory > maxMemory || threads == 0 ||
+		threads > maxThreads {
+		return Backup{}, ErrFormat
+	}
+
+	aead, err := chacha20poly1305.NewX(argon2.IDKey([]byte(passphrase), salt, iterations, memory, threads,
+		chacha20poly1305.KeySize))
+	if err != nil {
+		return Backup{}, fmt.Errorf("error in creating cipher: %w", err)
+	}
+
+	plain, errOpen := aead.Open(nil, nonce, data[headerSize:], header)
+	if errOpen != nil {
+		return Backup{}, ErrPassphrase
+	}
+
+	gz, errGzip := gzip.NewReader(bytes.NewReader(plain))
+	if errGzip != nil {
+		return Backup{}, fmt.Errorf("error in decompressing backup: %w", errGzip)
+	}
+	defer gz.Close()
+
+	var b Backup
+	if errDecode := json.NewDecoder(gz).Decode(&b); errDecode != nil {
+		return Backup{}, fmt.Errorf("error in decoding backup: %w", errDecode)
+	}
+
+	return b, nil
+}
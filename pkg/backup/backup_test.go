@@ -0,0 +1,128 @@
+package backup
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// paramsOffset - is offset of argon2id parameters in header.
+const paramsOffset = len(magic) + 1 + saltSize
+
+func TestEncryptDecrypt(t *testing.T) {
+	createdAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	expiresAt := createdAt.Add(30 * 24 * time.Hour)
+
+	b := Backup{
+		CreatedAt: createdAt,
+		Secrets: []Secret{
+			{
+				Title: "mail", Type: 1, Content: []byte(`{"login":"alice","password":"p@ss"}`),
+				CreatedAt: createdAt, UpdatedAt: createdAt, ExpiresAt: &expiresAt, RotateEveryDays: 90,
+			},
+			{Title: "file", Type: 3, Content: []byte{0, 1, 2, 255}, CreatedAt: createdAt, UpdatedAt: createdAt},
+		},
+	}
+
+	data, err := Encrypt(b, "correct horse")
+	require.NoError(t, err)
+
+	decrypted, err := Decrypt(data, "correct horse")
+	require.NoError(t, err)
+
+	b.Version = Version
+	assert.Equal(t, b, decrypted)
+}
+
+func TestDecrypt_Errors(t *testing.T) {
+	data, err := Encrypt(Backup{CreatedAt: time.Now().UTC()}, "correct horse")
+	require.NoError(t, err)
+
+	changed := func(change func(data []byte)) []byte {
+		cp := append([]byte(nil), data...)
+		change(cp)
+
+		return cp
+	}
+
+	tests := []struct {
+		name       string
+		data       []byte
+		passphrase string
+		err        error
+	}{
+		{
+			name:       "wrong passphrase",
+			data:       data,
+			passphrase: "wrong horse",
+			err:        ErrPassphrase,
+		},
+		{
+			name:       "truncated header",
+			data:       data[:headerSize-1],
+			passphrase: "correct horse",
+			err:        ErrFormat,
+		},
+		{
+			name:       "not a backup",
+			data:       changed(func(data []byte) { copy(data, "ZIP!") }),
+			passphrase: "correct horse",
+			err:        ErrFormat,
+		},
+		{
+			name:       "version zero",
+			data:       changed(func(data []byte) { data[len(magic)] = 0 }),
+			passphrase: "correct horse",
+			err:        ErrFormat,
+		},
+		{
+			name:       "newer version",
+			data:       changed(func(data []byte) { data[len(magic)] = Version + 1 }),
+			passphrase: "correct horse",
+			err:        ErrVersion,
+		},
+		{
+			name: "argon2 memory over limit",
+			data: changed(func(data []byte) {
+				binary.BigEndian.PutUint32(data[paramsOffset+4:paramsOffset+8], maxMemory+1)
+			}),
+			passphrase: "correct horse",
+			err:        ErrFormat,
+		},
+		{
+			name: "argon2 time over limit",
+			data: changed(func(data []byte) {
+				binary.BigEndian.PutUint32(data[paramsOffset:paramsOffset+4], maxTime+1)
+			}),
+			passphrase: "correct horse",
+			err:        ErrFormat,
+		},
+		{
+			name:       "zero argon2 threads",
+			data:       changed(func(data []byte) { data[paramsOffset+8] = 0 }),
+			passphrase: "correct horse",
+			err:        ErrFormat,
+		},
+		{
+			name:       "changed ciphertext",
+			data:       changed(func(data []byte) { data[len(data)-1] ^= 1 }),
+			passphrase: "correct horse",
+			err:        ErrPassphrase,
+		},
+		{
+			name:       "changed salt",
+			data:       changed(func(data []byte) { data[len(magic)+1] ^= 1 }),
+			passphrase: "correct horse",
+			err:        ErrPassphrase,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, errDecrypt := Decrypt(tt.data, tt.passphrase)
+			assert.ErrorIs(t, errDecrypt, tt.err)
+		})
+	}
+}
@@ -0,0 +1,146 @@
+// Package breach checks passwords against Have I Been Pwned-style SHA-1 corpus by k-anonymity.
+//
+// Only first 5 characters of SHA-1 hash of a password are used to look up a range of hash suffixes, so neither the
+// password nor its full hash leaves the process.
+package breach
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// prefixLength - is length of hash prefix which is sent to range API.
+const prefixLength = 5
+
+// requestTimeout - limits request to range API.
+const requestTimeout = 10 * time.Second
+
+// Checker - reports how many times a password is seen in breaches, zero stands for not found.
+type Checker interface {
+	Count(ctx context.Context, password string) (int, error)
+}
+
+// NewChecker - creates Checker by source of the corpus.
+//
+// Source starting with http:// or https:// is base URL of range API serving range/{prefix}, any other source is a
+// local path: either a directory of downloaded range files named {prefix}.txt, or a single file of full hashes.
+func NewChecker(source string) Checker {
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		return &RangeClient{BaseURL: strings.TrimRight(source, "/"), Client: &http.Client{Timeout: requestTimeout}}
+	}
+
+	return &RangeFiles{Path: source}
+}
+
+// hashPassword - returns upper-case SHA-1 hex of password split into prefix and suffix.
+func hashPassword(password string) (string, string) {
+	// corpus is keyed by SHA-1, it is not used to protect anything here
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+
+	return hash[:prefixLength], hash[prefixLength:]
+}
+
+// RangeClient - looks up ranges by HTTP API, e.g. https://api.pwnedpasswords.com or a local stand-in.
+type RangeClient struct {
+	BaseURL string
+	Client  *http.Client
+}
+
+// Count - requests range of hash prefix of password and finds its suffix there.
+func (r *RangeClient) Count(ctx context.Context, password string) (int, error) {
+	prefix, suffix := hashPassword(password)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.BaseURL+"/range/"+prefix, nil)
+	if err != nil {
+		return 0, fmt.Errorf("error in creating range request: %w", err)
+	}
+
+	// padding hides real count of suffixes in the range from observers of response size
+	req.Header.Set("Add-Padding", "true")
+
+	resp, errDo := r.Client.Do(req)
+	if errDo != nil {
+		return 0, fmt.Errorf("error in requesting range: %w", errDo)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("range API responded with %s", resp.Status)
+	}
+
+	return findSuffix(resp.Body, suffix, "")
+}
+
+// RangeFiles - looks up ranges in local corpus.
+type RangeFiles struct {
+	Path string
+}
+
+// Count - reads range file of hash prefix of password, or scans single corpus file for the full hash.
+func (r *RangeFiles) Count(_ context.Context, password string) (int, error) {
+	if r.Path == "" {
+		return 0, errors.New("breach corpus is not configured")
+	}
+
+	prefix, suffix := hashPassword(password)
+
+	info, err := os.Stat(r.Path)
+	if err != nil {
+		return 0, fmt.Errorf("error in opening breach corpus: %w", err)
+	}
+
+	path, linePrefix := r.Path, prefix
+	if info.IsDir() {
+		path, linePrefix = filepath.Join(r.Path, prefix+".txt"), ""
+	}
+
+	f, errOpen := os.Open(path)
+	if errOpen != nil {
+		// range without any breached hash may be absent in downloaded corpus
+		if errors.Is(errOpen, os.ErrNotExist) {
+			return 0, nil
+		}
+
+		return 0, fmt.Errorf("error in opening breach corpus: %w", errOpen)
+	}
+	defer f.Close()
+
+	return findSuffix(f, suffix, linePrefix)
+}
+
+// findSuffix - finds count of hash in lines formatted as HASH:COUNT, every hash starts with linePrefix.
+func findSuffix(r io.Reader, suffix, linePrefix string) (int, error) {
+	scanner := bufio.NewScanner(r)
+
+	for scanner.Scan() {
+		hash, count, ok := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		if !ok || !strings.EqualFold(hash, linePrefix+suffix) {
+			continue
+		}
+
+		n, err := strconv.Atoi(count)
+		if err != nil {
+			return 0, fmt.Errorf("malformed count of hash in breach corpus: %w", err)
+		}
+
+		return n, nil
+	}
+
+	if err := scanner.Err(); err != nil {
+		return 0, fmt.Errorf("error in reading breach corpus: %w", err)
+	}
+
+	return 0, nil
+}
@@ -0,0 +1,163 @@
+package breach
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// SHA-1 of "password" is 5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8.
+const (
+	passwordPrefix = "5BAA6"
+	passwordSuffix = "1E4C9B93F3F0682250B6CF8331B7EE68FD8"
+)
+
+// passwordRange - is range of passwordPrefix as range API serves it, with CRLF line endings and padding.
+const passwordRange = "003D68EB55068C33ACE09247EE4C639306B:3\r\n" +
+	"1e4c9b93f3f0682250b6cf8331b7ee68fd8:3861493\r\n" +
+	"01330C689E5D64F660D6947A93AD634EF8F:0\r\n"
+
+func TestHashPassword(t *testing.T) {
+	prefix, suffix := hashPassword("password")
+
+	assert.Equal(t, passwordPrefix, prefix)
+	assert.Equal(t, passwordSuffix, suffix)
+}
+
+func TestFindSuffix(t *testing.T) {
+	tests := []struct {
+		name       string
+		data       string
+		linePrefix string
+		want       int
+		wantErr    bool
+	}{
+		{name: "suffix in range", data: passwordRange, want: 3861493},
+		{name: "suffix is missing", data: "003D68EB55068C33ACE09247EE4C639306B:3\n", want: 0},
+		{name: "empty range", data: "", want: 0},
+		{name: "lines without count are skipped", data: "garbage\n" + passwordSuffix + ":7\n", want: 7},
+		{name: "full hash", data: passwordPrefix + passwordSuffix + ":12\n", linePrefix: passwordPrefix, want: 12},
+		{name: "suffix alone isn't full hash", data: passwordSuffix + ":12\n", linePrefix: passwordPrefix, want: 0},
+		{name: "malformed count", data: passwordSuffix + ":many\n", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			count, err := findSuffix(strings.NewReader(tt.data), passwordSuffix, tt.linePrefix)
+			if tt.wantErr {
+				assert.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, count)
+		})
+	}
+}
+
+func TestRangeClient(t *testing.T) {
+	var (
+		mu        sync.Mutex
+		requested []string
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requested = append(requested, r.URL.Path)
+		mu.Unlock()
+
+		assert.Equal(t, "true", r.Header.Get("Add-Padding"))
+
+		switch r.URL.Path {
+		case "/range/" + passwordPrefix:
+			_, _ = w.Write([]byte(passwordRange))
+		case "/range/" + "7C4A8":
+			// SHA-1 of "123456" is 7C4A8D09CA3762AF61E59520943DC26494F8941B
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		default:
+			_, _ = w.Write([]byte("003D68EB55068C33ACE09247EE4C639306B:3\r\n"))
+		}
+	}))
+	defer server.Close()
+
+	checker := NewChecker(server.URL + "/")
+	require.IsType(t, &RangeClient{}, checker)
+
+	count, err := checker.Count(context.Background(), "password")
+	require.NoError(t, err)
+	assert.Equal(t, 3861493, count)
+
+	count, err = checker.Count(context.Background(), "correct horse battery staple")
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	// only the prefix of hash leaves the process
+	mu.Lock()
+	assert.Equal(t, []string{"/range/" + passwordPrefix, "/range/ABF7A"}, requested)
+	mu.Unlock()
+
+	_, err = checker.Count(context.Background(), "123456")
+	assert.ErrorContains(t, err, "503 Service Unavailable")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = checker.Count(ctx, "password")
+	assert.ErrorIs(t, err, context.Canceled)
+
+	server.Close()
+
+	_, err = checker.Count(context.Background(), "password")
+	assert.ErrorContains(t, err, "error in requesting range")
+}
+
+func TestRangeFiles(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, passwordPrefix+".txt"), []byte(passwordRange), 0o600))
+
+	corpus := filepath.Join(t.TempDir(), "pwned.txt")
+	require.NoError(t, os.WriteFile(corpus, []byte("7C4A8D09CA3762AF61E59520943DC26494F8941B:37359195\n"+
+		passwordPrefix+passwordSuffix+":3861493\n"), 0o600))
+
+	tests := []struct {
+		name     string
+		path     string
+		password string
+		want     int
+		wantErr  string
+	}{
+		{name: "range file", path: dir, password: "password", want: 3861493},
+		{name: "range file is missing", path: dir, password: "correct horse battery staple", want: 0},
+		{name: "single corpus file", path: corpus, password: "password", want: 3861493},
+		{name: "hash isn't in corpus file", path: corpus, password: "correct horse battery staple", want: 0},
+		{name: "corpus isn't configured", path: "", password: "password", wantErr: "not configured"},
+		{
+			name: "corpus is missing", path: filepath.Join(dir, "missing"), password: "password",
+			wantErr: "error in opening breach corpus",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := NewChecker(tt.path)
+			require.IsType(t, &RangeFiles{}, checker)
+
+			count, err := checker.Count(context.Background(), tt.password)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, count)
+		})
+	}
+}
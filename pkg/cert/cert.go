@@ -2,6 +2,9 @@ package cert
 
 import (
 	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
 
 	"go.uber.org/zap"
 	"google.golang.org/grpc/credentials"
@@ -31,6 +34,8 @@ func NewSSLConfigService() *sslConfigService {
 }
 
 // LoadClientCertificate returns client credential TLS by path from client config.
+//
+// Server is verified by CA of client config and its address, without CA it isn't verified.
 func (s sslConfigService) LoadClientCertificate(cfg clientConfig.Config) (credentials.TransportCredentials, error) {
 	cert, err := tls.LoadX509KeyPair(cfg.SSLCertPath, cfg.SSLKeyPath)
 	if err != nil {
@@ -39,10 +44,30 @@ func (s sslConfigService) LoadClientCertificate(cfg clientConfig.Config) (creden
 		return nil, err
 	}
 
+	if cfg.CAPath == "" {
+		return credentials.NewTLS(
+			&tls.Config{
+				Certificates:       []tls.Certificate{cert},
+				InsecureSkipVerify: true,
+			},
+		), nil
+	}
+
+	ca, errCA := os.ReadFile(cfg.CAPath)
+	if errCA != nil {
+		return nil, fmt.Errorf("error in reading CA certificate: %w", errCA)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, fmt.Errorf("error in reading CA certificate: %s has no PEM certificates", cfg.CAPath)
+	}
+
 	return credentials.NewTLS(
 		&tls.Config{
-			Certificates:       []tls.Certificate{cert},
-			InsecureSkipVerify: true,
+			Certificates: []tls.Certificate{cert},
+			RootCAs:      pool,
+			ServerName:   cfg.Address,
 		},
 	), nil
 }
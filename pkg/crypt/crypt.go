@@ -9,7 +9,7 @@ import (
 )
 
 type Crypter interface {
-	Encode(payload string) string
+	Encode(payload string) (string, error)
 	Decode(sha string) (string, error)
 }
 
@@ -46,14 +46,14 @@ func NewCrypt() (*crypt, error) {
 }
 
 // Encode - returns sha of sealed payload by aesGCM.
-func (c *crypt) Encode(payload string) string {
+func (c *crypt) Encode(payload string) (string, error) {
 	src := []byte(payload)
 
 	dst := c.aesGCM.Seal(nil, c.nonce, src, nil)
 
 	sha := hex.EncodeToString(dst)
 
-	return sha
+	return sha, nil
 }
 
 // Decode - returns decoded string by aesGCM from sha.
@@ -65,7 +65,7 @@ func (c *crypt) Decode(sha string) (string, error) {
 
 	src, errGCM := c.aesGCM.Open(nil, c.nonce, dst, nil)
 	if errGCM != nil {
-		return "", errDecode
+		return "", fmt.Errorf("GCM open error: %w", errGCM)
 	}
 
 	return string(src), nil
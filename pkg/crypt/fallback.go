@@ -0,0 +1,48 @@
+package crypt
+
+import "errors"
+
+// errNoCrypter - is returned by fallbackCrypt without crypters.
+var errNoCrypter = errors.New("no crypter is provided")
+
+type fallbackCrypt struct {
+	crypters []Crypter
+}
+
+// NewFallbackCrypt - creates new Crypter instance, which encodes payloads with the first provided Crypter and decodes
+// them with the first Crypter that succeeds. Nil crypters are skipped.
+//
+// It allows to switch to a new key while payloads encoded with an old key can still be decoded.
+func NewFallbackCrypt(crypters ...Crypter) *fallbackCrypt {
+	fc := &fallbackCrypt{}
+	for _, c := range crypters {
+		if c != nil {
+			fc.crypters = append(fc.crypters, c)
+		}
+	}
+
+	return fc
+}
+
+// Encode - returns sha of payload encoded by the first Crypter.
+func (f *fallbackCrypt) Encode(payload string) (string, error) {
+	if len(f.crypters) == 0 {
+		return "", errNoCrypter
+	}
+
+	return f.crypters[0].Encode(payload)
+}
+
+// Decode - returns decoded string by the first Crypter which is able to decode sha.
+func (f *fallbackCrypt) Decode(sha string) (string, error) {
+	err := errNoCrypter
+
+	for _, c := range f.crypters {
+		var decoded string
+		if decoded, err = c.Decode(sha); err == nil {
+			return decoded, nil
+		}
+	}
+
+	return "", err
+}
@@ -0,0 +1,149 @@
+package crypt
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/curve25519"
+	"golang.org/x/crypto/nacl/box"
+)
+
+// KeySize - is a size in bytes of symmetric keys and X25519 keys.
+const KeySize = 32
+
+// ErrUnwrap - is returned when wrapped key can't be opened by provided key pair.
+var ErrUnwrap = errors.New("key could not be unwrapped")
+
+// KeyPair - is X25519 key pair of a user, which is used to wrap and unwrap vault keys.
+type KeyPair struct {
+	Public  [KeySize]byte
+	Private [KeySize]byte
+}
+
+// DeriveKeyPair - derives KeyPair of a user from their login and password via argon2id.
+//
+// Login is used as a salt, so the same password of different users gives different key pairs.
+func DeriveKeyPair(login, password string) (*KeyPair, error) {
+	salt := sha256.Sum256([]byte("secretKeeper/" + login))
+
+	seed := argon2.IDKey([]byte(password), salt[:], 1, 64*1024, 4, KeySize)
+
+	return NewKeyPair(seed)
+}
+
+// NewKeyPair - creates KeyPair from private key.
+func NewKeyPair(private []byte) (*KeyPair, error) {
+	if len(private) != KeySize {
+		return nil, fmt.Errorf("private key must be %d bytes long", KeySize)
+	}
+
+	kp := &KeyPair{}
+	copy(kp.Private[:], private)
+
+	public, err := curve25519.X25519(kp.Private[:], curve25519.Basepoint)
+	if err != nil {
+		return nil, fmt.Errorf("error in deriving public key: %w", err)
+	}
+	copy(kp.Public[:], public)
+
+	return kp, nil
+}
+
+// Zero - overwrites private key of KeyPair.
+func (kp *KeyPair) Zero() {
+	for i := range kp.Private {
+		kp.Private[i] = 0
+	}
+}
+
+// NewKey - generates new random symmetric key.
+func NewKey() ([]byte, error) {
+	key := make([]byte, KeySize)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, fmt.Errorf("error in generating key: %w", err)
+	}
+
+	return key, nil
+}
+
+// WrapKey - seals key for recipient public key, so only owner of matching private key could unwrap it.
+func WrapKey(key, recipient []byte) ([]byte, error) {
+	if len(recipient) != KeySize {
+		return nil, fmt.Errorf("public key must be %d bytes long", KeySize)
+	}
+
+	var pub [KeySize]byte
+	copy(pub[:], recipient)
+
+	return box.SealAnonymous(nil, key, &pub, rand.Reader)
+}
+
+// UnwrapKey - opens key wrapped by WrapKey with KeyPair.
+func UnwrapKey(wrapped []byte, kp *KeyPair) ([]byte, error) {
+	key, ok := box.OpenAnonymous(nil, wrapped, &kp.Public, &kp.Private)
+	if !ok {
+		return nil, ErrUnwrap
+	}
+
+	return key, nil
+}
+
+type keyCrypt struct {
+	aesGCM cipher.AEAD
+}
+
+// NewKeyCrypt - creates new Crypter instance, which encodes payloads with provided symmetric key.
+//
+// Unlike NewCrypt, every payload is sealed with its own random nonce which is prepended to the result.
+func NewKeyCrypt(key []byte) (*keyCrypt, error) {
+	aesBlock, errBlock := aes.NewCipher(key)
+	if errBlock != nil {
+		return nil, fmt.Errorf("error in creating new cipher: %w", errBlock)
+	}
+
+	aesGCM, errGCM := cipher.NewGCM(aesBlock)
+	if errGCM != nil {
+		return nil, fmt.Errorf("error in creating GCM: %w", errGCM)
+	}
+
+	return &keyCrypt{aesGCM: aesGCM}, nil
+}
+
+// Encode - returns sha of nonce and payload sealed by aesGCM.
+func (c *keyCrypt) Encode(payload string) (string, error) {
+	nonce := make([]byte, c.aesGCM.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", fmt.Errorf("error in generating nonce: %w", err)
+	}
+
+	dst := c.aesGCM.Seal(nonce, nonce, []byte(payload), nil)
+
+	return hex.EncodeToString(dst), nil
+}
+
+// Decode - returns decoded string by aesGCM from sha.
+func (c *keyCrypt) Decode(sha string) (string, error) {
+	dst, errDecode := hex.DecodeString(sha)
+	if errDecode != nil {
+		return "", fmt.Errorf("hex decode error: %w", errDecode)
+	}
+
+	size := c.aesGCM.NonceSize()
+	if len(dst) < size {
+		return "", errors.New("payload is too short")
+	}
+
+	src, errGCM := c.aesGCM.Open(nil, dst[:size], dst[size:], nil)
+	if errGCM != nil {
+		return "", fmt.Errorf("GCM open error: %w", errGCM)
+	}
+
+	return string(src), nil
+}
@@ -49,11 +49,12 @@ func (mr *MockCrypterMockRecorder) Decode(sha interface{}) *gomock.Call {
 }
 
 // Encode mocks base method.
-func (m *MockCrypter) Encode(payload string) string {
+func (m *MockCrypter) Encode(payload string) (string, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Encode", payload)
 	ret0, _ := ret[0].(string)
-	return ret0
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // Encode indicates an expected call of Encode.
@@ -0,0 +1,200 @@
+package crypt
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"strings"
+)
+
+// sharePrefix - is a prefix of text representation of a share, digit stands for format version.
+const sharePrefix = "SKS1-"
+
+var (
+	// ErrInvalidShare - is returned when text representation of a share is malformed or damaged.
+	ErrInvalidShare = errors.New("share is malformed or damaged")
+	// ErrNotEnoughShares - is returned when fewer shares than threshold are provided.
+	ErrNotEnoughShares = errors.New("not enough shares to restore the key")
+
+	shareEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+	gfExp [510]byte
+	gfLog [256]byte
+)
+
+// init - fills exp and log tables of GF(2^8) with AES polynomial and generator 3.
+func init() {
+	x := byte(1)
+	for i := 0; i < 255; i++ {
+		gfExp[i] = x
+		gfExp[i+255] = x
+		gfLog[x] = byte(i)
+
+		// multiply x by generator 3 = x*2 ^ x
+		doubled := x << 1
+		if x&0x80 != 0 {
+			doubled ^= 0x1b
+		}
+		x ^= doubled
+	}
+}
+
+// Share - is one part of a secret split by SplitSecret.
+type Share struct {
+	X         byte
+	Threshold byte
+	Payload   []byte
+}
+
+// SplitSecret - splits secret into n shares by Shamir's scheme, any m of which restore the secret.
+func SplitSecret(secret []byte, n, m int) ([]Share, error) {
+	if m < 2 || m > n || n > 255 {
+		return nil, fmt.Errorf("shares count must be from 2 to 255 and threshold from 2 to shares count")
+	}
+
+	if len(secret) == 0 {
+		return nil, errors.New("secret is empty")
+	}
+
+	shares := make([]Share, n)
+	for i := range shares {
+		shares[i] = Share{X: byte(i + 1), Threshold: byte(m), Payload: make([]byte, len(secret))}
+	}
+
+	coefficients := make([]byte, m)
+	for i, b := range secret {
+		coefficients[0] = b
+		if _, err := io.ReadFull(rand.Reader, coefficients[1:]); err != nil {
+			return nil, fmt.Errorf("error in generating coefficients: %w", err)
+		}
+
+		for j := range shares {
+			shares[j].Payload[i] = evaluate(coefficients, shares[j].X)
+		}
+	}
+
+	for i := range coefficients {
+		coefficients[i] = 0
+	}
+
+	return shares, nil
+}
+
+// CombineShares - restores secret from shares made by SplitSecret.
+func CombineShares(shares []Share) ([]byte, error) {
+	if len(shares) == 0 {
+		return nil, ErrNotEnoughShares
+	}
+
+	threshold, size := shares[0].Threshold, len(shares[0].Payload)
+	if threshold < 2 {
+		// SplitSecret never makes such shares, threshold 0 would silently restore zero key
+		return nil, ErrInvalidShare
+	}
+
+	seen := make(map[byte]bool, len(shares))
+	for _, share := range shares {
+		if share.Threshold != threshold || len(share.Payload) != size || share.X == 0 {
+			return nil, errors.New("shares belong to different secrets")
+		}
+
+		if seen[share.X] {
+			return nil, errors.New("the same share is provided twice")
+		}
+		seen[share.X] = true
+	}
+
+	if len(shares) < int(threshold) {
+		return nil, ErrNotEnoughShares
+	}
+
+	shares = shares[:threshold]
+
+	secret := make([]byte, size)
+	for j, share := range shares {
+		// Lagrange basis polynomial of share j evaluated at x = 0
+		basis := byte(1)
+		for k, other := range shares {
+			if k != j {
+				basis = gfMul(basis, gfDiv(other.X, other.X^share.X))
+			}
+		}
+
+		for i := range secret {
+			secret[i] ^= gfMul(share.Payload[i], basis)
+		}
+	}
+
+	return secret, nil
+}
+
+// String - returns text representation of Share, which is protected by checksum.
+func (s Share) String() string {
+	raw := append([]byte{s.X, s.Threshold}, s.Payload...)
+	raw = binary.BigEndian.AppendUint32(raw, crc32.ChecksumIEEE(raw))
+
+	return sharePrefix + shareEncoding.EncodeToString(raw)
+}
+
+// ParseShare - parses text representation of Share made by Share.String.
+func ParseShare(text string) (Share, error) {
+	text = strings.ToUpper(strings.TrimSpace(text))
+	if !strings.HasPrefix(text, sharePrefix) {
+		return Share{}, ErrInvalidShare
+	}
+
+	raw, err := shareEncoding.DecodeString(strings.TrimPrefix(text, sharePrefix))
+	if err != nil || len(raw) < 7 {
+		return Share{}, ErrInvalidShare
+	}
+
+	body, sum := raw[:len(raw)-4], raw[len(raw)-4:]
+	if crc32.ChecksumIEEE(body) != binary.BigEndian.Uint32(sum) {
+		return Share{}, ErrInvalidShare
+	}
+
+	return Share{X: body[0], Threshold: body[1], Payload: bytes.Clone(body[2:])}, nil
+}
+
+// RecoveryToken - derives from recovery key a token, which proves possession of the key to server.
+//
+// Server stores only a hash of the token, so neither the token nor the key could be learnt from server.
+func RecoveryToken(recoveryKey []byte) []byte {
+	sum := sha256.Sum256(append([]byte("secretKeeper/recovery-token/"), recoveryKey...))
+
+	return sum[:]
+}
+
+// evaluate - evaluates polynomial with provided coefficients at x in GF(2^8) by Horner's method.
+func evaluate(coefficients []byte, x byte) byte {
+	var result byte
+	for i := len(coefficients) - 1; i >= 0; i-- {
+		result = gfMul(result, x) ^ coefficients[i]
+	}
+
+	return result
+}
+
+// gfMul - multiplies a and b in GF(2^8).
+func gfMul(a, b byte) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+
+	return gfExp[int(gfLog[a])+int(gfLog[b])]
+}
+
+// gfDiv - divides a by non-zero b in GF(2^8).
+func gfDiv(a, b byte) byte {
+	if a == 0 {
+		return 0
+	}
+
+	return gfExp[int(gfLog[a])+255-int(gfLog[b])]
+}
@@ -0,0 +1,107 @@
+package crypt
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitSecret(t *testing.T) {
+	secret := bytes.Repeat([]byte{0x5a, 0x00, 0xff}, 11)
+
+	tests := []struct {
+		name    string
+		n, m    int
+		pick    []int
+		wantErr error
+	}{
+		{name: "threshold of shares restores secret", n: 5, m: 3, pick: []int{0, 2, 4}},
+		{name: "any shares of threshold restore secret", n: 5, m: 3, pick: []int{4, 1, 3}},
+		{name: "more shares than threshold restore secret", n: 5, m: 3, pick: []int{0, 1, 2, 3, 4}},
+		{name: "all shares are required when threshold is shares count", n: 2, m: 2, pick: []int{1, 0}},
+		{name: "fewer shares than threshold don't restore secret", n: 5, m: 3, pick: []int{0, 4},
+			wantErr: ErrNotEnoughShares},
+		{name: "no shares don't restore secret", n: 3, m: 2, wantErr: ErrNotEnoughShares},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shares, err := SplitSecret(secret, tt.n, tt.m)
+			assert.NoError(t, err)
+			assert.Len(t, shares, tt.n)
+
+			picked := make([]Share, 0, len(tt.pick))
+			for _, i := range tt.pick {
+				// shares travel as text, so they are restored from it
+				share, errParse := ParseShare(shares[i].String())
+				assert.NoError(t, errParse)
+
+				picked = append(picked, share)
+			}
+
+			got, errCombine := CombineShares(picked)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, errCombine, tt.wantErr)
+
+				return
+			}
+
+			assert.NoError(t, errCombine)
+			assert.Equal(t, secret, got)
+		})
+	}
+}
+
+func TestSplitSecret_Invalid(t *testing.T) {
+	_, err := SplitSecret([]byte("key"), 3, 1)
+	assert.Error(t, err, "threshold must be at least 2")
+
+	_, err = SplitSecret([]byte("key"), 2, 3)
+	assert.Error(t, err, "threshold must not exceed shares count")
+
+	_, err = SplitSecret(nil, 3, 2)
+	assert.Error(t, err, "secret must not be empty")
+}
+
+func TestCombineShares_Mismatch(t *testing.T) {
+	first, _ := SplitSecret([]byte("first key"), 3, 2)
+	second, _ := SplitSecret([]byte("other key of another size"), 3, 2)
+
+	_, err := CombineShares([]Share{first[0], second[1]})
+	assert.Error(t, err, "shares of different secrets are rejected")
+
+	_, err = CombineShares([]Share{first[0], first[0]})
+	assert.Error(t, err, "the same share is rejected")
+
+	for _, threshold := range []byte{0, 1} {
+		share := first[0]
+		share.Threshold = threshold
+
+		_, err = CombineShares([]Share{share, first[1]})
+		assert.ErrorIs(t, err, ErrInvalidShare, "threshold %d is rejected", threshold)
+	}
+}
+
+func TestParseShare(t *testing.T) {
+	shares, _ := SplitSecret([]byte("key"), 2, 2)
+	text := shares[0].String()
+
+	share, err := ParseShare(" " + text + "\n")
+	assert.NoError(t, err)
+	assert.Equal(t, shares[0], share)
+
+	// the last character may carry only padding bits, so a character in the middle is damaged
+	damaged := []byte(text)
+	i := len(sharePrefix) + 2
+	if damaged[i] == 'A' {
+		damaged[i] = 'B'
+	} else {
+		damaged[i] = 'A'
+	}
+
+	_, err = ParseShare(string(damaged))
+	assert.ErrorIs(t, err, ErrInvalidShare)
+
+	_, err = ParseShare("share")
+	assert.ErrorIs(t, err, ErrInvalidShare)
+}
@@ -0,0 +1,77 @@
+package exporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+
+	"secretKeeper/pkg/backup"
+)
+
+// writeAge - encrypts JSON of backup to X25519 recipients of age, so it's read by `age -d -i key.txt`.
+func writeAge(b backup.Backup, recipients []string, armored bool) ([]byte, error) {
+	if len(recipients) == 0 {
+		return nil, ErrRecipient
+	}
+
+	parsed := make([]age.Recipient, 0, len(recipients))
+
+	for _, r := range recipients {
+		recipient, err := age.ParseX25519Recipient(strings.TrimSpace(r))
+		if err != nil {
+			return nil, fmt.Errorf("recipient %q is not an age public key: %w", r, err)
+		}
+
+		parsed = append(parsed, recipient)
+	}
+
+	b.Version = backup.Version
+
+	plain, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("error in encoding export: %w", err)
+	}
+
+	var (
+		out bytes.Buffer
+		dst io.WriteCloser = nopCloser{&out}
+	)
+
+	if armored {
+		dst = armor.NewWriter(&out)
+	}
+
+	w, errEncrypt := age.Encrypt(dst, parsed...)
+	if errEncrypt != nil {
+		return nil, fmt.Errorf("error in encrypting export: %w", errEncrypt)
+	}
+
+	if _, errWrite := w.Write(plain); errWrite != nil {
+		return nil, fmt.Errorf("error in encrypting export: %w", errWrite)
+	}
+
+	if errClose := w.Close(); errClose != nil {
+		return nil, fmt.Errorf("error in encrypting export: %w", errClose)
+	}
+
+	// armor writes its footer on close
+	if errClose := dst.Close(); errClose != nil {
+		return nil, fmt.Errorf("error in encrypting export: %w", errClose)
+	}
+
+	return out.Bytes(), nil
+}
+
+// nopCloser - is io.WriteCloser of buffer, as binary age file has nothing to write on close.
+type nopCloser struct {
+	io.Writer
+}
+
+func (nopCloser) Close() error {
+	return nil
+}
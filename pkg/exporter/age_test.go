@@ -0,0 +1,94 @@
+package exporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"secretKeeper/pkg/backup"
+)
+
+// ageBackup - is backup encrypted by age export.
+var ageBackup = backup.Backup{
+	CreatedAt: fixtureCreated,
+	Secrets: []backup.Secret{
+		{
+			Title: "Mail", Type: 1, Content: []byte(`{"login":"alice"}`), CreatedAt: fixtureCreated,
+			UpdatedAt: fixtureCreated, ExpiresAt: &fixtureExpires, RotateEveryDays: 90,
+		},
+		{
+			Title: "Codes", Type: 2, Content: []byte(`{"text":"one"}`), CreatedAt: fixtureCreated,
+			UpdatedAt: fixtureCreated,
+		},
+	},
+}
+
+// decryptAge - decrypts age export by identity, as `age -d -i key.txt` does.
+func decryptAge(t *testing.T, data []byte, armored bool, identity age.Identity) (backup.Backup, error) {
+	t.Helper()
+
+	var src io.Reader = bytes.NewReader(data)
+	if armored {
+		src = armor.NewReader(src)
+	}
+
+	r, err := age.Decrypt(src, identity)
+	if err != nil {
+		return backup.Backup{}, err
+	}
+
+	var b backup.Backup
+
+	require.NoError(t, json.NewDecoder(r).Decode(&b))
+
+	return b, nil
+}
+
+func TestWrite_Age(t *testing.T) {
+	alice, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+
+	bob, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+
+	mallory, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+
+	want := ageBackup
+	want.Version = backup.Version
+
+	for _, armored := range []bool{false, true} {
+		data, errWrite := Write(FormatAge, nil, Options{
+			Backup:     ageBackup,
+			Recipients: []string{alice.Recipient().String(), " " + bob.Recipient().String() + "\n"},
+			Armor:      armored,
+		})
+		require.NoError(t, errWrite)
+
+		assert.Equal(t, armored, bytes.HasPrefix(data, []byte(armor.Header)), "armored %v", armored)
+
+		// every recipient decrypts the export
+		for _, identity := range []age.Identity{alice, bob} {
+			got, errDecrypt := decryptAge(t, data, armored, identity)
+			require.NoError(t, errDecrypt)
+			assert.Equal(t, want, got)
+		}
+
+		_, err = decryptAge(t, data, armored, mallory)
+		assert.Error(t, err, "export must not be decrypted by other identity")
+	}
+}
+
+func TestWrite_AgeRecipients(t *testing.T) {
+	_, err := Write(FormatAge, nil, Options{Backup: ageBackup})
+	assert.ErrorIs(t, err, ErrRecipient)
+
+	_, err = Write(FormatAge, nil, Options{Backup: ageBackup, Recipients: []string{"ssh-ed25519 AAAA"}})
+	assert.ErrorContains(t, err, "is not an age public key")
+}
@@ -0,0 +1,176 @@
+package exporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// bitwarden item and field types.
+const (
+	bitwardenItemLogin = 1
+	bitwardenItemNote  = 2
+	bitwardenItemCard  = 3
+
+	bitwardenFieldText   = 0
+	bitwardenFieldHidden = 1
+)
+
+// bitwardenExport - is unencrypted JSON export of Bitwarden vault, it's imported by Bitwarden as "Bitwarden (json)".
+type bitwardenExport struct {
+	Encrypted bool            `json:"encrypted"`
+	Folders   []struct{}      `json:"folders"`
+	Items     []bitwardenItem `json:"items"`
+}
+
+type bitwardenItem struct {
+	OrganizationID *string          `json:"organizationId"`
+	FolderID       *string          `json:"folderId"`
+	Type           int              `json:"type"`
+	Reprompt       int              `json:"reprompt"`
+	Name           string           `json:"name"`
+	Notes          *string          `json:"notes"`
+	Favorite       bool             `json:"favorite"`
+	Fields         []bitwardenField `json:"fields,omitempty"`
+	Login          *bitwardenLogin  `json:"login,omitempty"`
+	SecureNote     *struct {
+		Type int `json:"type"`
+	} `json:"secureNote,omitempty"`
+	Card          *bitwardenCard `json:"card,omitempty"`
+	CollectionIDs []string       `json:"collectionIds"`
+	CreationDate  *time.Time     `json:"creationDate,omitempty"`
+	RevisionDate  *time.Time     `json:"revisionDate,omitempty"`
+}
+
+type bitwardenField struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+	Type  int    `json:"type"`
+}
+
+type bitwardenLogin struct {
+	URIs     []bitwardenURI `json:"uris,omitempty"`
+	Username *string        `json:"username"`
+	Password *string        `json:"password"`
+	TOTP     *string        `json:"totp"`
+}
+
+type bitwardenURI struct {
+	Match *int   `json:"match"`
+	URI   string `json:"uri"`
+}
+
+type bitwardenCard struct {
+	CardholderName *string `json:"cardholderName"`
+	Brand          *string `json:"brand"`
+	Number         *string `json:"number"`
+	ExpMonth       *string `json:"expMonth"`
+	ExpYear        *string `json:"expYear"`
+	Code           *string `json:"code"`
+}
+
+// optional - is optional string of export, empty value is null.
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+
+	return &s
+}
+
+// optionalTime - is optional time of export, zero time is omitted.
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+
+	t = t.UTC()
+
+	return &t
+}
+
+// writeBitwarden - writes unencrypted JSON export of Bitwarden, text entries become secure notes and binary entries
+// are skipped, as attachments aren't a part of the export.
+func writeBitwarden(entries []Entry) ([]byte, error) {
+	export := bitwardenExport{Folders: []struct{}{}, Items: make([]bitwardenItem, 0, len(entries))}
+
+	for _, entry := range entries {
+		item := bitwardenItem{
+			Name:         entry.Title,
+			Notes:        optional(entry.Notes),
+			CreationDate: optionalTime(entry.CreatedAt),
+			RevisionDate: optionalTime(entry.UpdatedAt),
+		}
+
+		for _, f := range entry.Fields {
+			fieldType := bitwardenFieldText
+			if f.Hidden {
+				fieldType = bitwardenFieldHidden
+			}
+
+			item.Fields = append(item.Fields, bitwardenField{Name: f.Name, Value: f.Value, Type: fieldType})
+		}
+
+		switch entry.Kind {
+		case KindLogin:
+			item.Type = bitwardenItemLogin
+			item.Login = &bitwardenLogin{
+				Username: optional(entry.Login),
+				Password: optional(entry.Password),
+				TOTP:     optional(entry.TOTP),
+			}
+
+			if entry.URL != "" {
+				item.Login.URIs = []bitwardenURI{{URI: entry.URL}}
+			}
+		case KindCard:
+			month, year := splitDue(entry.Due)
+			item.Type = bitwardenItemCard
+			item.Card = &bitwardenCard{
+				Number:   optional(entry.CardNumber),
+				Code:     optional(entry.CVV),
+				ExpMonth: optional(month),
+				ExpYear:  optional(year),
+			}
+		case KindText:
+			item.Type = bitwardenItemNote
+			item.SecureNote = &struct {
+				Type int `json:"type"`
+			}{}
+		case KindBinary:
+			continue
+		default:
+			return nil, fmt.Errorf("entry %q has unknown kind %q", entry.Title, entry.Kind)
+		}
+
+		export.Items = append(export.Items, item)
+	}
+
+	data, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("error in encoding Bitwarden export: %w", err)
+	}
+
+	return data, nil
+}
+
+// splitDue - splits MM/YY or MM/YYYY expiration of card into month without leading zero and four digit year, as
+// Bitwarden keeps them, unknown format is kept as month.
+func splitDue(due string) (string, string) {
+	month, year, ok := strings.Cut(strings.TrimSpace(due), "/")
+	if !ok {
+		return due, ""
+	}
+
+	if m, err := strconv.Atoi(month); err == nil {
+		month = strconv.Itoa(m)
+	}
+
+	if len(year) == 2 {
+		year = "20" + year
+	}
+
+	return month, year
+}
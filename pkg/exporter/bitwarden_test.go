@@ -0,0 +1,61 @@
+package exporter
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"secretKeeper/pkg/importer"
+)
+
+func TestWrite_BitwardenRoundTrip(t *testing.T) {
+	data, err := Write(FormatBitwarden, fixtureEntries, Options{})
+	require.NoError(t, err)
+
+	entries, err := importer.Parse("bitwarden", data, importer.Options{})
+	require.NoError(t, err)
+
+	// attachments aren't a part of the export, custom fields and TOTP come back as notes
+	assert.Equal(t, []importer.Entry{
+		{
+			Kind: importer.KindLogin, Title: "Mail", Login: "alice", Password: "p@ss", URL: "https://mail.example.com",
+			Notes: "main account\nPIN: 1234\nTOTP: otpauth://totp/Mail?secret=JBSWY3DPEHPK3PXP",
+		},
+		{Kind: importer.KindCard, Title: "Visa", CardNumber: "4111111111111111", CVV: "123", Due: "07/31"},
+		{Kind: importer.KindText, Title: "Codes", Notes: "one\ntwo"},
+	}, entries)
+
+	var export bitwardenExport
+	require.NoError(t, json.Unmarshal(data, &export))
+
+	require.Len(t, export.Items, 3)
+	assert.Equal(t, fixtureCreated, *export.Items[0].CreationDate)
+	assert.Equal(t, bitwardenFieldHidden, export.Items[0].Fields[0].Type)
+	assert.Nil(t, export.Items[2].CreationDate, "zero time is omitted")
+}
+
+func TestWrite_BitwardenUnknownKind(t *testing.T) {
+	_, err := Write(FormatBitwarden, []Entry{{Kind: "identity", Title: "Me"}}, Options{})
+	assert.ErrorContains(t, err, `unknown kind "identity"`)
+}
+
+func TestSplitDue(t *testing.T) {
+	tests := []struct {
+		due       string
+		wantMonth string
+		wantYear  string
+	}{
+		{due: "07/31", wantMonth: "7", wantYear: "2031"},
+		{due: "12/2030", wantMonth: "12", wantYear: "2030"},
+		{due: " 01/29 ", wantMonth: "1", wantYear: "2029"},
+		{due: "soon", wantMonth: "soon"},
+		{due: ""},
+	}
+	for _, tt := range tests {
+		month, year := splitDue(tt.due)
+		assert.Equal(t, tt.wantMonth, month, "month of %q", tt.due)
+		assert.Equal(t, tt.wantYear, year, "year of %q", tt.due)
+	}
+}
@@ -0,0 +1,92 @@
+// Package exporter writes secrets in formats of other tools, so secrets may be moved out of SecretKeeper.
+//
+// Age export is JSON of backup encrypted to age recipients, Bitwarden and KeePass exports are unencrypted files
+// which Bitwarden, KeePass and KeePassXC import.
+package exporter
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"secretKeeper/pkg/backup"
+)
+
+// Kind - is kind of entry, it defines which fields of Entry are set.
+type Kind string
+
+const (
+	KindLogin  Kind = "login"
+	KindText   Kind = "text"
+	KindBinary Kind = "binary"
+	KindCard   Kind = "card"
+)
+
+// Formats of export.
+const (
+	FormatAge       = "age"
+	FormatBitwarden = "bitwarden"
+	FormatKeePass   = "keepass"
+)
+
+// Formats - are names of formats Write accepts.
+var Formats = []string{FormatAge, FormatBitwarden, FormatKeePass}
+
+var (
+	ErrFormat    = errors.New("format must be age, bitwarden or keepass")
+	ErrRecipient = errors.New("age export needs at least one recipient")
+)
+
+// Field - is a named value of entry which has no field of its own.
+type Field struct {
+	Name  string
+	Value string
+	// Hidden - value is masked by the tool, like a password.
+	Hidden bool
+}
+
+// Entry - is a secret mapped onto fields common to password managers.
+type Entry struct {
+	Kind       Kind
+	Title      string
+	Login      string
+	Password   string
+	URL        string
+	Notes      string
+	CardNumber string
+	CVV        string
+	// Due - is expiration of card in MM/YY or MM/YYYY format.
+	Due string
+	// TOTP - is otpauth:// URI of one-time passwords.
+	TOTP   string
+	Fields []Field
+	// FileName and Data - are attachment of entry, binary entry has nothing else.
+	FileName  string
+	Data      []byte
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	ExpiresAt *time.Time
+}
+
+// Options - are parameters of export, Backup and Recipients are used only by age format.
+type Options struct {
+	Backup     backup.Backup
+	Recipients []string
+	// Armor - age export is PEM-like text instead of binary file.
+	Armor bool
+}
+
+// Write - returns content of export file in format, entries are written by Bitwarden and KeePass formats and backup
+// of options by age format.
+func Write(format string, entries []Entry, opts Options) ([]byte, error) {
+	switch format {
+	case FormatAge:
+		return writeAge(opts.Backup, opts.Recipients, opts.Armor)
+	case FormatBitwarden:
+		return writeBitwarden(entries)
+	case FormatKeePass:
+		return writeKeePass(entries, time.Now())
+	default:
+		return nil, fmt.Errorf("%w, got %q", ErrFormat, format)
+	}
+}
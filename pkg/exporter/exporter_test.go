@@ -0,0 +1,31 @@
+package exporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	fixtureCreated = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	fixtureExpires = time.Date(2025, 6, 7, 8, 9, 10, 0, time.UTC)
+)
+
+// fixtureEntries - are entries of every kind, they are read back by pkg/importer.
+var fixtureEntries = []Entry{
+	{
+		Kind: KindLogin, Title: "Mail", Login: "alice", Password: "p@ss", URL: "https://mail.example.com",
+		Notes: "main account", TOTP: "otpauth://totp/Mail?secret=JBSWY3DPEHPK3PXP",
+		Fields:    []Field{{Name: "PIN", Value: "1234", Hidden: true}},
+		CreatedAt: fixtureCreated, UpdatedAt: fixtureCreated, ExpiresAt: &fixtureExpires,
+	},
+	{Kind: KindCard, Title: "Visa", CardNumber: "4111111111111111", CVV: "123", Due: "07/31"},
+	{Kind: KindText, Title: "Codes", Notes: "one\ntwo"},
+	{Kind: KindBinary, Title: "Key", FileName: "id.pem", Data: []byte("-----BEGIN KEY-----")},
+}
+
+func TestWrite_UnknownFormat(t *testing.T) {
+	_, err := Write("csv", fixtureEntries, Options{})
+	assert.ErrorIs(t, err, ErrFormat)
+}
@@ -0,0 +1,191 @@
+package exporter
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"time"
+)
+
+// keepassTime - is layout of times in KeePass XML.
+const keepassTime = "2006-01-02T15:04:05Z"
+
+// keepassFile - is XML of KeePass database, it's imported by KeePass as "KeePass XML (2.x)" and by KeePassXC.
+type keepassFile struct {
+	XMLName xml.Name `xml:"KeePassFile"`
+	Meta    struct {
+		Generator    string          `xml:"Generator"`
+		DatabaseName string          `xml:"DatabaseName"`
+		Binaries     []keepassBinary `xml:"Binaries>Binary,omitempty"`
+	} `xml:"Meta"`
+	Root struct {
+		Group keepassGroup `xml:"Group"`
+	} `xml:"Root"`
+}
+
+type keepassBinary struct {
+	ID         int    `xml:"ID,attr"`
+	Compressed string `xml:"Compressed,attr"`
+	// Content - is base64 of attachment.
+	Content string `xml:",chardata"`
+}
+
+type keepassGroup struct {
+	UUID    string         `xml:"UUID"`
+	Name    string         `xml:"Name"`
+	Times   keepassTimes   `xml:"Times"`
+	Entries []keepassEntry `xml:"Entry"`
+}
+
+type keepassTimes struct {
+	CreationTime         string `xml:"CreationTime"`
+	LastModificationTime string `xml:"LastModificationTime"`
+	ExpiryTime           string `xml:"ExpiryTime"`
+	Expires              string `xml:"Expires"`
+}
+
+type keepassEntry struct {
+	UUID     string               `xml:"UUID"`
+	Times    keepassTimes         `xml:"Times"`
+	Strings  []keepassString      `xml:"String"`
+	Binaries []keepassBinaryValue `xml:"Binary,omitempty"`
+}
+
+type keepassString struct {
+	Key   string `xml:"Key"`
+	Value struct {
+		ProtectInMemory string `xml:"ProtectInMemory,attr,omitempty"`
+		Value           string `xml:",chardata"`
+	} `xml:"Value"`
+}
+
+type keepassBinaryValue struct {
+	Key   string `xml:"Key"`
+	Value struct {
+		Ref int `xml:"Ref,attr"`
+	} `xml:"Value"`
+}
+
+// writeKeePass - writes unencrypted XML of KeePass database with a group of every entry, card number, CVV and due
+// become custom fields, TOTP becomes "otp" field KeePassXC generates codes of and attachments are kept in database.
+func writeKeePass(entries []Entry, now time.Time) ([]byte, error) {
+	var file keepassFile
+
+	file.Meta.Generator = "SecretKeeper"
+	file.Meta.DatabaseName = "SecretKeeper"
+
+	groupUUID, err := newUUID()
+	if err != nil {
+		return nil, err
+	}
+
+	file.Root.Group = keepassGroup{UUID: groupUUID, Name: "SecretKeeper", Times: times(now, now, nil)}
+
+	for _, entry := range entries {
+		id, errUUID := newUUID()
+		if errUUID != nil {
+			return nil, errUUID
+		}
+
+		created, updated := entry.CreatedAt, entry.UpdatedAt
+		if created.IsZero() {
+			created = now
+		}
+
+		if updated.IsZero() {
+			updated = created
+		}
+
+		e := keepassEntry{UUID: id, Times: times(created, updated, entry.ExpiresAt)}
+
+		add := func(key, value string, protect bool) {
+			s := keepassString{Key: key}
+			s.Value.Value = value
+
+			if protect {
+				s.Value.ProtectInMemory = "True"
+			}
+
+			e.Strings = append(e.Strings, s)
+		}
+
+		add("Title", entry.Title, false)
+		add("UserName", entry.Login, false)
+		add("Password", entry.Password, true)
+		add("URL", entry.URL, false)
+		add("Notes", entry.Notes, false)
+
+		switch entry.Kind {
+		case KindLogin, KindText:
+		case KindCard:
+			add("Card number", entry.CardNumber, true)
+			add("CVV", entry.CVV, true)
+			add("Due", entry.Due, false)
+		case KindBinary:
+			if entry.FileName == "" {
+				return nil, fmt.Errorf("binary entry %q has no file name", entry.Title)
+			}
+		default:
+			return nil, fmt.Errorf("entry %q has unknown kind %q", entry.Title, entry.Kind)
+		}
+
+		if entry.TOTP != "" {
+			add("otp", entry.TOTP, true)
+		}
+
+		for _, f := range entry.Fields {
+			add(f.Name, f.Value, f.Hidden)
+		}
+
+		if entry.FileName != "" {
+			ref := keepassBinaryValue{Key: entry.FileName}
+			ref.Value.Ref = len(file.Meta.Binaries)
+
+			file.Meta.Binaries = append(file.Meta.Binaries, keepassBinary{
+				ID: ref.Value.Ref, Compressed: "False", Content: base64.StdEncoding.EncodeToString(entry.Data),
+			})
+			e.Binaries = append(e.Binaries, ref)
+		}
+
+		file.Root.Group.Entries = append(file.Root.Group.Entries, e)
+	}
+
+	data, errXML := xml.MarshalIndent(file, "", "\t")
+	if errXML != nil {
+		return nil, fmt.Errorf("error in encoding KeePass export: %w", errXML)
+	}
+
+	return append([]byte(xml.Header), data...), nil
+}
+
+// times - returns times of entry, entry without expiry never expires.
+func times(created, updated time.Time, expires *time.Time) keepassTimes {
+	t := keepassTimes{
+		CreationTime:         created.UTC().Format(keepassTime),
+		LastModificationTime: updated.UTC().Format(keepassTime),
+		ExpiryTime:           updated.UTC().Format(keepassTime),
+		Expires:              "False",
+	}
+
+	if expires != nil {
+		t.ExpiryTime, t.Expires = expires.UTC().Format(keepassTime), "True"
+	}
+
+	return t
+}
+
+// newUUID - returns base64 of random UUID of group or entry.
+func newUUID() (string, error) {
+	id := make([]byte, 16)
+	if _, err := io.ReadFull(rand.Reader, id); err != nil {
+		return "", fmt.Errorf("error in generating UUID: %w", err)
+	}
+
+	// version 4 and variant bits of RFC 4122
+	id[6] = id[6]&0x0f | 0x40
+	id[8] = id[8]&0x3f | 0x80
+
+	return base64.StdEncoding.EncodeToString(id), nil
+}
@@ -0,0 +1,77 @@
+package exporter
+
+import (
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"secretKeeper/pkg/importer"
+)
+
+func TestWrite_KeePassRoundTrip(t *testing.T) {
+	data, err := Write(FormatKeePass, fixtureEntries, Options{})
+	require.NoError(t, err)
+
+	entries, err := importer.Parse("keepass", data, importer.Options{})
+	require.NoError(t, err)
+
+	// KeePass has no cards, so card fields come back as notes of text entry, attachment becomes binary entry
+	assert.Equal(t, []importer.Entry{
+		{
+			Kind: importer.KindLogin, Title: "Mail", Login: "alice", Password: "p@ss", URL: "https://mail.example.com",
+			Notes: "main account\nPIN: 1234\notp: otpauth://totp/Mail?secret=JBSWY3DPEHPK3PXP",
+		},
+		{Kind: importer.KindText, Title: "Visa", Notes: "CVV: 123\nCard number: 4111111111111111\nDue: 07/31"},
+		{Kind: importer.KindText, Title: "Codes", Notes: "one\ntwo"},
+		{
+			Kind: importer.KindBinary, Title: "Key id.pem", FileName: "id.pem",
+			Data: []byte("-----BEGIN KEY-----"),
+		},
+	}, entries)
+}
+
+func TestWrite_KeePassTimes(t *testing.T) {
+	now := time.Date(2024, 3, 4, 5, 6, 7, 0, time.UTC)
+
+	data, err := writeKeePass(fixtureEntries[:3], now)
+	require.NoError(t, err)
+
+	var file keepassFile
+	require.NoError(t, xml.Unmarshal(data, &file))
+
+	entries := file.Root.Group.Entries
+	require.Len(t, entries, 3)
+
+	assert.Equal(t, keepassTimes{
+		CreationTime: "2024-01-02T03:04:05Z", LastModificationTime: "2024-01-02T03:04:05Z",
+		ExpiryTime: "2025-06-07T08:09:10Z", Expires: "True",
+	}, entries[0].Times)
+
+	// entry without times is created now and never expires
+	assert.Equal(t, keepassTimes{
+		CreationTime: "2024-03-04T05:06:07Z", LastModificationTime: "2024-03-04T05:06:07Z",
+		ExpiryTime: "2024-03-04T05:06:07Z", Expires: "False",
+	}, entries[1].Times)
+
+	assert.NotEqual(t, entries[0].UUID, entries[1].UUID)
+}
+
+func TestWrite_KeePassErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		entry   Entry
+		wantErr string
+	}{
+		{name: "binary without file name", entry: Entry{Kind: KindBinary, Title: "Key"}, wantErr: "has no file name"},
+		{name: "unknown kind", entry: Entry{Kind: "identity", Title: "Me"}, wantErr: `unknown kind "identity"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Write(FormatKeePass, []Entry{tt.entry}, Options{})
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,235 @@
+package importer
+
+import (
+	"encoding/binary"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+// argon2 constants of RFC 9106, golang.org/x/crypto/argon2 implements Argon2i and Argon2id only, while KeePass
+// uses Argon2d by default.
+const (
+	argon2Version    = 0x13
+	argon2dType      = 0
+	argon2BlockWords = 128
+	argon2SyncPoints = 4
+)
+
+type argon2Block [argon2BlockWords]uint64
+
+// argon2dKey - derives key by Argon2d, memory is in KiB.
+func argon2dKey(password, salt, secret, data []byte, time, memory, threads, keyLen uint32) []byte {
+	h0 := argon2InitHash(password, salt, secret, data, time, memory, threads, keyLen)
+
+	memory = memory / (argon2SyncPoints * threads) * (argon2SyncPoints * threads)
+	if memory < 2*argon2SyncPoints*threads {
+		memory = 2 * argon2SyncPoints * threads
+	}
+
+	lanes := memory / threads
+	segments := lanes / argon2SyncPoints
+	blocks := make([]argon2Block, memory)
+
+	var buf [1024]byte
+
+	for lane := uint32(0); lane < threads; lane++ {
+		binary.LittleEndian.PutUint32(h0[blake2b.Size+4:], lane)
+
+		for i := uint32(0); i < 2; i++ {
+			binary.LittleEndian.PutUint32(h0[blake2b.Size:], i)
+			argon2Hash(buf[:], h0[:])
+
+			for j := range blocks[lane*lanes+i] {
+				blocks[lane*lanes+i][j] = binary.LittleEndian.Uint64(buf[j*8:])
+			}
+		}
+	}
+
+	// lanes are filled one after another, parallel filling gives the same result
+	for pass := uint32(0); pass < time; pass++ {
+		for slice := uint32(0); slice < argon2SyncPoints; slice++ {
+			for lane := uint32(0); lane < threads; lane++ {
+				index := uint32(0)
+				if pass == 0 && slice == 0 {
+					index = 2
+				}
+
+				offset := lane*lanes + slice*segments + index
+				for ; index < segments; index, offset = index+1, offset+1 {
+					prev := offset - 1
+					if index == 0 && slice == 0 {
+						prev += lanes
+					}
+
+					ref := argon2RefIndex(blocks[prev][0], lanes, segments, threads, pass, slice, lane, index)
+					argon2Compress(&blocks[offset], &blocks[prev], &blocks[ref], pass > 0)
+				}
+			}
+		}
+	}
+
+	final := blocks[memory-1]
+	for lane := uint32(0); lane < threads-1; lane++ {
+		for i, v := range blocks[lane*lanes+lanes-1] {
+			final[i] ^= v
+		}
+	}
+
+	for i, v := range final {
+		binary.LittleEndian.PutUint64(buf[i*8:], v)
+	}
+
+	key := make([]byte, keyLen)
+	argon2Hash(key, buf[:])
+
+	return key
+}
+
+// argon2InitHash - returns H0 of RFC 9106 with room for block and lane numbers.
+func argon2InitHash(password, salt, secret, data []byte, time, memory, threads, keyLen uint32) [blake2b.Size + 8]byte {
+	var h0 [blake2b.Size + 8]byte
+
+	h, _ := blake2b.New512(nil)
+
+	for _, v := range []uint32{threads, keyLen, memory, time, argon2Version, argon2dType} {
+		_ = binary.Write(h, binary.LittleEndian, v)
+	}
+
+	for _, b := range [][]byte{password, salt, secret, data} {
+		_ = binary.Write(h, binary.LittleEndian, uint32(len(b)))
+		h.Write(b)
+	}
+
+	h.Sum(h0[:0])
+
+	return h0
+}
+
+// argon2Hash - is variable length hash H' of RFC 9106, out is filled completely.
+func argon2Hash(out []byte, in []byte) {
+	var length [4]byte
+
+	binary.LittleEndian.PutUint32(length[:], uint32(len(out)))
+
+	if len(out) <= blake2b.Size {
+		h, _ := blake2b.New(len(out), nil)
+		h.Write(length[:])
+		h.Write(in)
+		h.Sum(out[:0])
+
+		return
+	}
+
+	h, _ := blake2b.New512(nil)
+	h.Write(length[:])
+	h.Write(in)
+
+	var v [blake2b.Size]byte
+
+	h.Sum(v[:0])
+
+	n := copy(out, v[:32])
+	for len(out)-n > blake2b.Size {
+		v = blake2b.Sum512(v[:])
+		n += copy(out[n:], v[:32])
+	}
+
+	last, _ := blake2b.New(len(out)-n, nil)
+	last.Write(v[:])
+	last.Sum(out[n:n])
+}
+
+// argon2RefIndex - returns index of the reference block, Argon2d takes randomness from the previous block.
+func argon2RefIndex(random uint64, lanes, segments, threads, pass, slice, lane, index uint32) uint32 {
+	refLane := uint32(random>>32) % threads
+	if pass == 0 && slice == 0 {
+		refLane = lane
+	}
+
+	area, start := 3*segments, ((slice+1)%argon2SyncPoints)*segments
+	if lane == refLane {
+		area += index
+	}
+
+	if pass == 0 {
+		area, start = slice*segments, 0
+		if slice == 0 || lane == refLane {
+			area += index
+		}
+	}
+
+	if index == 0 || lane == refLane {
+		area--
+	}
+
+	p := random & 0xFFFFFFFF
+	p = (p * p) >> 32
+	p = (p * uint64(area)) >> 32
+
+	return refLane*lanes + uint32((uint64(start)+uint64(area)-(p+1))%uint64(lanes))
+}
+
+// argon2Compress - is compression function G, result is XORed into out on passes after the first.
+func argon2Compress(out, x, y *argon2Block, xor bool) {
+	var r, z argon2Block
+
+	for i := range r {
+		r[i] = x[i] ^ y[i]
+	}
+
+	z = r
+
+	for i := 0; i < 8; i++ {
+		argon2Permute(&z, i*16, 2)
+	}
+
+	for i := 0; i < 8; i++ {
+		argon2Permute(&z, i*2, 16)
+	}
+
+	for i := range z {
+		if xor {
+			out[i] ^= r[i] ^ z[i]
+		} else {
+			out[i] = r[i] ^ z[i]
+		}
+	}
+}
+
+// argon2Permute - applies permutation P to 8 pairs of words of block, pairs start at base and are step words
+// apart, so rows of the block are permuted with step 2 and its columns with step 16.
+func argon2Permute(b *argon2Block, base, step int) {
+	var idx [16]int
+
+	for i := 0; i < 8; i++ {
+		idx[2*i] = base + i*step
+		idx[2*i+1] = base + i*step + 1
+	}
+
+	v := func(i int) *uint64 { return &b[idx[i]] }
+
+	argon2G(v(0), v(4), v(8), v(12))
+	argon2G(v(1), v(5), v(9), v(13))
+	argon2G(v(2), v(6), v(10), v(14))
+	argon2G(v(3), v(7), v(11), v(15))
+	argon2G(v(0), v(5), v(10), v(15))
+	argon2G(v(1), v(6), v(11), v(12))
+	argon2G(v(2), v(7), v(8), v(13))
+	argon2G(v(3), v(4), v(9), v(14))
+}
+
+// argon2G - is BlaMka mixing function GB of RFC 9106.
+func argon2G(a, b, c, d *uint64) {
+	*a += *b + 2*uint64(uint32(*a))*uint64(uint32(*b))
+	*d ^= *a
+	*d = *d>>32 | *d<<32
+	*c += *d + 2*uint64(uint32(*c))*uint64(uint32(*d))
+	*b ^= *c
+	*b = *b>>24 | *b<<40
+	*a += *b + 2*uint64(uint32(*a))*uint64(uint32(*b))
+	*d ^= *a
+	*d = *d>>16 | *d<<48
+	*c += *d + 2*uint64(uint32(*c))*uint64(uint32(*d))
+	*b ^= *c
+	*b = *b>>63 | *b<<1
+}
@@ -0,0 +1,42 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArgon2dKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		password []byte
+		salt     []byte
+		secret   []byte
+		data     []byte
+		time     uint32
+		memory   uint32
+		threads  uint32
+		want     string
+	}{
+		{
+			name:     "Argon2d test vector of RFC 9106",
+			password: bytes.Repeat([]byte{0x01}, 32),
+			salt:     bytes.Repeat([]byte{0x02}, 16),
+			secret:   bytes.Repeat([]byte{0x03}, 8),
+			data:     bytes.Repeat([]byte{0x04}, 12),
+			time:     3,
+			memory:   32,
+			threads:  4,
+			want:     "512b391b6f1162975371d30919734294f868e3be3984f3c1a13a4db9fabe4acb",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := argon2dKey(tt.password, tt.salt, tt.secret, tt.data, tt.time, tt.memory, tt.threads, 32)
+
+			assert.Equal(t, tt.want, hex.EncodeToString(got))
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// bitwarden item types.
+const (
+	bitwardenLogin    = 1
+	bitwardenNote     = 2
+	bitwardenCard     = 3
+	bitwardenIdentity = 4
+)
+
+// bitwardenExport - is unencrypted JSON export of Bitwarden vault.
+type bitwardenExport struct {
+	Encrypted bool `json:"encrypted"`
+	Folders   []struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"folders"`
+	Items []bitwardenItem `json:"items"`
+}
+
+type bitwardenItem struct {
+	Type     int     `json:"type"`
+	Name     string  `json:"name"`
+	Notes    *string `json:"notes"`
+	FolderID *string `json:"folderId"`
+	Fields   []struct {
+		Name  string  `json:"name"`
+		Value *string `json:"value"`
+	} `json:"fields"`
+	Login *struct {
+		Username *string `json:"username"`
+		Password *string `json:"password"`
+		Totp     *string `json:"totp"`
+		URIs     []struct {
+			URI *string `json:"uri"`
+		} `json:"uris"`
+	} `json:"login"`
+	Card *struct {
+		CardholderName *string `json:"cardholderName"`
+		Brand          *string `json:"brand"`
+		Number         *string `json:"number"`
+		ExpMonth       *string `json:"expMonth"`
+		ExpYear        *string `json:"expYear"`
+		Code           *string `json:"code"`
+	} `json:"card"`
+	Identity map[string]*string `json:"identity"`
+}
+
+// str - dereferences optional string of export.
+func str(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
+
+// parseBitwarden - reads JSON export of Bitwarden, identities and secure notes become text entries.
+func parseBitwarden(data []byte) ([]Entry, error) {
+	var export bitwardenExport
+	if err := json.Unmarshal(data, &export); err != nil {
+		return nil, fmt.Errorf("error in reading Bitwarden export: %w", err)
+	}
+
+	if export.Encrypted {
+		return nil, ErrEncrypted
+	}
+
+	folders := make(map[string]string, len(export.Folders))
+	for _, f := range export.Folders {
+		folders[f.ID] = f.Name
+	}
+
+	entries := make([]Entry, 0, len(export.Items))
+
+	for _, item := range export.Items {
+		entry := Entry{Title: item.Name, Folder: folders[str(item.FolderID)]}
+
+		fields := make([]field, 0, len(item.Fields))
+		for _, f := range item.Fields {
+			fields = append(fields, field{name: f.Name, value: str(f.Value)})
+		}
+
+		switch {
+		case item.Type == bitwardenLogin && item.Login != nil:
+			entry.Kind = KindLogin
+			entry.Login, entry.Password = str(item.Login.Username), str(item.Login.Password)
+
+			for i, u := range item.Login.URIs {
+				if i == 0 {
+					entry.URL = str(u.URI)
+
+					continue
+				}
+
+				fields = append(fields, field{name: "URL", value: str(u.URI)})
+			}
+
+			fields = append(fields, field{name: "TOTP", value: str(item.Login.Totp)})
+		case item.Type == bitwardenCard && item.Card != nil:
+			entry.Kind = KindCard
+			entry.CardNumber, entry.CVV = str(item.Card.Number), str(item.Card.Code)
+			entry.Due = cardDue(str(item.Card.ExpMonth), str(item.Card.ExpYear))
+
+			fields = append(fields,
+				field{name: "Cardholder", value: str(item.Card.CardholderName)},
+				field{name: "Brand", value: str(item.Card.Brand)},
+			)
+		case item.Type == bitwardenIdentity:
+			entry.Kind = KindText
+
+			identity := make(map[string]string, len(item.Identity))
+			for k, v := range item.Identity {
+				identity[k] = str(v)
+			}
+
+			identityFields := make([]field, 0, len(identity))
+			for _, k := range sortedKeys(identity) {
+				identityFields = append(identityFields, field{name: k, value: identity[k]})
+			}
+
+			fields = append(identityFields, fields...)
+		case item.Type == bitwardenNote:
+			entry.Kind = KindText
+		default:
+			entry.Kind = KindText
+			fields = append(fields, field{name: "Type", value: strconv.Itoa(item.Type)})
+		}
+
+		entry.Notes = joinNotes(str(item.Notes), fields)
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,72 @@
+package importer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const bitwardenFixture = `{
+  "encrypted": false,
+  "folders": [{"id": "f1", "name": "Work"}],
+  "items": [
+    {
+      "type": 1, "name": "Mail", "folderId": "f1", "notes": "main account",
+      "fields": [{"name": "PIN", "value": "1234"}, {"name": "Empty", "value": null}],
+      "login": {
+        "username": "alice", "password": "p@ss", "totp": "otpauth://totp/mail?secret=JBSWY3DP",
+        "uris": [{"uri": "https://mail.example.com"}, {"uri": "https://m.example.com"}]
+      }
+    },
+    {
+      "type": 3, "name": "Visa", "folderId": null, "notes": null,
+      "card": {"cardholderName": "Alice", "brand": "Visa", "number": "4111111111111111",
+        "expMonth": "7", "expYear": "2031", "code": "123"}
+    },
+    {"type": 2, "name": "Recovery codes", "notes": "one\ntwo"},
+    {"type": 4, "name": "Passport", "identity": {"firstName": "Alice", "lastName": "Smith", "middleName": null}},
+    {"type": 1, "name": "", "login": {"username": "bob", "uris": []}}
+  ]
+}`
+
+func TestParse_Bitwarden(t *testing.T) {
+	entries, err := Parse("bitwarden", []byte(bitwardenFixture), Options{})
+	require.NoError(t, err)
+
+	assert.Equal(t, []Entry{
+		{
+			Kind: KindLogin, Title: "Mail", Folder: "Work", Login: "alice", Password: "p@ss",
+			URL:   "https://mail.example.com",
+			Notes: "main account\nPIN: 1234\nURL: https://m.example.com\nTOTP: otpauth://totp/mail?secret=JBSWY3DP",
+		},
+		{
+			Kind: KindCard, Title: "Visa", CardNumber: "4111111111111111", CVV: "123", Due: "07/31",
+			Notes: "Cardholder: Alice\nBrand: Visa",
+		},
+		{Kind: KindText, Title: "Recovery codes", Notes: "one\ntwo"},
+		{Kind: KindText, Title: "Passport", Notes: "firstName: Alice\nlastName: Smith"},
+		{Kind: KindLogin, Title: "bob", Login: "bob"},
+	}, entries)
+}
+
+func TestParse_BitwardenErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		err  error
+	}{
+		{name: "encrypted export", data: `{"encrypted": true, "items": []}`, err: ErrEncrypted},
+		{name: "not JSON", data: `name,password`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse("bitwarden", []byte(tt.data), Options{})
+			require.Error(t, err)
+
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+			}
+		})
+	}
+}
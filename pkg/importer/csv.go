@@ -0,0 +1,121 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// csvColumns - are header names of columns recognised for fields of entry, they cover CSV exports of Bitwarden,
+// LastPass and browsers.
+var csvColumns = map[string][]string{
+	"title":    {"title", "name"},
+	"folder":   {"folder", "group", "grouping"},
+	"login":    {"login", "username", "user name", "user", "login_username", "email"},
+	"password": {"password", "pass", "login_password"},
+	"url":      {"url", "uri", "website", "login_uri"},
+	"notes":    {"notes", "note", "extra", "comments"},
+	"number":   {"number", "card number", "cardnumber"},
+	"cvv":      {"cvv", "cvc", "security code"},
+	"due":      {"due", "expiry", "expiration", "expiration date"},
+}
+
+// CSVFields - are fields of entry columns of CSV file may be mapped onto.
+var CSVFields = []string{"title", "folder", "login", "password", "url", "notes", "number", "cvv", "due"}
+
+// parseCSV - reads CSV file with header, columns are mapped onto fields by mapping or by their header names.
+//
+// Row with card number becomes card entry, row with login or password becomes login entry and others become text
+// entries, unmapped columns are skipped.
+func parseCSV(data []byte, mapping map[string]string) ([]Entry, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))))
+	reader.FieldsPerRecord = -1
+
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("error in reading CSV file: %w", err)
+	}
+
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	header := make(map[string]int, len(rows[0]))
+	for i, name := range rows[0] {
+		header[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	columns := make(map[string]int, len(csvColumns))
+
+	for f, names := range csvColumns {
+		for _, name := range names {
+			if i, ok := header[name]; ok {
+				columns[f] = i
+
+				break
+			}
+		}
+	}
+
+	for f, name := range mapping {
+		if _, ok := csvColumns[f]; !ok {
+			return nil, fmt.Errorf("field %q can't be mapped, fields are %s", f, strings.Join(CSVFields, ", "))
+		}
+
+		i, ok := header[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			return nil, fmt.Errorf("column %q is not found in CSV header", name)
+		}
+
+		columns[f] = i
+	}
+
+	if _, ok := columns["title"]; !ok {
+		if _, okURL := columns["url"]; !okURL {
+			return nil, errors.New("CSV file has no title column, map one by title=%column%")
+		}
+	}
+
+	entries := make([]Entry, 0, len(rows)-1)
+
+	for _, row := range rows[1:] {
+		value := func(f string) string {
+			i, ok := columns[f]
+			if !ok || i >= len(row) {
+				return ""
+			}
+
+			return strings.TrimSpace(row[i])
+		}
+
+		entry := Entry{
+			Title:      value("title"),
+			Folder:     value("folder"),
+			Login:      value("login"),
+			Password:   value("password"),
+			URL:        value("url"),
+			Notes:      value("notes"),
+			CardNumber: value("number"),
+			CVV:        value("cvv"),
+			Due:        value("due"),
+		}
+
+		switch {
+		case entry.CardNumber != "":
+			entry.Kind, entry.Login, entry.Password, entry.URL = KindCard, "", "", ""
+		case entry.Login != "" || entry.Password != "":
+			entry.Kind, entry.CVV, entry.Due = KindLogin, "", ""
+		case entry.Notes != "":
+			entry.Kind = KindText
+		default:
+			// blank rows are skipped
+			continue
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
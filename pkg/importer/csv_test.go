@@ -0,0 +1,98 @@
+package importer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse_CSV(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		mapping map[string]string
+		want    []Entry
+		wantErr bool
+	}{
+		{
+			name: "columns are recognised by header names",
+			data: "\xef\xbb\xbfName,Folder,Username,Password,URL,Notes\n" +
+				"Mail,Work,alice,p@ss,https://mail.example.com,main account\n" +
+				"Codes,,,,,one two\n" +
+				",,,,,\n",
+			want: []Entry{
+				{
+					Kind: KindLogin, Title: "Mail", Folder: "Work", Login: "alice", Password: "p@ss",
+					URL: "https://mail.example.com", Notes: "main account",
+				},
+				{Kind: KindText, Title: "Codes", Notes: "one two"},
+			},
+		},
+		{
+			name: "card row drops login fields",
+			data: "title,card number,cvv,expiry,username\nVisa,4111111111111111,123,07/31,alice\n",
+			want: []Entry{{Kind: KindCard, Title: "Visa", CardNumber: "4111111111111111", CVV: "123", Due: "07/31"}},
+		},
+		{
+			name:    "mapping overrides header names",
+			data:    "Site,Account,Secret,Name\nhttps://example.com,alice,p@ss,ignored\n",
+			mapping: map[string]string{"url": "Site", "login": "account", "password": "Secret", "title": "Site"},
+			want: []Entry{
+				{
+					Kind: KindLogin, Title: "https://example.com", Login: "alice", Password: "p@ss",
+					URL: "https://example.com",
+				},
+			},
+		},
+		{
+			name: "title falls back to URL",
+			data: "url,login\nhttps://example.com,alice\n",
+			want: []Entry{{Kind: KindLogin, Title: "https://example.com", Login: "alice", URL: "https://example.com"}},
+		},
+		{
+			name: "short row",
+			data: "title,username,password\nMail,alice\n",
+			want: []Entry{{Kind: KindLogin, Title: "Mail", Login: "alice"}},
+		},
+		{
+			name:    "unknown field of mapping",
+			data:    "title,username\nMail,alice\n",
+			mapping: map[string]string{"otp": "title"},
+			wantErr: true,
+		},
+		{
+			name:    "mapped column is missing",
+			data:    "title,username\nMail,alice\n",
+			mapping: map[string]string{"password": "Secret"},
+			wantErr: true,
+		},
+		{
+			name:    "no title column",
+			data:    "username,password\nalice,p@ss\n",
+			wantErr: true,
+		},
+		{
+			name:    "broken quotes",
+			data:    "title,password\n\"Mail,p@ss\n",
+			wantErr: true,
+		},
+		{
+			name: "empty file",
+			data: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := Parse("csv", []byte(tt.data), Options{Mapping: tt.mapping})
+			if tt.wantErr {
+				assert.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, entries)
+		})
+	}
+}
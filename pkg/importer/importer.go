@@ -0,0 +1,162 @@
+// Package importer reads exports of other password managers, Bitwarden JSON, KeePass XML and KDBX, 1Password 1PUX
+// and generic CSV, into entries which map onto login/pass, text, card and binary secrets.
+package importer
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Kind - is a kind of secret entry maps onto.
+type Kind string
+
+const (
+	KindLogin  Kind = "login"
+	KindText   Kind = "text"
+	KindCard   Kind = "card"
+	KindBinary Kind = "binary"
+)
+
+// Formats - are names of supported export formats.
+var Formats = []string{"bitwarden", "keepass", "1password", "csv"}
+
+var (
+	ErrFormat    = errors.New("format must be bitwarden, keepass, 1password or csv")
+	ErrEncrypted = errors.New("export is encrypted by password manager, export it unencrypted")
+	ErrPassword  = errors.New("password or key file is wrong")
+)
+
+// Entry - is an item of export, only fields of its Kind are set.
+type Entry struct {
+	Kind  Kind
+	Title string
+	// Folder - is a path of folder or group of item in password manager, it isn't kept by secrets.
+	Folder string
+
+	Login    string
+	Password string
+	URL      string
+
+	// Notes - are notes and custom fields of item, for text entries they are the text.
+	Notes string
+
+	CardNumber string
+	CVV        string
+	// Due - is expiry of card in MM/YY format.
+	Due string
+
+	// FileName - is name of attached file, Data is its content.
+	FileName string
+	Data     []byte
+}
+
+// Options - are options of formats, Password and KeyFile unlock KDBX files, Mapping maps fields of entry onto
+// columns of CSV file.
+type Options struct {
+	Password string
+	KeyFile  []byte
+	Mapping  map[string]string
+}
+
+// Parse - reads export of format from data.
+func Parse(format string, data []byte, opts Options) ([]Entry, error) {
+	var (
+		entries []Entry
+		err     error
+	)
+
+	switch format {
+	case "bitwarden":
+		entries, err = parseBitwarden(data)
+	case "keepass":
+		entries, err = parseKeePass(data, opts)
+	case "1password":
+		entries, err = parseOnePassword(data)
+	case "csv":
+		entries, err = parseCSV(data, opts.Mapping)
+	default:
+		return nil, ErrFormat
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range entries {
+		if entries[i].Title == "" {
+			entries[i].Title = defaultTitle(entries[i])
+		}
+	}
+
+	return entries, nil
+}
+
+// defaultTitle - returns title for entry without one, secrets can't be created without title.
+func defaultTitle(e Entry) string {
+	switch {
+	case e.URL != "":
+		return e.URL
+	case e.FileName != "":
+		return e.FileName
+	case e.Login != "":
+		return e.Login
+	default:
+		return "Untitled " + string(e.Kind)
+	}
+}
+
+// field - is a named value of item, which has no place in Entry.
+type field struct {
+	name  string
+	value string
+}
+
+// joinNotes - appends fields to notes as "name: value" lines, empty values are skipped.
+func joinNotes(notes string, fields []field) string {
+	lines := make([]string, 0, len(fields)+1)
+	if notes = strings.TrimSpace(notes); notes != "" {
+		lines = append(lines, notes)
+	}
+
+	for _, f := range fields {
+		if f.value == "" {
+			continue
+		}
+
+		lines = append(lines, fmt.Sprintf("%s: %s", f.name, f.value))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// sortedKeys - returns keys of map in order, so fields are written to notes in the same order every time.
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// cardDue - formats month and year of card expiry as MM/YY, empty string is returned if either is missing.
+func cardDue(month, year string) string {
+	month, year = strings.TrimSpace(month), strings.TrimSpace(year)
+	if month == "" || year == "" {
+		return ""
+	}
+
+	if len(month) == 1 {
+		month = "0" + month
+	}
+
+	if len(year) == 4 {
+		year = year[2:]
+	}
+
+	return month + "/" + year
+}
@@ -0,0 +1,678 @@
+package importer
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/chacha20"
+	"golang.org/x/crypto/salsa20/salsa"
+	"golang.org/x/crypto/twofish"
+)
+
+// kdbx signatures and header field IDs, see https://keepass.info/help/kb/kdbx.html.
+const (
+	kdbxSignature1 = 0x9AA2D903
+	kdbxSignature2 = 0xB54BFB67
+
+	kdbxEndOfHeader      = 0
+	kdbxCipherID         = 2
+	kdbxCompression      = 3
+	kdbxMasterSeed       = 4
+	kdbxTransformSeed    = 5
+	kdbxTransformRounds  = 6
+	kdbxEncryptionIV     = 7
+	kdbxProtectedKey     = 8
+	kdbxStreamStartBytes = 9
+	kdbxRandomStreamID   = 10
+	kdbxKdfParameters    = 11
+
+	kdbxInnerRandomStreamID  = 1
+	kdbxInnerRandomStreamKey = 2
+	kdbxInnerBinary          = 3
+
+	kdbxStreamSalsa20  = 2
+	kdbxStreamChaCha20 = 3
+
+	// kdbxMaxArgon2Memory - limits argon2 memory in KiB read from KDBX header, so a crafted file can't exhaust memory.
+	kdbxMaxArgon2Memory = 1024 * 1024
+)
+
+var (
+	kdbxAES      = mustUUID("31c1f2e6bf714350be5805216afc5aff")
+	kdbxChaCha20 = mustUUID("d6038a2b8b6f4cb5a524339a31dbb59a")
+	kdbxTwofish  = mustUUID("ad68f29f576f4bb9a36ad47af965346c")
+
+	kdfAES      = mustUUID("c9d9f39a628a4460bf740d08c18a4fea")
+	kdfArgon2d  = mustUUID("ef636ddf8c29444b91f7a9a403e30a0c")
+	kdfArgon2id = mustUUID("9e298b1956db4773b23dfc3ec6f0a1e6")
+
+	salsa20Nonce = []byte{0xE8, 0x30, 0x09, 0x4B, 0x97, 0x20, 0x5D, 0x2A}
+)
+
+// mustUUID - decodes hex UUID of cipher or KDF.
+func mustUUID(s string) string {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return string(b)
+}
+
+// isKDBX - reports whether data is KDBX database rather than XML export.
+func isKDBX(data []byte) bool {
+	return len(data) >= 12 &&
+		binary.LittleEndian.Uint32(data[0:4]) == kdbxSignature1 &&
+		binary.LittleEndian.Uint32(data[4:8]) == kdbxSignature2
+}
+
+// kdbxDatabase - is decrypted KDBX database, protected values of XML are still encrypted by stream.
+type kdbxDatabase struct {
+	xml      []byte
+	binaries [][]byte
+	stream   cipher.Stream
+}
+
+// kdbxHeader - is outer header of KDBX file.
+type kdbxHeader struct {
+	cipherID       string
+	compressed     bool
+	masterSeed     []byte
+	iv             []byte
+	transformSeed  []byte
+	transformRound uint64
+	protectedKey   []byte
+	startBytes     []byte
+	streamID       uint32
+	kdf            map[string][]byte
+}
+
+// openKDBX - decrypts KDBX 3.1 or 4 database by password and key file.
+func openKDBX(data []byte, opts Options) (kdbxDatabase, error) {
+	major := binary.LittleEndian.Uint16(data[10:12])
+	if major != 3 && major != 4 {
+		return kdbxDatabase{}, fmt.Errorf("KDBX version %d is not supported", major)
+	}
+
+	composite, err := compositeKey(opts)
+	if err != nil {
+		return kdbxDatabase{}, err
+	}
+
+	header, headerLen, errHeader := readKDBXHeader(data, major)
+	if errHeader != nil {
+		return kdbxDatabase{}, errHeader
+	}
+
+	transformed, errKDF := transformKey(header, composite)
+	if errKDF != nil {
+		return kdbxDatabase{}, errKDF
+	}
+
+	masterKey := sha256.Sum256(concat(header.masterSeed, transformed))
+
+	if major == 3 {
+		return openKDBX3(data[headerLen:], header, masterKey[:])
+	}
+
+	return openKDBX4(data, headerLen, header, masterKey[:], transformed)
+}
+
+// openKDBX3 - decrypts payload of KDBX 3.1, which is hashed block stream encrypted as a whole.
+func openKDBX3(payload []byte, header kdbxHeader, masterKey []byte) (kdbxDatabase, error) {
+	plain, err := decryptPayload(header, masterKey, payload)
+	if err != nil {
+		return kdbxDatabase{}, err
+	}
+
+	if len(plain) < 32 || !bytes.Equal(plain[:32], header.startBytes) {
+		return kdbxDatabase{}, ErrPassword
+	}
+
+	content, errBlocks := readHashedBlocks(plain[32:])
+	if errBlocks != nil {
+		return kdbxDatabase{}, errBlocks
+	}
+
+	if content, err = decompress(content, header.compressed); err != nil {
+		return kdbxDatabase{}, err
+	}
+
+	stream, errStream := innerStream(header.streamID, header.protectedKey)
+	if errStream != nil {
+		return kdbxDatabase{}, errStream
+	}
+
+	return kdbxDatabase{xml: content, stream: stream}, nil
+}
+
+// openKDBX4 - decrypts payload of KDBX 4, header and every block of which are authenticated by HMAC.
+func openKDBX4(data []byte, headerLen int, header kdbxHeader, masterKey, transformed []byte) (kdbxDatabase, error) {
+	if len(data) < headerLen+64 {
+		return kdbxDatabase{}, errors.New("KDBX file is truncated")
+	}
+
+	headerHash := sha256.Sum256(data[:headerLen])
+	if !bytes.Equal(headerHash[:], data[headerLen:headerLen+32]) {
+		return kdbxDatabase{}, errors.New("KDBX header is corrupted")
+	}
+
+	hmacKey := sha512.Sum512(concat(header.masterSeed, transformed, []byte{1}))
+
+	mac := hmac.New(sha256.New, blockHMACKey(hmacKey[:], ^uint64(0)))
+	mac.Write(data[:headerLen])
+
+	if !hmac.Equal(mac.Sum(nil), data[headerLen+32:headerLen+64]) {
+		return kdbxDatabase{}, ErrPassword
+	}
+
+	encrypted, err := readHMACBlocks(data[headerLen+64:], hmacKey[:])
+	if err != nil {
+		return kdbxDatabase{}, err
+	}
+
+	content, errDecrypt := decryptPayload(header, masterKey, encrypted)
+	if errDecrypt != nil {
+		return kdbxDatabase{}, errDecrypt
+	}
+
+	if content, err = decompress(content, header.compressed); err != nil {
+		return kdbxDatabase{}, err
+	}
+
+	db := kdbxDatabase{}
+
+	var (
+		streamID  uint32
+		streamKey []byte
+	)
+
+	// inner header holds key of protected values and attachments
+	for {
+		if len(content) < 5 {
+			return kdbxDatabase{}, errors.New("KDBX inner header is truncated")
+		}
+
+		id, size := content[0], int(binary.LittleEndian.Uint32(content[1:5]))
+		if size < 0 || len(content) < 5+size {
+			return kdbxDatabase{}, errors.New("KDBX inner header is truncated")
+		}
+
+		value := content[5 : 5+size]
+		content = content[5+size:]
+
+		if id == kdbxEndOfHeader {
+			break
+		}
+
+		switch id {
+		case kdbxInnerRandomStreamID:
+			if len(value) == 4 {
+				streamID = binary.LittleEndian.Uint32(value)
+			}
+		case kdbxInnerRandomStreamKey:
+			streamKey = value
+		case kdbxInnerBinary:
+			if len(value) > 0 {
+				// the first byte is flags of attachment
+				db.binaries = append(db.binaries, value[1:])
+			}
+		}
+	}
+
+	if db.stream, err = innerStream(streamID, streamKey); err != nil {
+		return kdbxDatabase{}, err
+	}
+
+	db.xml = content
+
+	return db, nil
+}
+
+// readKDBXHeader - reads outer header fields, length of header including signatures is returned.
+func readKDBXHeader(data []byte, major uint16) (kdbxHeader, int, error) {
+	header := kdbxHeader{}
+	pos := 12
+
+	sizeLen := 2
+	if major == 4 {
+		sizeLen = 4
+	}
+
+	for {
+		if len(data) < pos+1+sizeLen {
+			return kdbxHeader{}, 0, errors.New("KDBX header is truncated")
+		}
+
+		id := data[pos]
+
+		var size int
+		if sizeLen == 2 {
+			size = int(binary.LittleEndian.Uint16(data[pos+1:]))
+		} else {
+			size = int(binary.LittleEndian.Uint32(data[pos+1:]))
+		}
+
+		pos += 1 + sizeLen
+		if size < 0 || len(data) < pos+size {
+			return kdbxHeader{}, 0, errors.New("KDBX header is truncated")
+		}
+
+		value := data[pos : pos+size]
+		pos += size
+
+		switch id {
+		case kdbxEndOfHeader:
+			return header, pos, nil
+		case kdbxCipherID:
+			header.cipherID = string(value)
+		case kdbxCompression:
+			header.compressed = len(value) == 4 && binary.LittleEndian.Uint32(value) == 1
+		case kdbxMasterSeed:
+			header.masterSeed = value
+		case kdbxTransformSeed:
+			header.transformSeed = value
+		case kdbxTransformRounds:
+			if len(value) == 8 {
+				header.transformRound = binary.LittleEndian.Uint64(value)
+			}
+		case kdbxEncryptionIV:
+			header.iv = value
+		case kdbxProtectedKey:
+			header.protectedKey = value
+		case kdbxStreamStartBytes:
+			header.startBytes = value
+		case kdbxRandomStreamID:
+			if len(value) == 4 {
+				header.streamID = binary.LittleEndian.Uint32(value)
+			}
+		case kdbxKdfParameters:
+			kdf, err := readVariantDictionary(value)
+			if err != nil {
+				return kdbxHeader{}, 0, err
+			}
+
+			header.kdf = kdf
+		}
+	}
+}
+
+// readVariantDictionary - reads KDF parameters of KDBX 4, values are kept raw, as their type is known by name.
+func readVariantDictionary(data []byte) (map[string][]byte, error) {
+	if len(data) < 2 {
+		return nil, errors.New("KDF parameters are truncated")
+	}
+
+	dict := make(map[string][]byte)
+	pos := 2
+
+	for pos < len(data) {
+		kind := data[pos]
+		pos++
+
+		if kind == 0 {
+			return dict, nil
+		}
+
+		if len(data) < pos+4 {
+			break
+		}
+
+		nameLen := int(binary.LittleEndian.Uint32(data[pos:]))
+		pos += 4
+
+		if nameLen < 0 || len(data) < pos+nameLen+4 {
+			break
+		}
+
+		name := string(data[pos : pos+nameLen])
+		pos += nameLen
+
+		valueLen := int(binary.LittleEndian.Uint32(data[pos:]))
+		pos += 4
+
+		if valueLen < 0 || len(data) < pos+valueLen {
+			break
+		}
+
+		dict[name] = data[pos : pos+valueLen]
+		pos += valueLen
+	}
+
+	return nil, errors.New("KDF parameters are truncated")
+}
+
+// compositeKey - hashes password and key file as KeePass does, at least one of them must be set.
+func compositeKey(opts Options) ([]byte, error) {
+	var parts [][]byte
+
+	if opts.Password != "" || opts.KeyFile == nil {
+		sum := sha256.Sum256([]byte(opts.Password))
+		parts = append(parts, sum[:])
+	}
+
+	if opts.KeyFile != nil {
+		key, err := keyFileKey(opts.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+
+		parts = append(parts, key)
+	}
+
+	sum := sha256.Sum256(concat(parts...))
+
+	return sum[:], nil
+}
+
+// keyFileKey - returns key of key file, which is XML key file, 32 raw bytes, 64 hex digits or hash of any file.
+func keyFileKey(data []byte) ([]byte, error) {
+	var keyFile struct {
+		XMLName xml.Name `xml:"KeyFile"`
+		Version string   `xml:"Meta>Version"`
+		Data    string   `xml:"Key>Data"`
+	}
+
+	if xml.Unmarshal(data, &keyFile) == nil {
+		if strings.HasPrefix(keyFile.Version, "2.") {
+			key, err := hex.DecodeString(strings.Join(strings.Fields(keyFile.Data), ""))
+			if err != nil {
+				return nil, fmt.Errorf("error in reading key file: %w", err)
+			}
+
+			return key, nil
+		}
+
+		key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(keyFile.Data))
+		if err != nil {
+			return nil, fmt.Errorf("error in reading key file: %w", err)
+		}
+
+		return key, nil
+	}
+
+	switch len(data) {
+	case 32:
+		return data, nil
+	case 64:
+		if key, err := hex.DecodeString(string(data)); err == nil {
+			return key, nil
+		}
+	}
+
+	sum := sha256.Sum256(data)
+
+	return sum[:], nil
+}
+
+// transformKey - derives key from composite key by KDF of database.
+func transformKey(header kdbxHeader, composite []byte) ([]byte, error) {
+	if header.kdf == nil {
+		return aesKDF(composite, header.transformSeed, header.transformRound)
+	}
+
+	u64 := func(name string) uint64 {
+		if v := header.kdf[name]; len(v) == 8 {
+			return binary.LittleEndian.Uint64(v)
+		}
+
+		return 0
+	}
+
+	u32 := func(name string) uint32 {
+		if v := header.kdf[name]; len(v) == 4 {
+			return binary.LittleEndian.Uint32(v)
+		}
+
+		return 0
+	}
+
+	switch string(header.kdf["$UUID"]) {
+	case kdfAES:
+		return aesKDF(composite, header.kdf["S"], u64("R"))
+	case kdfArgon2d, kdfArgon2id:
+		iterations, memory, parallelism := u64("I"), u64("M")/1024, u32("P")
+		if iterations == 0 || iterations > 1<<32-1 || memory == 0 || parallelism == 0 || parallelism > 255 {
+			return nil, errors.New("argon2 parameters of KDBX file are invalid")
+		}
+
+		if memory > kdbxMaxArgon2Memory {
+			return nil, fmt.Errorf("argon2 memory of KDBX file is over %d MiB, lower it in KeePass and export again",
+				kdbxMaxArgon2Memory/1024)
+		}
+
+		if u32("V") != argon2Version {
+			return nil, fmt.Errorf("argon2 version %#x is not supported", u32("V"))
+		}
+
+		if string(header.kdf["$UUID"]) == kdfArgon2id {
+			// KeePass never sets secret key and associated data, which argon2.IDKey doesn't take
+			return argon2.IDKey(composite, header.kdf["S"], uint32(iterations), uint32(memory), uint8(parallelism), 32), nil
+		}
+
+		return argon2dKey(composite, header.kdf["S"], header.kdf["K"], header.kdf["A"],
+			uint32(iterations), uint32(memory), parallelism, 32), nil
+	default:
+		return nil, errors.New("KDF of KDBX file is not supported")
+	}
+}
+
+// aesKDF - encrypts key by AES-ECB rounds times and hashes result.
+func aesKDF(key, seed []byte, rounds uint64) ([]byte, error) {
+	block, err := aes.NewCipher(seed)
+	if err != nil {
+		return nil, fmt.Errorf("error in transforming key: %w", err)
+	}
+
+	transformed := append([]byte(nil), key...)
+	for i := uint64(0); i < rounds; i++ {
+		block.Encrypt(transformed[:16], transformed[:16])
+		block.Encrypt(transformed[16:], transformed[16:])
+	}
+
+	sum := sha256.Sum256(transformed)
+
+	return sum[:], nil
+}
+
+// decryptPayload - decrypts payload by cipher of database, CBC padding is removed.
+func decryptPayload(header kdbxHeader, key, payload []byte) ([]byte, error) {
+	var block cipher.Block
+
+	switch header.cipherID {
+	case kdbxChaCha20:
+		stream, err := chacha20.NewUnauthenticatedCipher(key, header.iv)
+		if err != nil {
+			return nil, fmt.Errorf("error in decrypting database: %w", err)
+		}
+
+		plain := make([]byte, len(payload))
+		stream.XORKeyStream(plain, payload)
+
+		return plain, nil
+	case kdbxAES:
+		block, _ = aes.NewCipher(key)
+	case kdbxTwofish:
+		block, _ = twofish.NewCipher(key)
+	default:
+		return nil, errors.New("cipher of KDBX file is not supported")
+	}
+
+	if len(payload) == 0 || len(payload)%block.BlockSize() != 0 || len(header.iv) != block.BlockSize() {
+		return nil, ErrPassword
+	}
+
+	plain := make([]byte, len(payload))
+	cipher.NewCBCDecrypter(block, header.iv).CryptBlocks(plain, payload)
+
+	padding := int(plain[len(plain)-1])
+	if padding == 0 || padding > block.BlockSize() {
+		return nil, ErrPassword
+	}
+
+	return plain[:len(plain)-padding], nil
+}
+
+// readHashedBlocks - joins blocks of KDBX 3.1, every block is verified by its SHA-256.
+func readHashedBlocks(data []byte) ([]byte, error) {
+	var content []byte
+
+	for {
+		if len(data) < 40 {
+			return nil, errors.New("KDBX block is truncated")
+		}
+
+		hash, size := data[4:36], int(binary.LittleEndian.Uint32(data[36:40]))
+		data = data[40:]
+
+		if size == 0 {
+			return content, nil
+		}
+
+		if size < 0 || len(data) < size {
+			return nil, errors.New("KDBX block is truncated")
+		}
+
+		if sum := sha256.Sum256(data[:size]); !bytes.Equal(sum[:], hash) {
+			return nil, errors.New("KDBX block is corrupted")
+		}
+
+		content = append(content, data[:size]...)
+		data = data[size:]
+	}
+}
+
+// readHMACBlocks - joins blocks of KDBX 4, every block is verified by its HMAC-SHA-256.
+func readHMACBlocks(data, hmacKey []byte) ([]byte, error) {
+	var content []byte
+
+	for index := uint64(0); ; index++ {
+		if len(data) < 36 {
+			return nil, errors.New("KDBX block is truncated")
+		}
+
+		sum, sizeBytes := data[:32], data[32:36]
+		size := int(binary.LittleEndian.Uint32(sizeBytes))
+		data = data[36:]
+
+		if size < 0 || len(data) < size {
+			return nil, errors.New("KDBX block is truncated")
+		}
+
+		var indexBytes [8]byte
+
+		binary.LittleEndian.PutUint64(indexBytes[:], index)
+
+		mac := hmac.New(sha256.New, blockHMACKey(hmacKey, index))
+		mac.Write(indexBytes[:])
+		mac.Write(sizeBytes)
+		mac.Write(data[:size])
+
+		if !hmac.Equal(mac.Sum(nil), sum) {
+			return nil, errors.New("KDBX block is corrupted")
+		}
+
+		if size == 0 {
+			return content, nil
+		}
+
+		content = append(content, data[:size]...)
+		data = data[size:]
+	}
+}
+
+// blockHMACKey - derives HMAC key of block, header is authenticated as block with the largest index.
+func blockHMACKey(hmacKey []byte, index uint64) []byte {
+	var indexBytes [8]byte
+
+	binary.LittleEndian.PutUint64(indexBytes[:], index)
+	sum := sha512.Sum512(concat(indexBytes[:], hmacKey))
+
+	return sum[:]
+}
+
+// decompress - ungzips content if database is compressed.
+func decompress(content []byte, compressed bool) ([]byte, error) {
+	if !compressed {
+		return content, nil
+	}
+
+	r, err := gzip.NewReader(bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("error in decompressing database: %w", err)
+	}
+	defer r.Close()
+
+	plain, errRead := io.ReadAll(r)
+	if errRead != nil {
+		return nil, fmt.Errorf("error in decompressing database: %w", errRead)
+	}
+
+	return plain, nil
+}
+
+// innerStream - returns cipher of protected values of XML.
+func innerStream(id uint32, key []byte) (cipher.Stream, error) {
+	switch id {
+	case kdbxStreamSalsa20:
+		stream := &salsa20Stream{used: 64}
+		stream.key = sha256.Sum256(key)
+		copy(stream.counter[:8], salsa20Nonce)
+
+		return stream, nil
+	case kdbxStreamChaCha20:
+		sum := sha512.Sum512(key)
+
+		return chacha20.NewUnauthenticatedCipher(sum[:32], sum[32:44])
+	default:
+		return nil, fmt.Errorf("protection %d of KDBX values is not supported", id)
+	}
+}
+
+// salsa20Stream - is Salsa20 key stream, which continues across values, unlike salsa20.XORKeyStream.
+type salsa20Stream struct {
+	key     [32]byte
+	counter [16]byte
+	block   [64]byte
+	used    int
+}
+
+// XORKeyStream - implements cipher.Stream.
+func (s *salsa20Stream) XORKeyStream(dst, src []byte) {
+	for i := range src {
+		if s.used == len(s.block) {
+			var zero [64]byte
+
+			salsa.XORKeyStream(s.block[:], zero[:], &s.counter, &s.key)
+			binary.LittleEndian.PutUint64(s.counter[8:], binary.LittleEndian.Uint64(s.counter[8:])+1)
+			s.used = 0
+		}
+
+		dst[i] = src[i] ^ s.block[s.used]
+		s.used++
+	}
+}
+
+// concat - joins byte slices into new one.
+func concat(parts ...[]byte) []byte {
+	var joined []byte
+	for _, p := range parts {
+		joined = append(joined, p...)
+	}
+
+	return joined
+}
@@ -0,0 +1,324 @@
+package importer
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/chacha20"
+	"golang.org/x/crypto/salsa20"
+)
+
+// Fixtures are written as KeePass writes them, see https://keepass.info/help/kb/kdbx.html, with primitives of
+// standard and x/crypto packages rather than helpers under test.
+
+// kdbxFixture - are options of written KDBX file.
+type kdbxFixture struct {
+	password string
+	keyFile  []byte
+}
+
+func randomBytes(t *testing.T, n int) []byte {
+	t.Helper()
+
+	b := make([]byte, n)
+	_, err := rand.Read(b)
+	require.NoError(t, err)
+
+	return b
+}
+
+func u16(v uint16) []byte { return binary.LittleEndian.AppendUint16(nil, v) }
+func u32(v uint32) []byte { return binary.LittleEndian.AppendUint32(nil, v) }
+func u64(v uint64) []byte { return binary.LittleEndian.AppendUint64(nil, v) }
+
+// kdbxStart - returns signatures and version of KDBX file.
+func kdbxStart(major uint16) []byte {
+	return concat(u32(kdbxSignature1), u32(kdbxSignature2), u16(1), u16(major))
+}
+
+// fixtureCompositeKey - hashes password and 32 bytes key file.
+func fixtureCompositeKey(f kdbxFixture) []byte {
+	password := sha256.Sum256([]byte(f.password))
+	composite := sha256.Sum256(concat(password[:], f.keyFile))
+
+	return composite[:]
+}
+
+// protector - returns protect of keepassDocument, which encrypts values by key stream in document order.
+func protector(keyStream []byte) func(string) string {
+	pos := 0
+
+	return func(value string) string {
+		encrypted := make([]byte, len(value))
+		for i := range encrypted {
+			encrypted[i] = value[i] ^ keyStream[pos+i]
+		}
+
+		pos += len(value)
+
+		return base64.StdEncoding.EncodeToString(encrypted)
+	}
+}
+
+// writeKDBX3 - writes KDBX 3.1 file of fixture XML with Meta attachments, AES-KDF, AES-CBC and Salsa20 protection.
+func writeKDBX3(t *testing.T, f kdbxFixture) []byte {
+	masterSeed, transformSeed, iv := randomBytes(t, 32), randomBytes(t, 32), randomBytes(t, 16)
+	protectedKey, startBytes := randomBytes(t, 32), randomBytes(t, 32)
+	rounds := uint64(1000)
+
+	streamKey := sha256.Sum256(protectedKey)
+	keyStream := make([]byte, 1024)
+	salsa20.XORKeyStream(keyStream, keyStream, salsa20Nonce, &streamKey)
+
+	doc := keepassDocument(protector(keyStream), keepassMetaBinaries(t))
+
+	field := func(id byte, value []byte) []byte { return concat([]byte{id}, u16(uint16(len(value))), value) }
+
+	header := concat(kdbxStart(3),
+		field(kdbxCipherID, []byte(kdbxAES)),
+		field(kdbxCompression, u32(1)),
+		field(kdbxMasterSeed, masterSeed),
+		field(kdbxTransformSeed, transformSeed),
+		field(kdbxTransformRounds, u64(rounds)),
+		field(kdbxEncryptionIV, iv),
+		field(kdbxProtectedKey, protectedKey),
+		field(kdbxStreamStartBytes, startBytes),
+		field(kdbxRandomStreamID, u32(kdbxStreamSalsa20)),
+		field(kdbxEndOfHeader, []byte("\r\n\r\n")),
+	)
+
+	block, err := aes.NewCipher(transformSeed)
+	require.NoError(t, err)
+
+	transformed := fixtureCompositeKey(f)
+	for i := uint64(0); i < rounds; i++ {
+		block.Encrypt(transformed[:16], transformed[:16])
+		block.Encrypt(transformed[16:], transformed[16:])
+	}
+
+	transformedSum := sha256.Sum256(transformed)
+	masterKey := sha256.Sum256(concat(masterSeed, transformedSum[:]))
+
+	content := gzipped(t, []byte(doc))
+	contentSum := sha256.Sum256(content)
+	plain := concat(startBytes,
+		u32(0), contentSum[:], u32(uint32(len(content))), content,
+		u32(1), make([]byte, 32), u32(0),
+	)
+
+	padding := aes.BlockSize - len(plain)%aes.BlockSize
+	plain = append(plain, bytes.Repeat([]byte{byte(padding)}, padding)...)
+
+	payloadCipher, err := aes.NewCipher(masterKey[:])
+	require.NoError(t, err)
+
+	encrypted := make([]byte, len(plain))
+	cipher.NewCBCEncrypter(payloadCipher, iv).CryptBlocks(encrypted, plain)
+
+	return concat(header, encrypted)
+}
+
+// writeKDBX4 - writes KDBX 4 file of fixture XML with inner header attachments, Argon2id, ChaCha20 and ChaCha20
+// protection, payload is split into several HMAC blocks.
+func writeKDBX4(t *testing.T, f kdbxFixture) []byte {
+	masterSeed, iv, salt, protectedKey := randomBytes(t, 32), randomBytes(t, 12), randomBytes(t, 32), randomBytes(t, 64)
+
+	entry := func(kind byte, name string, value []byte) []byte {
+		return concat([]byte{kind}, u32(uint32(len(name))), []byte(name), u32(uint32(len(value))), value)
+	}
+
+	kdf := concat(u16(0x0100),
+		entry(0x42, "$UUID", []byte(kdfArgon2id)),
+		entry(0x42, "S", salt),
+		entry(0x05, "I", u64(2)),
+		entry(0x05, "M", u64(1024*1024)),
+		entry(0x04, "P", u32(2)),
+		entry(0x04, "V", u32(argon2Version)),
+		[]byte{0},
+	)
+
+	field := func(id byte, value []byte) []byte { return concat([]byte{id}, u32(uint32(len(value))), value) }
+
+	header := concat(kdbxStart(4),
+		field(kdbxCipherID, []byte(kdbxChaCha20)),
+		field(kdbxCompression, u32(1)),
+		field(kdbxMasterSeed, masterSeed),
+		field(kdbxEncryptionIV, iv),
+		field(kdbxKdfParameters, kdf),
+		field(kdbxEndOfHeader, []byte("\r\n\r\n")),
+	)
+
+	streamSum := sha512.Sum512(protectedKey)
+	stream, err := chacha20.NewUnauthenticatedCipher(streamSum[:32], streamSum[32:44])
+	require.NoError(t, err)
+
+	keyStream := make([]byte, 1024)
+	stream.XORKeyStream(keyStream, keyStream)
+
+	doc := keepassDocument(protector(keyStream), "")
+
+	inner := concat(
+		field(kdbxInnerRandomStreamID, u32(kdbxStreamChaCha20)),
+		field(kdbxInnerRandomStreamKey, protectedKey),
+		field(kdbxInnerBinary, concat([]byte{1}, []byte("-----BEGIN KEY-----"))),
+		field(kdbxInnerBinary, concat([]byte{0}, []byte("text file"))),
+		field(kdbxEndOfHeader, nil),
+		[]byte(doc),
+	)
+
+	transformed := argon2.IDKey(fixtureCompositeKey(f), salt, 2, 1024, 2, 32)
+	masterKey := sha256.Sum256(concat(masterSeed, transformed))
+	hmacKey := sha512.Sum512(concat(masterSeed, transformed, []byte{1}))
+
+	blockKey := func(index uint64) []byte {
+		sum := sha512.Sum512(concat(u64(index), hmacKey[:]))
+
+		return sum[:]
+	}
+
+	payload, err := chacha20.NewUnauthenticatedCipher(masterKey[:], iv)
+	require.NoError(t, err)
+
+	encrypted := gzipped(t, inner)
+	payload.XORKeyStream(encrypted, encrypted)
+
+	headerSum := sha256.Sum256(header)
+	headerMAC := hmac.New(sha256.New, blockKey(^uint64(0)))
+	headerMAC.Write(header)
+
+	file := concat(header, headerSum[:], headerMAC.Sum(nil))
+
+	for index := uint64(0); ; index++ {
+		size := len(encrypted)
+		if size > 256 {
+			size = 256
+		}
+
+		mac := hmac.New(sha256.New, blockKey(index))
+		mac.Write(concat(u64(index), u32(uint32(size)), encrypted[:size]))
+
+		file = concat(file, mac.Sum(nil), u32(uint32(size)), encrypted[:size])
+		encrypted = encrypted[size:]
+
+		if size == 0 {
+			return file
+		}
+	}
+}
+
+func TestParse_KDBX3(t *testing.T) {
+	data := writeKDBX3(t, kdbxFixture{password: "correct horse"})
+
+	entries, err := Parse("keepass", data, Options{Password: "correct horse"})
+	require.NoError(t, err)
+	assert.Equal(t, keepassFixtureEntries, entries)
+
+	_, err = Parse("keepass", data, Options{Password: "wrong horse"})
+	assert.ErrorIs(t, err, ErrPassword)
+}
+
+func TestParse_KDBX4(t *testing.T) {
+	keyFile := randomBytes(t, 32)
+	data := writeKDBX4(t, kdbxFixture{password: "correct horse", keyFile: keyFile})
+
+	entries, err := Parse("keepass", data, Options{Password: "correct horse", KeyFile: keyFile})
+	require.NoError(t, err)
+	assert.Equal(t, keepassFixtureEntries, entries)
+
+	tests := []struct {
+		name string
+		opts Options
+	}{
+		{name: "wrong password", opts: Options{Password: "wrong horse", KeyFile: keyFile}},
+		{name: "key file is missing", opts: Options{Password: "correct horse"}},
+		{name: "wrong key file", opts: Options{Password: "correct horse", KeyFile: randomBytes(t, 32)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, errParse := Parse("keepass", data, tt.opts)
+			assert.ErrorIs(t, errParse, ErrPassword)
+		})
+	}
+}
+
+func TestParse_KDBX4Corrupted(t *testing.T) {
+	f := kdbxFixture{password: "correct horse"}
+	opts := Options{Password: "correct horse"}
+
+	data := writeKDBX4(t, f)
+	_, headerLen, err := readKDBXHeader(data, 4)
+	require.NoError(t, err)
+
+	changed := func(pos int) []byte {
+		cp := append([]byte(nil), data...)
+		cp[pos] ^= 1
+
+		return cp
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+		err  string
+	}{
+		{name: "hash of header", data: changed(headerLen), err: "KDBX header is corrupted"},
+		{name: "HMAC of block", data: changed(headerLen + 64), err: "KDBX block is corrupted"},
+		{name: "content of block", data: changed(headerLen + 64 + 36 + 10), err: "KDBX block is corrupted"},
+		{name: "truncated blocks", data: data[:len(data)-20], err: "KDBX block is truncated"},
+		{name: "truncated header", data: data[:headerLen-4], err: "KDBX header is truncated"},
+		{
+			name: "unsupported version",
+			data: append(concat(kdbxStart(5)), data[12:]...),
+			err:  "KDBX version 5 is not supported",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, errParse := Parse("keepass", tt.data, opts)
+			require.Error(t, errParse)
+			assert.Contains(t, errParse.Error(), tt.err)
+			assert.NotErrorIs(t, errParse, ErrPassword)
+		})
+	}
+}
+
+func TestTransformKey_Argon2Limits(t *testing.T) {
+	params := func(memory uint64, parallelism uint32) kdbxHeader {
+		return kdbxHeader{kdf: map[string][]byte{
+			"$UUID": []byte(kdfArgon2id), "S": make([]byte, 32),
+			"I": u64(2), "M": u64(memory), "P": u32(parallelism), "V": u32(argon2Version),
+		}}
+	}
+
+	tests := []struct {
+		name   string
+		header kdbxHeader
+		err    string
+	}{
+		{
+			name:   "memory over limit",
+			header: params((kdbxMaxArgon2Memory+1)*1024, 2),
+			err:    "argon2 memory of KDBX file is over",
+		},
+		{name: "zero memory", header: params(0, 2), err: "argon2 parameters of KDBX file are invalid"},
+		{name: "zero parallelism", header: params(1024*1024, 0), err: "argon2 parameters of KDBX file are invalid"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := transformKey(tt.header, make([]byte, 32))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.err)
+		})
+	}
+}
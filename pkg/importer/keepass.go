@@ -0,0 +1,273 @@
+package importer
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// keepassFile - is XML of KeePass database, which is exported as is or kept inside KDBX.
+type keepassFile struct {
+	Meta struct {
+		RecycleBinUUID string `xml:"RecycleBinUUID"`
+		Binaries       []struct {
+			ID         string `xml:"ID,attr"`
+			Compressed bool   `xml:"Compressed,attr"`
+			Content    string `xml:",chardata"`
+		} `xml:"Binaries>Binary"`
+	} `xml:"Meta"`
+	Root struct {
+		Groups []keepassGroup `xml:"Group"`
+	} `xml:"Root"`
+}
+
+type keepassGroup struct {
+	UUID    string         `xml:"UUID"`
+	Name    string         `xml:"Name"`
+	Entries []keepassEntry `xml:"Entry"`
+	Groups  []keepassGroup `xml:"Group"`
+}
+
+// keepassEntry - is an entry of group, its history isn't imported.
+type keepassEntry struct {
+	Strings []struct {
+		Key   string `xml:"Key"`
+		Value string `xml:"Value"`
+	} `xml:"String"`
+	Binaries []struct {
+		Key   string `xml:"Key"`
+		Value struct {
+			Ref string `xml:"Ref,attr"`
+		} `xml:"Value"`
+	} `xml:"Binary"`
+}
+
+// keepassStandardFields - are fields of entry, other fields are custom.
+var keepassStandardFields = map[string]bool{"Title": true, "UserName": true, "Password": true, "URL": true, "Notes": true}
+
+// parseKeePass - reads XML export or KDBX database of KeePass, entries of recycle bin are skipped and attachments
+// become binary entries.
+func parseKeePass(data []byte, opts Options) ([]Entry, error) {
+	var (
+		binaries [][]byte
+		stream   cipher.Stream
+	)
+
+	if isKDBX(data) {
+		db, err := openKDBX(data, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		data, binaries, stream = db.xml, db.binaries, db.stream
+	}
+
+	if stream != nil {
+		unprotected, err := unprotectXML(data, stream)
+		if err != nil {
+			return nil, err
+		}
+
+		data = unprotected
+	}
+
+	var file keepassFile
+	if err := xml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("error in reading KeePass database: %w", err)
+	}
+
+	// KDBX 3.1 and XML export keep attachments in Meta, KDBX 4 in inner header
+	metaBinaries := make(map[string][]byte, len(file.Meta.Binaries))
+	for _, b := range file.Meta.Binaries {
+		content, err := base64.StdEncoding.DecodeString(strings.TrimSpace(b.Content))
+		if err != nil {
+			return nil, fmt.Errorf("error in reading KeePass attachment: %w", err)
+		}
+
+		if b.Compressed {
+			if content, err = decompress(content, true); err != nil {
+				return nil, err
+			}
+		}
+
+		metaBinaries[b.ID] = content
+	}
+
+	attachment := func(ref string) ([]byte, bool) {
+		if content, ok := metaBinaries[ref]; ok {
+			return content, true
+		}
+
+		i, err := strconv.Atoi(ref)
+		if err != nil || i < 0 || i >= len(binaries) {
+			return nil, false
+		}
+
+		return binaries[i], true
+	}
+
+	var (
+		entries []Entry
+		walk    func(group keepassGroup, folder string)
+	)
+
+	walk = func(group keepassGroup, folder string) {
+		if group.UUID != "" && group.UUID == file.Meta.RecycleBinUUID {
+			return
+		}
+
+		for _, e := range group.Entries {
+			entries = append(entries, keepassEntries(e, folder, attachment)...)
+		}
+
+		for _, g := range group.Groups {
+			sub := g.Name
+			if folder != "" {
+				sub = folder + "/" + g.Name
+			}
+
+			walk(g, sub)
+		}
+	}
+
+	// root group is database itself, so it isn't a folder
+	for _, g := range file.Root.Groups {
+		walk(g, "")
+	}
+
+	return entries, nil
+}
+
+// keepassEntries - maps entry onto login entry, or text entry if it has neither login nor password, and binary
+// entries of its attachments.
+func keepassEntries(e keepassEntry, folder string, attachment func(ref string) ([]byte, bool)) []Entry {
+	values := make(map[string]string, len(e.Strings))
+	for _, s := range e.Strings {
+		values[s.Key] = s.Value
+	}
+
+	entry := Entry{
+		Kind:     KindLogin,
+		Title:    values["Title"],
+		Folder:   folder,
+		Login:    values["UserName"],
+		Password: values["Password"],
+		URL:      values["URL"],
+	}
+
+	var fields []field
+
+	for _, k := range sortedKeys(values) {
+		if !keepassStandardFields[k] {
+			fields = append(fields, field{name: k, value: values[k]})
+		}
+	}
+
+	entry.Notes = joinNotes(values["Notes"], fields)
+
+	if entry.Login == "" && entry.Password == "" {
+		entry.Kind = KindText
+	}
+
+	entries := []Entry{entry}
+
+	for _, b := range e.Binaries {
+		content, ok := attachment(b.Value.Ref)
+		if !ok {
+			continue
+		}
+
+		entries = append(entries, Entry{
+			Kind:     KindBinary,
+			Title:    strings.TrimSpace(entry.Title + " " + b.Key),
+			Folder:   folder,
+			FileName: b.Key,
+			Data:     content,
+		})
+	}
+
+	// text entry without text is useless, unless it only holds attachments
+	if entry.Kind == KindText && entry.Notes == "" && len(entries) > 1 {
+		entries = entries[1:]
+	}
+
+	return entries
+}
+
+// unprotectXML - decrypts values with Protected="True" attribute, which are encrypted by inner stream in document
+// order, and drops the attribute.
+func unprotectXML(data []byte, stream cipher.Stream) ([]byte, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+
+	var buf bytes.Buffer
+
+	encoder := xml.NewEncoder(&buf)
+
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("error in reading KeePass database: %w", err)
+		}
+
+		switch t := token.(type) {
+		case xml.ProcInst:
+			continue
+		case xml.StartElement:
+			if t.Name.Local != "Value" || !protected(t.Attr) {
+				break
+			}
+
+			var value string
+			if errDecode := decoder.DecodeElement(&value, &t); errDecode != nil {
+				return nil, fmt.Errorf("error in reading KeePass database: %w", errDecode)
+			}
+
+			encrypted, errBase64 := base64.StdEncoding.DecodeString(strings.TrimSpace(value))
+			if errBase64 != nil {
+				return nil, fmt.Errorf("error in reading protected value: %w", errBase64)
+			}
+
+			plain := make([]byte, len(encrypted))
+			stream.XORKeyStream(plain, encrypted)
+
+			if errEncode := encoder.EncodeElement(string(plain), xml.StartElement{Name: t.Name}); errEncode != nil {
+				return nil, errEncode
+			}
+
+			continue
+		}
+
+		if errEncode := encoder.EncodeToken(xml.CopyToken(token)); errEncode != nil {
+			return nil, errEncode
+		}
+	}
+
+	if err := encoder.Flush(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// protected - reports whether value is protected by inner stream.
+func protected(attrs []xml.Attr) bool {
+	for _, a := range attrs {
+		if a.Name.Local == "Protected" {
+			v, _ := strconv.ParseBool(a.Value)
+
+			return v
+		}
+	}
+
+	return false
+}
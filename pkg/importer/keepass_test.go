@@ -0,0 +1,132 @@
+package importer
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// keepassDocument - returns XML of KeePass database with fixture entries, protect encodes values of protected
+// fields in document order and meta is XML of attachments kept in Meta.
+func keepassDocument(protect func(value string) string, meta string) string {
+	return fmt.Sprintf(`<?xml version="1.0" encoding="utf-8" standalone="yes"?>
+<KeePassFile>
+  <Meta>
+    <RecycleBinUUID>YmluYmluYmluYmluYmluYg==</RecycleBinUUID>
+    %s
+  </Meta>
+  <Root>
+    <Group>
+      <UUID>cm9vdHJvb3Ryb290cm9vdA==</UUID>
+      <Name>Database</Name>
+      <Entry>
+        <String><Key>Title</Key><Value>Mail</Value></String>
+        <String><Key>UserName</Key><Value>alice</Value></String>
+        <String><Key>Password</Key><Value Protected="True">%s</Value></String>
+        <String><Key>URL</Key><Value>https://mail.example.com</Value></String>
+        <String><Key>Notes</Key><Value>main account</Value></String>
+        <String><Key>PIN</Key><Value Protected="True">%s</Value></String>
+        <Binary><Key>id.pem</Key><Value Ref="0"/></Binary>
+        <History>
+          <Entry><String><Key>Password</Key><Value Protected="True">%s</Value></String></Entry>
+        </History>
+      </Entry>
+      <Group>
+        <UUID>d29ya3dvcmt3b3Jrd29yaw==</UUID>
+        <Name>Work</Name>
+        <Entry>
+          <String><Key>Title</Key><Value>Codes</Value></String>
+          <String><Key>Notes</Key><Value>one
+two</Value></String>
+        </Entry>
+        <Group>
+          <UUID>c2VydmVyc3NlcnZlcnNzZQ==</UUID>
+          <Name>Servers</Name>
+          <Entry>
+            <String><Key>Title</Key><Value>Files</Value></String>
+            <Binary><Key>a.txt</Key><Value Ref="1"/></Binary>
+          </Entry>
+        </Group>
+      </Group>
+      <Group>
+        <UUID>YmluYmluYmluYmluYmluYg==</UUID>
+        <Name>Recycle Bin</Name>
+        <Entry>
+          <String><Key>Title</Key><Value>Deleted</Value></String>
+          <String><Key>UserName</Key><Value>mallory</Value></String>
+        </Entry>
+      </Group>
+    </Group>
+  </Root>
+</KeePassFile>`, meta, protect("p@ss"), protect("1234"), protect("old"))
+}
+
+// keepassFixtureEntries - are entries of keepassDocument, attachments are the same whether they are kept in Meta or
+// in inner header of KDBX 4.
+var keepassFixtureEntries = []Entry{
+	{
+		Kind: KindLogin, Title: "Mail", Login: "alice", Password: "p@ss", URL: "https://mail.example.com",
+		Notes: "main account\nPIN: 1234",
+	},
+	{Kind: KindBinary, Title: "Mail id.pem", FileName: "id.pem", Data: []byte("-----BEGIN KEY-----")},
+	{Kind: KindText, Title: "Codes", Folder: "Work", Notes: "one\ntwo"},
+	{Kind: KindBinary, Title: "Files a.txt", Folder: "Work/Servers", FileName: "a.txt", Data: []byte("text file")},
+}
+
+// gzipped - returns content compressed by gzip.
+func gzipped(t *testing.T, content []byte) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	w := gzip.NewWriter(&buf)
+	_, err := w.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	return buf.Bytes()
+}
+
+// keepassMetaBinaries - returns Meta attachments of fixture, the first one is compressed.
+func keepassMetaBinaries(t *testing.T) string {
+	return fmt.Sprintf(`<Binaries><Binary ID="0" Compressed="True">%s</Binary><Binary ID="1">%s</Binary></Binaries>`,
+		base64.StdEncoding.EncodeToString(gzipped(t, []byte("-----BEGIN KEY-----"))),
+		base64.StdEncoding.EncodeToString([]byte("text file")))
+}
+
+func TestParse_KeePassXML(t *testing.T) {
+	// XML export keeps protected values in plain text
+	plain := func(value string) string { return value }
+
+	entries, err := Parse("keepass", []byte(keepassDocument(plain, keepassMetaBinaries(t))), Options{})
+	require.NoError(t, err)
+
+	assert.Equal(t, keepassFixtureEntries, entries)
+}
+
+func TestParse_KeePassXMLErrors(t *testing.T) {
+	plain := func(value string) string { return value }
+
+	tests := []struct {
+		name string
+		data string
+	}{
+		{name: "not XML", data: "Title,Password"},
+		{name: "attachment is not base64", data: keepassDocument(plain, `<Binaries><Binary ID="0">@@</Binary></Binaries>`)},
+		{
+			name: "compressed attachment is not gzip",
+			data: keepassDocument(plain, `<Binaries><Binary ID="0" Compressed="True">dGV4dA==</Binary></Binaries>`),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse("keepass", []byte(tt.data), Options{})
+			assert.Error(t, err)
+		})
+	}
+}
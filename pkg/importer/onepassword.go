@@ -0,0 +1,235 @@
+package importer
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// 1password categories of items.
+const (
+	onePasswordLogin    = "001"
+	onePasswordCard     = "002"
+	onePasswordNote     = "003"
+	onePasswordPassword = "005"
+	onePasswordDocument = "006"
+)
+
+// onePasswordExport - is export.data of 1PUX archive.
+type onePasswordExport struct {
+	Accounts []struct {
+		Vaults []struct {
+			Attrs struct {
+				Name string `json:"name"`
+			} `json:"attrs"`
+			Items []onePasswordItem `json:"items"`
+		} `json:"vaults"`
+	} `json:"accounts"`
+}
+
+type onePasswordItem struct {
+	State        string `json:"state"`
+	CategoryUUID string `json:"categoryUuid"`
+	Overview     struct {
+		Title string `json:"title"`
+		URL   string `json:"url"`
+	} `json:"overview"`
+	Details struct {
+		LoginFields []struct {
+			Value       string `json:"value"`
+			Name        string `json:"name"`
+			Designation string `json:"designation"`
+		} `json:"loginFields"`
+		NotesPlain string `json:"notesPlain"`
+		Password   string `json:"password"`
+		Sections   []struct {
+			Title  string `json:"title"`
+			Fields []struct {
+				Title string                     `json:"title"`
+				ID    string                     `json:"id"`
+				Value map[string]json.RawMessage `json:"value"`
+			} `json:"fields"`
+		} `json:"sections"`
+		DocumentAttributes *struct {
+			FileName   string `json:"fileName"`
+			DocumentID string `json:"documentId"`
+		} `json:"documentAttributes"`
+	} `json:"details"`
+}
+
+// parseOnePassword - reads 1PUX archive of 1Password, archived items are skipped and documents become binary
+// entries.
+func parseOnePassword(data []byte) ([]Entry, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("error in reading 1PUX archive: %w", err)
+	}
+
+	files := make(map[string]*zip.File, len(archive.File))
+	for _, f := range archive.File {
+		files[f.Name] = f
+	}
+
+	exportData, ok := files["export.data"]
+	if !ok {
+		return nil, errors.New("error in reading 1PUX archive: export.data is missing")
+	}
+
+	content, errRead := readZipFile(exportData)
+	if errRead != nil {
+		return nil, errRead
+	}
+
+	var export onePasswordExport
+	if errJSON := json.Unmarshal(content, &export); errJSON != nil {
+		return nil, fmt.Errorf("error in reading 1PUX archive: %w", errJSON)
+	}
+
+	var entries []Entry
+
+	for _, account := range export.Accounts {
+		for _, vault := range account.Vaults {
+			for _, item := range vault.Items {
+				if item.State == "archived" {
+					continue
+				}
+
+				entry, errItem := onePasswordEntry(item, files)
+				if errItem != nil {
+					return nil, errItem
+				}
+
+				entry.Folder = vault.Attrs.Name
+				entries = append(entries, entry)
+			}
+		}
+	}
+
+	return entries, nil
+}
+
+// onePasswordEntry - maps item onto entry by its category, items of other categories become text entries.
+func onePasswordEntry(item onePasswordItem, files map[string]*zip.File) (Entry, error) {
+	entry := Entry{Kind: KindText, Title: item.Overview.Title, URL: item.Overview.URL}
+
+	var fields []field
+
+	for _, lf := range item.Details.LoginFields {
+		switch lf.Designation {
+		case "username":
+			entry.Login = lf.Value
+		case "password":
+			entry.Password = lf.Value
+		default:
+			fields = append(fields, field{name: lf.Name, value: lf.Value})
+		}
+	}
+
+	for _, section := range item.Details.Sections {
+		for _, f := range section.Fields {
+			value := onePasswordValue(f.Value)
+
+			switch {
+			case item.CategoryUUID == onePasswordCard && f.ID == "ccnum":
+				entry.CardNumber = value
+			case item.CategoryUUID == onePasswordCard && f.ID == "cvv":
+				entry.CVV = value
+			case item.CategoryUUID == onePasswordCard && f.ID == "expiry":
+				// month and year are kept as YYYYMM number
+				if len(value) == 6 {
+					entry.Due = cardDue(value[4:], value[:4])
+				}
+			default:
+				name := f.Title
+				if name == "" {
+					name = f.ID
+				}
+
+				if section.Title != "" {
+					name = section.Title + " " + name
+				}
+
+				fields = append(fields, field{name: name, value: value})
+			}
+		}
+	}
+
+	switch item.CategoryUUID {
+	case onePasswordLogin:
+		entry.Kind = KindLogin
+	case onePasswordPassword:
+		entry.Kind, entry.Password = KindLogin, item.Details.Password
+	case onePasswordCard:
+		entry.Kind = KindCard
+	case onePasswordDocument:
+		if doc := item.Details.DocumentAttributes; doc != nil {
+			f, ok := files["files/"+doc.DocumentID+"__"+doc.FileName]
+			if ok {
+				content, err := readZipFile(f)
+				if err != nil {
+					return Entry{}, err
+				}
+
+				entry.Kind, entry.FileName, entry.Data = KindBinary, doc.FileName, content
+			}
+		}
+	case onePasswordNote:
+		// text of note is in its notes
+	default:
+		fields = append(fields,
+			field{name: "Login", value: entry.Login},
+			field{name: "Password", value: entry.Password},
+			field{name: "URL", value: entry.URL},
+		)
+		entry.Login, entry.Password, entry.URL = "", "", ""
+	}
+
+	entry.Notes = joinNotes(item.Details.NotesPlain, fields)
+
+	return entry, nil
+}
+
+// onePasswordValue - returns value of field, which is an object with a single key naming its type.
+func onePasswordValue(value map[string]json.RawMessage) string {
+	for _, raw := range value {
+		var s string
+		if json.Unmarshal(raw, &s) == nil {
+			return s
+		}
+
+		var n json.Number
+		if json.Unmarshal(raw, &n) == nil {
+			return n.String()
+		}
+
+		var b bool
+		if json.Unmarshal(raw, &b) == nil {
+			return strconv.FormatBool(b)
+		}
+
+		return strings.TrimSpace(string(raw))
+	}
+
+	return ""
+}
+
+// readZipFile - reads file of archive.
+func readZipFile(f *zip.File) ([]byte, error) {
+	r, err := f.Open()
+	if err != nil {
+		return nil, fmt.Errorf("error in reading 1PUX archive: %w", err)
+	}
+	defer r.Close()
+
+	content, errRead := io.ReadAll(r)
+	if errRead != nil {
+		return nil, fmt.Errorf("error in reading 1PUX archive: %w", errRead)
+	}
+
+	return content, nil
+}
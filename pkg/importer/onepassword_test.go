@@ -0,0 +1,126 @@
+package importer
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const onePasswordFixture = `{
+  "accounts": [{"vaults": [{
+    "attrs": {"name": "Private"},
+    "items": [
+      {
+        "categoryUuid": "001",
+        "overview": {"title": "Mail", "url": "https://mail.example.com"},
+        "details": {
+          "loginFields": [
+            {"value": "alice", "name": "username", "designation": "username"},
+            {"value": "p@ss", "name": "password", "designation": "password"},
+            {"value": "on", "name": "remember", "designation": ""}
+          ],
+          "notesPlain": "main account",
+          "sections": [{"title": "Security", "fields": [{"title": "PIN", "id": "pin", "value": {"concealed": "1234"}}]}]
+        }
+      },
+      {
+        "categoryUuid": "002",
+        "overview": {"title": "Visa"},
+        "details": {"sections": [{"title": "", "fields": [
+          {"title": "number", "id": "ccnum", "value": {"creditCardNumber": "4111111111111111"}},
+          {"title": "verification number", "id": "cvv", "value": {"concealed": "123"}},
+          {"title": "expiry date", "id": "expiry", "value": {"monthYear": 203107}},
+          {"title": "", "id": "contactless", "value": {"boolean": true}}
+        ]}]}
+      },
+      {
+        "categoryUuid": "005",
+        "overview": {"title": "Wi-Fi password"},
+        "details": {"password": "hunter2"}
+      },
+      {
+        "categoryUuid": "006",
+        "overview": {"title": "Key"},
+        "details": {"documentAttributes": {"fileName": "id.pem", "documentId": "doc1"}}
+      },
+      {
+        "categoryUuid": "110",
+        "overview": {"title": "Server", "url": "ssh://example.com"},
+        "details": {"loginFields": [{"value": "root", "name": "username", "designation": "username"}]}
+      },
+      {
+        "state": "archived",
+        "categoryUuid": "003",
+        "overview": {"title": "Old note"},
+        "details": {"notesPlain": "gone"}
+      }
+    ]
+  }]}]
+}`
+
+// onePasswordArchive - returns 1PUX archive of files by name.
+func onePasswordArchive(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	w := zip.NewWriter(&buf)
+	for name, content := range files {
+		f, err := w.Create(name)
+		require.NoError(t, err)
+
+		_, err = f.Write([]byte(content))
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, w.Close())
+
+	return buf.Bytes()
+}
+
+func TestParse_OnePassword(t *testing.T) {
+	data := onePasswordArchive(t, map[string]string{
+		"export.data":          onePasswordFixture,
+		"files/doc1__id.pem":   "-----BEGIN KEY-----",
+		"export.attributes":    `{"version": 3}`,
+		"files/unused__a.txt":  "unused",
+		"files/doc2__lost.txt": "lost",
+	})
+
+	entries, err := Parse("1password", data, Options{})
+	require.NoError(t, err)
+
+	assert.Equal(t, []Entry{
+		{
+			Kind: KindLogin, Title: "Mail", Folder: "Private", Login: "alice", Password: "p@ss",
+			URL: "https://mail.example.com", Notes: "main account\nremember: on\nSecurity PIN: 1234",
+		},
+		{
+			Kind: KindCard, Title: "Visa", Folder: "Private", CardNumber: "4111111111111111", CVV: "123", Due: "07/31",
+			Notes: "contactless: true",
+		},
+		{Kind: KindLogin, Title: "Wi-Fi password", Folder: "Private", Password: "hunter2"},
+		{Kind: KindBinary, Title: "Key", Folder: "Private", FileName: "id.pem", Data: []byte("-----BEGIN KEY-----")},
+		{Kind: KindText, Title: "Server", Folder: "Private", Notes: "Login: root\nURL: ssh://example.com"},
+	}, entries)
+}
+
+func TestParse_OnePasswordErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "not an archive", data: []byte(onePasswordFixture)},
+		{name: "export.data is missing", data: onePasswordArchive(t, map[string]string{"export.attributes": "{}"})},
+		{name: "export.data is not JSON", data: onePasswordArchive(t, map[string]string{"export.data": "<xml/>"})},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse("1password", tt.data, Options{})
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,65 @@
+// Package osc52 sets clipboard of terminal by OSC 52 escape sequence, which terminal emulator handles itself, so it
+// works over SSH and needs no display server.
+package osc52
+
+import (
+	"encoding/base64"
+	"errors"
+	"io"
+	"strings"
+)
+
+// MaxSize - is the largest value Copy accepts, its base64 is 100000 bytes, which most terminals take.
+const MaxSize = 74994
+
+// screenChunk - is size of DCS chunks for GNU screen, which drops longer strings.
+const screenChunk = 76
+
+var ErrTooLarge = errors.New("value is too large for clipboard of terminal")
+
+// Sequence - returns escape sequence setting clipboard to data, empty data clears clipboard.
+//
+// Inside tmux and GNU screen, recognised by TMUX and STY of env, the sequence is wrapped into DCS passthrough, so it
+// reaches the outer terminal, tmux passes it with "allow-passthrough on".
+func Sequence(data []byte, env func(string) string) string {
+	seq := "\x1b]52;c;" + base64.StdEncoding.EncodeToString(data) + "\a"
+
+	switch {
+	case env("TMUX") != "":
+		return "\x1bPtmux;" + strings.ReplaceAll(seq, "\x1b", "\x1b\x1b") + "\x1b\\"
+	case env("STY") != "":
+		var b strings.Builder
+
+		for len(seq) > 0 {
+			n := screenChunk
+			if n > len(seq) {
+				n = len(seq)
+			}
+
+			b.WriteString("\x1bP" + seq[:n] + "\x1b\\")
+			seq = seq[n:]
+		}
+
+		return b.String()
+	default:
+		return seq
+	}
+}
+
+// Copy - writes sequence setting clipboard to data to terminal w.
+func Copy(w io.Writer, data []byte, env func(string) string) error {
+	if len(data) > MaxSize {
+		return ErrTooLarge
+	}
+
+	_, err := io.WriteString(w, Sequence(data, env))
+
+	return err
+}
+
+// Clear - writes sequence clearing clipboard to terminal w.
+func Clear(w io.Writer, env func(string) string) error {
+	_, err := io.WriteString(w, Sequence(nil, env))
+
+	return err
+}
@@ -0,0 +1,207 @@
+// Package passgen generates passwords and diceware-style passphrases with crypto/rand.
+package passgen
+
+import (
+	"crypto/rand"
+	_ "embed"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+)
+
+const (
+	lowerChars  = "abcdefghijklmnopqrstuvwxyz"
+	upperChars  = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	digitChars  = "0123456789"
+	symbolChars = "!#$%&()*+,-./:;<=>?@[]^_{|}~"
+
+	// ambiguousChars - are characters which are easy to confuse with each other when read or retyped.
+	ambiguousChars = "Il1|O0o"
+
+	// maxAttempts - limits regeneration of password which misses some of required classes.
+	maxAttempts = 1000
+)
+
+var (
+	ErrEmptyCharset = errors.New("no characters left to generate password from")
+	ErrLength       = errors.New("length is too short to include every character class")
+)
+
+//go:embed words.txt
+var wordsFile string
+
+// words - is embedded wordlist of 1296 words, so every word is picked by four dice rolls.
+var words = strings.Fields(wordsFile)
+
+// PasswordOptions - describes characters and length of generated password.
+type PasswordOptions struct {
+	Length  int
+	Lower   bool
+	Upper   bool
+	Digits  bool
+	Symbols bool
+	// NoAmbiguous - excludes characters which are easy to confuse, e.g. l, 1 and I.
+	NoAmbiguous bool
+	// Exclude - are characters which must not appear in password.
+	Exclude string
+}
+
+// DefaultPasswordOptions - returns options of 20 characters long password from every character class.
+func DefaultPasswordOptions() PasswordOptions {
+	return PasswordOptions{Length: 20, Lower: true, Upper: true, Digits: true, Symbols: true}
+}
+
+// PassphraseOptions - describes generated passphrase.
+type PassphraseOptions struct {
+	Words     int
+	Separator string
+	// Capitalize - starts every word with capital letter.
+	Capitalize bool
+}
+
+// DefaultPassphraseOptions - returns options of 6 words long passphrase separated by dash.
+func DefaultPassphraseOptions() PassphraseOptions {
+	return PassphraseOptions{Words: 6, Separator: "-"}
+}
+
+// Result - is generated password or passphrase with its entropy in bits.
+type Result struct {
+	Value   string
+	Entropy float64
+}
+
+// Password - generates password which contains at least one character of every enabled class.
+//
+// Entropy is estimated as if every character was picked from the whole alphabet.
+func Password(opts PasswordOptions) (Result, error) {
+	if opts.Length <= 0 {
+		return Result{}, fmt.Errorf("length must be positive, got %d", opts.Length)
+	}
+
+	var classes []string
+
+	for _, class := range []struct {
+		enabled bool
+		chars   string
+	}{
+		{opts.Lower, lowerChars},
+		{opts.Upper, upperChars},
+		{opts.Digits, digitChars},
+		{opts.Symbols, symbolChars},
+	} {
+		if !class.enabled {
+			continue
+		}
+
+		chars := filterChars(class.chars, opts)
+		if chars != "" {
+			classes = append(classes, chars)
+		}
+	}
+
+	if len(classes) == 0 {
+		return Result{}, ErrEmptyCharset
+	}
+
+	if opts.Length < len(classes) {
+		return Result{}, ErrLength
+	}
+
+	alphabet := []rune(strings.Join(classes, ""))
+
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		password := make([]rune, opts.Length)
+
+		for i := range password {
+			n, err := randomInt(len(alphabet))
+			if err != nil {
+				return Result{}, err
+			}
+
+			password[i] = alphabet[n]
+		}
+
+		if containsClasses(string(password), classes) {
+			return Result{
+				Value:   string(password),
+				Entropy: float64(opts.Length) * math.Log2(float64(len(alphabet))),
+			}, nil
+		}
+	}
+
+	return Result{}, ErrLength
+}
+
+// Passphrase - generates passphrase of words picked from embedded wordlist.
+func Passphrase(opts PassphraseOptions) (Result, error) {
+	if opts.Words <= 0 {
+		return Result{}, fmt.Errorf("count of words must be positive, got %d", opts.Words)
+	}
+
+	picked := make([]string, opts.Words)
+
+	for i := range picked {
+		n, err := randomInt(len(words))
+		if err != nil {
+			return Result{}, err
+		}
+
+		picked[i] = words[n]
+		if opts.Capitalize {
+			picked[i] = strings.ToUpper(picked[i][:1]) + picked[i][1:]
+		}
+	}
+
+	return Result{
+		Value:   strings.Join(picked, opts.Separator),
+		Entropy: float64(opts.Words) * math.Log2(float64(len(words))),
+	}, nil
+}
+
+// filterChars - removes ambiguous and excluded characters.
+func filterChars(chars string, opts PasswordOptions) string {
+	return strings.Map(func(r rune) rune {
+		if strings.ContainsRune(opts.Exclude, r) || (opts.NoAmbiguous && strings.ContainsRune(ambiguousChars, r)) {
+			return -1
+		}
+
+		return r
+	}, chars)
+}
+
+// containsClasses - reports whether password has a character of every class.
+func containsClasses(password string, classes []string) bool {
+	for _, class := range classes {
+		if !strings.ContainsAny(password, class) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// randomInt - returns uniformly distributed number in [0, n) read from crypto/rand.
+func randomInt(n int) (int, error) {
+	value, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		return 0, fmt.Errorf("error in reading random number: %w", err)
+	}
+
+	return int(value.Int64()), nil
+}
+
+// Strength - describes entropy in words.
+func Strength(entropy float64) string {
+	switch {
+	case entropy < 40:
+		return "weak"
+	case entropy < 60:
+		return "fair"
+	case entropy < 80:
+		return "strong"
+	default:
+		return "very strong"
+	}
+}
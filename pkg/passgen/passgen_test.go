@@ -0,0 +1,167 @@
+package passgen
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runs - is count of generated values checked by every case, as characters are picked randomly.
+const runs = 50
+
+func TestPassword(t *testing.T) {
+	tests := []struct {
+		name         string
+		opts         PasswordOptions
+		wantClasses  []string
+		wantAlphabet int
+	}{
+		{
+			name:         "default options",
+			opts:         DefaultPasswordOptions(),
+			wantClasses:  []string{lowerChars, upperChars, digitChars, symbolChars},
+			wantAlphabet: 26 + 26 + 10 + len(symbolChars),
+		},
+		{
+			name:         "digits only",
+			opts:         PasswordOptions{Length: 6, Digits: true},
+			wantClasses:  []string{digitChars},
+			wantAlphabet: 10,
+		},
+		{
+			name:         "every class in shortest password",
+			opts:         PasswordOptions{Length: 4, Lower: true, Upper: true, Digits: true, Symbols: true},
+			wantClasses:  []string{lowerChars, upperChars, digitChars, symbolChars},
+			wantAlphabet: 26 + 26 + 10 + len(symbolChars),
+		},
+		{
+			name:         "no ambiguous characters",
+			opts:         PasswordOptions{Length: 30, Lower: true, Upper: true, Digits: true, NoAmbiguous: true},
+			wantClasses:  []string{"abcdefghijkmnpqrstuvwxyz", "ABCDEFGHJKLMNPQRSTUVWXYZ", "23456789"},
+			wantAlphabet: 24 + 24 + 8,
+		},
+		{
+			name:         "excluded characters",
+			opts:         PasswordOptions{Length: 12, Lower: true, Digits: true, Exclude: "abcdefghijklmnopqrstuvw0"},
+			wantClasses:  []string{"xyz", "123456789"},
+			wantAlphabet: 3 + 9,
+		},
+		{
+			name:         "class is dropped when every character is excluded",
+			opts:         PasswordOptions{Length: 8, Lower: true, Digits: true, Exclude: digitChars},
+			wantClasses:  []string{lowerChars},
+			wantAlphabet: 26,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			alphabet := strings.Join(tt.wantClasses, "")
+
+			for i := 0; i < runs; i++ {
+				result, err := Password(tt.opts)
+				require.NoError(t, err)
+
+				assert.Len(t, []rune(result.Value), tt.opts.Length)
+				assert.InDelta(t, float64(tt.opts.Length)*math.Log2(float64(tt.wantAlphabet)), result.Entropy, 1e-9)
+
+				for _, class := range tt.wantClasses {
+					assert.True(t, strings.ContainsAny(result.Value, class), "%q misses one of %q", result.Value, class)
+				}
+
+				for _, r := range result.Value {
+					assert.True(t, strings.ContainsRune(alphabet, r), "%q has unexpected %q", result.Value, r)
+				}
+			}
+		})
+	}
+}
+
+func TestPassword_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    PasswordOptions
+		wantErr error
+	}{
+		{name: "zero length", opts: PasswordOptions{Lower: true}},
+		{name: "negative length", opts: PasswordOptions{Length: -1, Lower: true}},
+		{name: "no classes", opts: PasswordOptions{Length: 10}, wantErr: ErrEmptyCharset},
+		{
+			name:    "every character is excluded",
+			opts:    PasswordOptions{Length: 10, Digits: true, Exclude: digitChars},
+			wantErr: ErrEmptyCharset,
+		},
+		{
+			name:    "shorter than count of classes",
+			opts:    PasswordOptions{Length: 3, Lower: true, Upper: true, Digits: true, Symbols: true},
+			wantErr: ErrLength,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Password(tt.opts)
+			require.Error(t, err)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPassphrase(t *testing.T) {
+	require.Len(t, words, 1296, "every word must be picked by four dice rolls")
+
+	tests := []struct {
+		name string
+		opts PassphraseOptions
+	}{
+		{name: "default options", opts: DefaultPassphraseOptions()},
+		{name: "single word", opts: PassphraseOptions{Words: 1, Separator: "-"}},
+		{name: "space separator", opts: PassphraseOptions{Words: 4, Separator: " "}},
+		{name: "capitalized words", opts: PassphraseOptions{Words: 8, Separator: ".", Capitalize: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < runs; i++ {
+				result, err := Passphrase(tt.opts)
+				require.NoError(t, err)
+
+				assert.InDelta(t, float64(tt.opts.Words)*math.Log2(1296), result.Entropy, 1e-9)
+
+				picked := strings.Split(result.Value, tt.opts.Separator)
+				require.Len(t, picked, tt.opts.Words)
+
+				for _, word := range picked {
+					if tt.opts.Capitalize {
+						assert.Equal(t, strings.ToUpper(word[:1]), word[:1])
+					}
+
+					_, ok := dictionary[strings.ToLower(word)]
+					assert.True(t, ok, "%q isn't a word of the wordlist", word)
+				}
+			}
+		})
+	}
+
+	_, err := Passphrase(PassphraseOptions{Words: 0, Separator: "-"})
+	assert.Error(t, err)
+}
+
+func TestStrength(t *testing.T) {
+	tests := []struct {
+		entropy float64
+		want    string
+	}{
+		{entropy: 0, want: "weak"},
+		{entropy: 39.9, want: "weak"},
+		{entropy: 40, want: "fair"},
+		{entropy: 60, want: "strong"},
+		{entropy: 80, want: "very strong"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, Strength(tt.entropy), "entropy %v", tt.entropy)
+	}
+}
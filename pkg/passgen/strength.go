@@ -0,0 +1,321 @@
+package passgen
+
+import (
+	_ "embed"
+	"math"
+	"strings"
+	"unicode"
+)
+
+const (
+	// bruteforceBits - is entropy of a character which doesn't belong to any pattern, the same as zxcvbn assumes.
+	bruteforceBits = 3.321928
+
+	// maxEstimatedLength - limits part of a password searched for patterns, the rest is counted as bruteforce.
+	maxEstimatedLength = 100
+)
+
+//go:embed common.txt
+var commonFile string
+
+// common - ranks of the most common passwords starting from 1.
+var common = ranks(strings.Fields(commonFile))
+
+// dictionary - is a set of words of embedded wordlist.
+var dictionary = ranks(words)
+
+// keyboardRows - are rows of QWERTY keyboard, adjacent keys of a row form a keyboard pattern.
+var keyboardRows = []string{"qwertyuiop", "asdfghjkl", "zxcvbnm", "1234567890"}
+
+// leet - are substitutions of letters commonly used in passwords.
+var leet = map[rune]rune{'4': 'a', '@': 'a', '3': 'e', '1': 'i', '!': 'i', '0': 'o', '$': 's', '5': 's', '7': 't'}
+
+// scoreLabels - describe Estimate.Score.
+var scoreLabels = []string{"too guessable", "very guessable", "somewhat guessable", "safely unguessable",
+	"very unguessable"}
+
+// Estimate - is strength of a password estimated by patterns it is made of, like zxcvbn does.
+type Estimate struct {
+	// Entropy - is log2 of guesses needed to crack the password.
+	Entropy float64
+	// Score - is strength from 0, too guessable, to 4, very unguessable.
+	Score int
+	// Warnings - describe patterns the password is made of.
+	Warnings []string
+}
+
+// Label - describes score of Estimate in words.
+func (e Estimate) Label() string {
+	return scoreLabels[e.Score]
+}
+
+// match - is part of a password which is a pattern, end is exclusive.
+type match struct {
+	start, end int
+	bits       float64
+	warning    string
+}
+
+// EstimateStrength - estimates strength of a password.
+//
+// Password is split into common passwords, dictionary words, sequences, repeats, keyboard patterns and years in the
+// way which needs the fewest guesses, every other character is guessed by bruteforce.
+func EstimateStrength(password string) Estimate {
+	runes := []rune(password)
+
+	searched := runes
+	if len(searched) > maxEstimatedLength {
+		searched = searched[:maxEstimatedLength]
+	}
+
+	matches := findMatches(searched)
+
+	// bits[i] - is the fewest bits needed for the first i characters, via[i] - is pattern which ends there
+	bits := make([]float64, len(searched)+1)
+	via := make([]*match, len(searched)+1)
+
+	for i := 1; i <= len(searched); i++ {
+		bits[i] = bits[i-1] + bruteforceBits
+
+		for j := range matches {
+			m := &matches[j]
+			if m.end == i && bits[m.start]+m.bits < bits[i] {
+				bits[i], via[i] = bits[m.start]+m.bits, m
+			}
+		}
+	}
+
+	var (
+		warnings []string
+		seen     = make(map[string]bool)
+	)
+
+	for i := len(searched); i > 0; {
+		m := via[i]
+		if m == nil {
+			i--
+
+			continue
+		}
+
+		if !seen[m.warning] {
+			warnings = append([]string{m.warning}, warnings...)
+			seen[m.warning] = true
+		}
+
+		i = m.start
+	}
+
+	entropy := bits[len(searched)] + float64(len(runes)-len(searched))*bruteforceBits
+
+	return Estimate{Entropy: entropy, Score: score(entropy), Warnings: warnings}
+}
+
+// score - converts entropy to score by guesses thresholds of zxcvbn: 10^3, 10^6, 10^8 and 10^10.
+func score(entropy float64) int {
+	guesses := entropy / math.Log2(10)
+
+	switch {
+	case guesses < 3:
+		return 0
+	case guesses < 6:
+		return 1
+	case guesses < 8:
+		return 2
+	case guesses < 10:
+		return 3
+	default:
+		return 4
+	}
+}
+
+// findMatches - returns all patterns found in a password.
+func findMatches(runes []rune) []match {
+	var matches []match
+
+	matches = append(matches, dictionaryMatches(runes)...)
+	matches = append(matches, runMatches(runes)...)
+	matches = append(matches, yearMatches(runes)...)
+
+	return matches
+}
+
+// dictionaryMatches - finds common passwords and dictionary words, also written with capitals or leet substitutions.
+func dictionaryMatches(runes []rune) []match {
+	var matches []match
+
+	for i := 0; i < len(runes); i++ {
+		for j := i + 3; j <= len(runes); j++ {
+			token := runes[i:j]
+			lower := strings.ToLower(string(token))
+			unleeted, substitutions := unleet(lower)
+
+			for k, candidate := range []string{lower, unleeted} {
+				variantBits := capitalBits(token)
+				if k == 1 {
+					variantBits += float64(substitutions)
+				}
+
+				if rank, ok := common[candidate]; ok {
+					matches = append(matches, match{
+						start: i, end: j, bits: math.Log2(float64(rank)) + variantBits,
+						warning: "common password",
+					})
+				}
+
+				// words of the wordlist are equally likely
+				if _, ok := dictionary[candidate]; ok && j-i >= 4 {
+					matches = append(matches, match{
+						start: i, end: j, bits: math.Log2(float64(len(dictionary))) + variantBits,
+						warning: "dictionary word",
+					})
+				}
+			}
+		}
+	}
+
+	return matches
+}
+
+// runMatches - finds repeated characters, sequences like abc or 321 and keyboard patterns like qwerty.
+func runMatches(runes []rune) []match {
+	var matches []match
+
+	for i := 0; i < len(runes); i++ {
+		repeat, sequence, keyboard := i+1, i+1, i+1
+
+		for repeat < len(runes) && runes[repeat] == runes[i] {
+			repeat++
+		}
+
+		if repeat-i >= 3 {
+			matches = append(matches, match{
+				start: i, end: repeat, bits: classBits(runes[i]) + math.Log2(float64(repeat-i)),
+				warning: "repeated characters",
+			})
+		}
+
+		if i+1 < len(runes) {
+			delta := runes[i+1] - runes[i]
+			for delta*delta == 1 && sequence < len(runes) && runes[sequence]-runes[sequence-1] == delta &&
+				sameClass(runes[sequence], runes[i]) {
+				sequence++
+			}
+
+			if sequence-i >= 3 {
+				matches = append(matches, match{
+					start: i, end: sequence, bits: classBits(runes[i]) + math.Log2(float64(sequence-i)),
+					warning: "sequence like abc or 123",
+				})
+			}
+		}
+
+		for keyboard < len(runes) && adjacentKeys(runes[keyboard-1], runes[keyboard]) {
+			keyboard++
+		}
+
+		if keyboard-i >= 4 {
+			matches = append(matches, match{
+				start: i, end: keyboard, bits: math.Log2(float64(len(keyboardRows) * 10 * (keyboard - i))),
+				warning: "keyboard pattern",
+			})
+		}
+	}
+
+	return matches
+}
+
+// yearMatches - finds years from 1900 to 2039.
+func yearMatches(runes []rune) []match {
+	var matches []match
+
+	for i := 0; i+4 <= len(runes); i++ {
+		year := string(runes[i : i+4])
+		if year >= "1900" && year <= "2039" && strings.Trim(year, "0123456789") == "" {
+			matches = append(matches, match{start: i, end: i + 4, bits: math.Log2(140), warning: "year"})
+		}
+	}
+
+	return matches
+}
+
+// unleet - replaces leet substitutions with letters and returns count of replaced characters.
+func unleet(s string) (string, int) {
+	var count int
+
+	unleeted := strings.Map(func(r rune) rune {
+		if letter, ok := leet[r]; ok {
+			count++
+
+			return letter
+		}
+
+		return r
+	}, s)
+
+	return unleeted, count
+}
+
+// capitalBits - returns extra bits of a word with capitals, first capital letter costs one bit.
+func capitalBits(token []rune) float64 {
+	var upper int
+
+	for _, r := range token {
+		if unicode.IsUpper(r) {
+			upper++
+		}
+	}
+
+	switch {
+	case upper == 0:
+		return 0
+	case upper == 1 && unicode.IsUpper(token[0]), upper == len(token):
+		return 1
+	default:
+		return float64(upper)
+	}
+}
+
+// classBits - returns bits of guessing a character within its class.
+func classBits(r rune) float64 {
+	switch {
+	case unicode.IsDigit(r):
+		return math.Log2(10)
+	case unicode.IsLower(r), unicode.IsUpper(r):
+		return math.Log2(26)
+	default:
+		return math.Log2(33)
+	}
+}
+
+// sameClass - reports whether characters are both digits, lower or upper letters.
+func sameClass(a, b rune) bool {
+	return unicode.IsDigit(a) == unicode.IsDigit(b) && unicode.IsLower(a) == unicode.IsLower(b) &&
+		unicode.IsUpper(a) == unicode.IsUpper(b)
+}
+
+// adjacentKeys - reports whether keys are next to each other in a row of keyboard.
+func adjacentKeys(a, b rune) bool {
+	a, b = unicode.ToLower(a), unicode.ToLower(b)
+
+	for _, row := range keyboardRows {
+		i, j := strings.IndexRune(row, a), strings.IndexRune(row, b)
+		if i >= 0 && j >= 0 && (i-j == 1 || j-i == 1) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ranks - returns ranks of words starting from 1.
+func ranks(list []string) map[string]int {
+	result := make(map[string]int, len(list))
+	for i, word := range list {
+		if _, ok := result[word]; !ok {
+			result[word] = i + 1
+		}
+	}
+
+	return result
+}
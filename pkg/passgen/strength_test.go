@@ -0,0 +1,97 @@
+package passgen
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimateStrength(t *testing.T) {
+	wordBits := math.Log2(float64(len(words)))
+
+	tests := []struct {
+		name         string
+		password     string
+		wantEntropy  float64
+		wantScore    int
+		wantWarnings []string
+	}{
+		{name: "empty", password: "", wantEntropy: 0, wantScore: 0},
+		{
+			name: "common password", password: "password", wantEntropy: 1, wantScore: 0,
+			wantWarnings: []string{"common password"},
+		},
+		{
+			name: "capital costs one bit", password: "Password", wantEntropy: 2, wantScore: 0,
+			wantWarnings: []string{"common password"},
+		},
+		{
+			name: "leet substitution costs one bit", password: "p@ssw0rd", wantEntropy: 3, wantScore: 0,
+			wantWarnings: []string{"common password"},
+		},
+		{
+			name: "descending sequence", password: "987654", wantEntropy: math.Log2(10) + math.Log2(6), wantScore: 0,
+			wantWarnings: []string{"sequence like abc or 123"},
+		},
+		{
+			name: "keyboard pattern", password: "qwer", wantEntropy: math.Log2(4 * 10 * 4), wantScore: 0,
+			wantWarnings: []string{"keyboard pattern"},
+		},
+		{
+			name: "year", password: "1987", wantEntropy: math.Log2(140), wantScore: 0,
+			wantWarnings: []string{"year"},
+		},
+		{
+			name: "dictionary word", password: "agent", wantEntropy: wordBits, wantScore: 1,
+			wantWarnings: []string{"dictionary word"},
+		},
+		{
+			name: "capitalized words", password: "AgentAcorn", wantEntropy: 2 * (wordBits + 1), wantScore: 2,
+			wantWarnings: []string{"dictionary word"},
+		},
+		{
+			name: "words and year", password: "abacusabbey1987", wantEntropy: 2*wordBits + math.Log2(140),
+			wantScore: 3, wantWarnings: []string{"dictionary word", "year"},
+		},
+		{
+			name: "no patterns", password: "xK9#mQ2$vL7@pR4!", wantEntropy: 16 * bruteforceBits, wantScore: 4,
+		},
+		{
+			name: "tail over searched length is bruteforce", password: strings.Repeat("x", 150),
+			wantEntropy: math.Log2(26) + math.Log2(maxEstimatedLength) + 50*bruteforceBits, wantScore: 4,
+			wantWarnings: []string{"repeated characters"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			estimate := EstimateStrength(tt.password)
+
+			assert.InDelta(t, tt.wantEntropy, estimate.Entropy, 1e-6)
+			assert.Equal(t, tt.wantScore, estimate.Score)
+			assert.Equal(t, tt.wantWarnings, estimate.Warnings)
+			assert.Equal(t, scoreLabels[tt.wantScore], estimate.Label())
+		})
+	}
+}
+
+func TestScore(t *testing.T) {
+	tests := []struct {
+		guesses float64
+		want    int
+	}{
+		{guesses: 0, want: 0},
+		{guesses: 2.9, want: 0},
+		{guesses: 3, want: 1},
+		{guesses: 5.9, want: 1},
+		{guesses: 6, want: 2},
+		{guesses: 8, want: 3},
+		{guesses: 9.9, want: 3},
+		{guesses: 10, want: 4},
+	}
+	for _, tt := range tests {
+		// score takes entropy, thresholds are powers of 10 guesses
+		assert.Equal(t, tt.want, score(tt.guesses*math.Log2(10)), "10^%v guesses", tt.guesses)
+	}
+}
@@ -0,0 +1,151 @@
+// Package redact masks secret values in streamed output, values split between writes are masked too.
+package redact
+
+import (
+	"bytes"
+	"io"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Mask - replaces every secret value in output.
+const Mask = "*****"
+
+// holdDelay - is how long tail of output which may start a secret waits for the next write, so a prompt of
+// interactive process isn't stalled by it.
+const holdDelay = 100 * time.Millisecond
+
+// Writer - is io.Writer which masks secrets before writing to underlying writer.
+//
+// Tail of output which may start a secret is held until next write, Close, or until holdDelay passes without writes,
+// as the process has stopped writing, e.g. waits for input.
+type Writer struct {
+	w       io.Writer
+	secrets [][]byte
+	delay   time.Duration
+
+	mu   sync.Mutex
+	held []byte
+	// writes - counts writes, so held tail is written after delay only if no write came since it was held.
+	writes int
+	timer  *time.Timer
+	// err - is error of writing held tail after delay, it is returned by the next call.
+	err error
+}
+
+// NewWriter - creates Writer masking secrets, empty values are ignored.
+func NewWriter(w io.Writer, secrets []string) *Writer {
+	values := make([][]byte, 0, len(secrets))
+	for _, secret := range secrets {
+		if secret != "" {
+			values = append(values, []byte(secret))
+		}
+	}
+
+	// longer values go first, so a secret containing another one is masked whole
+	sort.Slice(values, func(i, j int) bool {
+		return len(values[i]) > len(values[j])
+	})
+
+	return &Writer{w: w, secrets: values, delay: holdDelay}
+}
+
+// Write - masks secrets of p and writes it, except a tail which may start a secret.
+func (r *Writer) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.stop(); err != nil {
+		return 0, err
+	}
+
+	buf := append(r.held, p...)
+	out := make([]byte, 0, len(buf))
+
+	i := 0
+
+scan:
+	for i < len(buf) {
+		// secrets are sorted longest first, so start of longer secret is held rather than shorter one is masked
+		for _, secret := range r.secrets {
+			if bytes.HasPrefix(buf[i:], secret) {
+				out = append(out, Mask...)
+				i += len(secret)
+
+				continue scan
+			}
+
+			if bytes.HasPrefix(secret, buf[i:]) {
+				break scan
+			}
+		}
+
+		out = append(out, buf[i])
+		i++
+	}
+
+	r.held = append([]byte(nil), buf[i:]...)
+
+	if _, err := r.w.Write(out); err != nil {
+		return 0, err
+	}
+
+	if len(r.held) > 0 {
+		writes := r.writes
+		r.timer = time.AfterFunc(r.delay, func() { r.writeIdle(writes) })
+	}
+
+	return len(p), nil
+}
+
+// Close - writes held tail, which turned out not to be a secret as output is over, underlying writer isn't closed.
+func (r *Writer) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.stop(); err != nil {
+		return err
+	}
+
+	return r.writeHeld()
+}
+
+// stop - cancels writing of held tail after delay and returns error of the previous one.
+func (r *Writer) stop() error {
+	r.writes++
+
+	if r.timer != nil {
+		r.timer.Stop()
+		r.timer = nil
+	}
+
+	err := r.err
+	r.err = nil
+
+	return err
+}
+
+// writeIdle - writes held tail if no write came since it was held.
+func (r *Writer) writeIdle(writes int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if writes != r.writes {
+		return
+	}
+
+	r.err = r.writeHeld()
+}
+
+// writeHeld - writes held tail as is.
+func (r *Writer) writeHeld() error {
+	if len(r.held) == 0 {
+		return nil
+	}
+
+	_, err := r.w.Write(r.held)
+	r.held = nil
+
+	return err
+}
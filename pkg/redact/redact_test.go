@@ -0,0 +1,125 @@
+package redact
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// syncBuffer - is bytes.Buffer safe to write from held tail timer.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.String()
+}
+
+func TestWriter(t *testing.T) {
+	tests := []struct {
+		name    string
+		secrets []string
+		writes  []string
+		want    string
+	}{
+		{name: "no secrets", writes: []string{"plain ", "text"}, want: "plain text"},
+		{name: "whole secret", secrets: []string{"p@ss"}, writes: []string{"pw=p@ss\n"}, want: "pw=*****\n"},
+		{
+			name:    "secret split across writes",
+			secrets: []string{"p@ss"},
+			writes:  []string{"pw=p", "@", "ss\n"},
+			want:    "pw=*****\n",
+		},
+		{
+			name:    "held bytes are not a secret",
+			secrets: []string{"p@ss"},
+			writes:  []string{"pw=p@", "x\n"},
+			want:    "pw=p@x\n",
+		},
+		{
+			name:    "secret contains another one",
+			secrets: []string{"abc", "abcdef"},
+			writes:  []string{"x abcd", "ef abc y"},
+			want:    "x ***** ***** y",
+		},
+		{
+			name:    "secrets overlap",
+			secrets: []string{"abc", "bcd"},
+			writes:  []string{"abcd bcd"},
+			want:    "*****d *****",
+		},
+		{name: "empty secret is ignored", secrets: []string{""}, writes: []string{"text"}, want: "text"},
+		{
+			name:    "close writes held bytes",
+			secrets: []string{"p@ss"},
+			writes:  []string{"pw=p@s"},
+			want:    "pw=p@s",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			w := NewWriter(&buf, tt.secrets)
+			for _, s := range tt.writes {
+				n, err := w.Write([]byte(s))
+				require.NoError(t, err)
+				assert.Equal(t, len(s), n)
+			}
+
+			require.NoError(t, w.Close())
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestWriter_HeldBytesAreWrittenWhenWritesStop(t *testing.T) {
+	buf := &syncBuffer{}
+
+	w := NewWriter(buf, []string{"p@ss"})
+	w.delay = 10 * time.Millisecond
+
+	// prompt of interactive process ends with start of secret
+	_, err := w.Write([]byte("continue? p"))
+	require.NoError(t, err)
+	assert.Equal(t, "continue? ", buf.String())
+
+	assert.Eventually(t, func() bool { return buf.String() == "continue? p" }, time.Second, time.Millisecond)
+
+	require.NoError(t, w.Close())
+	assert.Equal(t, "continue? p", buf.String())
+}
+
+func TestWriter_NextWriteCancelsHeldBytesWrite(t *testing.T) {
+	buf := &syncBuffer{}
+
+	w := NewWriter(buf, []string{"p@ss"})
+	w.delay = 50 * time.Millisecond
+
+	_, err := w.Write([]byte("pw=p@"))
+	require.NoError(t, err)
+
+	_, err = w.Write([]byte("ss"))
+	require.NoError(t, err)
+
+	time.Sleep(2 * w.delay)
+	assert.Equal(t, "pw=*****", buf.String())
+
+	require.NoError(t, w.Close())
+	assert.Equal(t, "pw=*****", buf.String())
+}
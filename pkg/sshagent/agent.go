@@ -0,0 +1,264 @@
+// Package sshagent serves SSH keys stored in the vault over ssh-agent protocol, so keys never touch the disk.
+package sshagent
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+)
+
+var (
+	// ErrReadOnly - is returned for requests which modify keys of the agent, keys are managed by the vault.
+	ErrReadOnly = errors.New("keys are managed by the vault")
+	ErrDenied   = errors.New("use of key is denied")
+	ErrNotFound = errors.New("key is not found")
+)
+
+// Identity - is a key served by Agent.
+type Identity struct {
+	Signer  ssh.Signer
+	Comment string
+	// Confirm - every use of the key must be confirmed.
+	Confirm bool
+}
+
+// KeySource - loads identities on every request, so keys are served only while the vault is available.
+type KeySource func() ([]Identity, error)
+
+// Confirmer - asks user whether key with comment may be used, false denies signing.
+type Confirmer func(comment string) bool
+
+// Agent - is agent.ExtendedAgent which serves identities of KeySource.
+type Agent struct {
+	source  KeySource
+	confirm Confirmer
+	// confirmAll - every use of any key must be confirmed.
+	confirmAll bool
+	// mu - serializes confirmations, so user is asked about one use at a time.
+	mu sync.Mutex
+}
+
+// NewAgent - creates Agent, confirm may be nil, then keys which need confirmation are never used.
+func NewAgent(source KeySource, confirm Confirmer, confirmAll bool) *Agent {
+	return &Agent{source: source, confirm: confirm, confirmAll: confirmAll}
+}
+
+// List - returns public keys of identities.
+func (a *Agent) List() ([]*agent.Key, error) {
+	identities, err := a.source()
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]*agent.Key, 0, len(identities))
+	for _, identity := range identities {
+		pub := identity.Signer.PublicKey()
+		keys = append(keys, &agent.Key{Format: pub.Type(), Blob: pub.Marshal(), Comment: identity.Comment})
+	}
+
+	return keys, nil
+}
+
+// Sign - signs data with identity of key.
+func (a *Agent) Sign(key ssh.PublicKey, data []byte) (*ssh.Signature, error) {
+	return a.SignWithFlags(key, data, 0)
+}
+
+// SignWithFlags - signs data with identity of key, flags pick SHA-2 algorithms of RSA keys.
+func (a *Agent) SignWithFlags(key ssh.PublicKey, data []byte, flags agent.SignatureFlags) (*ssh.Signature, error) {
+	identities, err := a.source()
+	if err != nil {
+		return nil, err
+	}
+
+	wanted := key.Marshal()
+
+	for _, identity := range identities {
+		if !bytes.Equal(identity.Signer.PublicKey().Marshal(), wanted) {
+			continue
+		}
+
+		if (a.confirmAll || identity.Confirm) && !a.confirmed(identity.Comment) {
+			return nil, ErrDenied
+		}
+
+		if flags == 0 {
+			return identity.Signer.Sign(rand.Reader, data)
+		}
+
+		algorithmSigner, ok := identity.Signer.(ssh.AlgorithmSigner)
+		if !ok {
+			return nil, fmt.Errorf("key %s doesn't support signature flags", identity.Comment)
+		}
+
+		var algorithm string
+
+		switch flags {
+		case agent.SignatureFlagRsaSha256:
+			algorithm = ssh.KeyAlgoRSASHA256
+		case agent.SignatureFlagRsaSha512:
+			algorithm = ssh.KeyAlgoRSASHA512
+		default:
+			return nil, fmt.Errorf("unsupported signature flags: %d", flags)
+		}
+
+		return algorithmSigner.SignWithAlgorithm(rand.Reader, data, algorithm)
+	}
+
+	return nil, ErrNotFound
+}
+
+// confirmed - asks Confirmer about use of key.
+func (a *Agent) confirmed(comment string) bool {
+	if a.confirm == nil {
+		return false
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.confirm(comment)
+}
+
+// Signers - returns signers of identities.
+func (a *Agent) Signers() ([]ssh.Signer, error) {
+	identities, err := a.source()
+	if err != nil {
+		return nil, err
+	}
+
+	signers := make([]ssh.Signer, 0, len(identities))
+	for _, identity := range identities {
+		signers = append(signers, identity.Signer)
+	}
+
+	return signers, nil
+}
+
+// Add - is not supported, keys are added to the vault.
+func (a *Agent) Add(agent.AddedKey) error {
+	return ErrReadOnly
+}
+
+// Remove - is not supported, keys are removed from the vault.
+func (a *Agent) Remove(ssh.PublicKey) error {
+	return ErrReadOnly
+}
+
+// RemoveAll - is not supported, keys are removed from the vault.
+func (a *Agent) RemoveAll() error {
+	return ErrReadOnly
+}
+
+// Lock - is not supported, keys are served only while user is logged in.
+func (a *Agent) Lock([]byte) error {
+	return ErrReadOnly
+}
+
+// Unlock - is not supported, keys are served only while user is logged in.
+func (a *Agent) Unlock([]byte) error {
+	return ErrReadOnly
+}
+
+// Extension - no extensions are supported.
+func (a *Agent) Extension(string, []byte) ([]byte, error) {
+	return nil, agent.ErrExtensionUnsupported
+}
+
+// Listen - creates Unix socket at path which is accessible only by its owner.
+//
+// Socket left by agent which wasn't stopped properly is replaced.
+func Listen(path string) (net.Listener, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("error in creating directory of socket: %w", err)
+	}
+
+	if info, err := os.Lstat(path); err == nil && info.Mode()&os.ModeSocket != 0 {
+		if conn, errDial := net.Dial("unix", path); errDial == nil {
+			conn.Close()
+
+			return nil, fmt.Errorf("agent is already listening on %s", path)
+		}
+
+		os.Remove(path)
+	}
+
+	// socket is bound in a fresh directory only the owner can enter, so nobody can connect to it before chmod,
+	// and is moved to path after that.
+	dir, err := os.MkdirTemp(filepath.Dir(path), ".agent-")
+	if err != nil {
+		return nil, fmt.Errorf("error in creating directory of socket: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	tmp := filepath.Join(dir, "s")
+
+	l, err := net.Listen("unix", tmp)
+	if err != nil {
+		return nil, fmt.Errorf("error in listening on socket: %w", err)
+	}
+
+	l.(*net.UnixListener).SetUnlinkOnClose(false)
+
+	if errChmod := os.Chmod(tmp, 0o600); errChmod != nil {
+		l.Close()
+
+		return nil, fmt.Errorf("error in protecting socket: %w", errChmod)
+	}
+
+	if errRename := os.Rename(tmp, path); errRename != nil {
+		l.Close()
+
+		return nil, fmt.Errorf("error in moving socket: %w", errRename)
+	}
+
+	return &socketListener{Listener: l, path: path}, nil
+}
+
+// socketListener - removes socket at path on Close, as it was bound at another path.
+type socketListener struct {
+	net.Listener
+	path string
+}
+
+// Close - closes listener and removes its socket.
+func (s *socketListener) Close() error {
+	err := s.Listener.Close()
+	os.Remove(s.path)
+
+	return err
+}
+
+// Serve - serves agent on listener until ctx is done, socket is removed on return.
+func Serve(ctx context.Context, l net.Listener, a agent.Agent) error {
+	go func() {
+		<-ctx.Done()
+		l.Close()
+	}()
+
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+
+			return fmt.Errorf("error in accepting agent connection: %w", err)
+		}
+
+		go func() {
+			defer conn.Close()
+
+			_ = agent.ServeAgent(a, conn)
+		}()
+	}
+}
@@ -0,0 +1,140 @@
+package sshagent
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	KeyEd25519 = "ed25519"
+	KeyRSA     = "rsa"
+	KeyECDSA   = "ecdsa"
+
+	defaultRSABits = 3072
+)
+
+var (
+	ErrKeyType = errors.New("key type must be ed25519, rsa or ecdsa")
+	ErrKeyBits = errors.New("unsupported key size")
+	// ErrPassphrase - is returned by ImportKey for encrypted key if passphrase is missing or wrong.
+	ErrPassphrase = errors.New("key is protected by passphrase")
+)
+
+// Key - is private key in PKCS#8 PEM with its public key in authorized_keys format.
+type Key struct {
+	PrivateKey string
+	PublicKey  string
+}
+
+// GenerateKey - generates key of provided type, bits are used by rsa and ecdsa keys only, zero picks default size.
+func GenerateKey(keyType string, bits int, comment string) (Key, error) {
+	var (
+		raw interface{}
+		err error
+	)
+
+	switch strings.ToLower(keyType) {
+	case "", KeyEd25519:
+		_, raw, err = ed25519.GenerateKey(rand.Reader)
+	case KeyRSA:
+		if bits == 0 {
+			bits = defaultRSABits
+		}
+
+		if bits < 2048 {
+			return Key{}, ErrKeyBits
+		}
+
+		raw, err = rsa.GenerateKey(rand.Reader, bits)
+	case KeyECDSA:
+		curve, ok := map[int]elliptic.Curve{0: elliptic.P256(), 256: elliptic.P256(), 384: elliptic.P384(),
+			521: elliptic.P521()}[bits]
+		if !ok {
+			return Key{}, ErrKeyBits
+		}
+
+		raw, err = ecdsa.GenerateKey(curve, rand.Reader)
+	default:
+		return Key{}, ErrKeyType
+	}
+
+	if err != nil {
+		return Key{}, fmt.Errorf("error in generating key: %w", err)
+	}
+
+	return newKey(raw, comment)
+}
+
+// ImportKey - parses private key in OpenSSH, PKCS#1, SEC 1 or PKCS#8 format, passphrase is used for encrypted key.
+//
+// Key is stored unencrypted, as the vault encrypts it.
+func ImportKey(data []byte, passphrase string, comment string) (Key, error) {
+	var (
+		raw interface{}
+		err error
+	)
+
+	if passphrase == "" {
+		raw, err = ssh.ParseRawPrivateKey(data)
+	} else {
+		raw, err = ssh.ParseRawPrivateKeyWithPassphrase(data, []byte(passphrase))
+	}
+
+	var missing *ssh.PassphraseMissingError
+	if errors.As(err, &missing) || errors.Is(err, x509.IncorrectPasswordError) {
+		return Key{}, ErrPassphrase
+	}
+
+	if err != nil {
+		return Key{}, fmt.Errorf("error in parsing private key: %w", err)
+	}
+
+	// OpenSSH ed25519 keys are parsed as pointers, PKCS#8 needs values
+	if key, ok := raw.(*ed25519.PrivateKey); ok {
+		raw = *key
+	}
+
+	return newKey(raw, comment)
+}
+
+// ParseSigner - parses signer of private key stored in Key.
+func ParseSigner(privateKey string) (ssh.Signer, error) {
+	signer, err := ssh.ParsePrivateKey([]byte(privateKey))
+	if err != nil {
+		return nil, fmt.Errorf("error in parsing private key: %w", err)
+	}
+
+	return signer, nil
+}
+
+// newKey - encodes raw private key as PKCS#8 PEM and its public key in authorized_keys format.
+func newKey(raw interface{}, comment string) (Key, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(raw)
+	if err != nil {
+		return Key{}, fmt.Errorf("error in encoding private key: %w", err)
+	}
+
+	privateKey := string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+
+	signer, errSigner := ParseSigner(privateKey)
+	if errSigner != nil {
+		return Key{}, errSigner
+	}
+
+	publicKey := strings.TrimSpace(string(ssh.MarshalAuthorizedKey(signer.PublicKey())))
+	if comment != "" {
+		publicKey += " " + comment
+	}
+
+	return Key{PrivateKey: privateKey, PublicKey: publicKey}, nil
+}
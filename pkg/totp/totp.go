@@ -0,0 +1,202 @@
+// Package totp generates time-based one-time passwords by RFC 6238 and parses otpauth:// URIs.
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultDigits    = 6
+	DefaultPeriod    = 30
+	DefaultAlgorithm = "SHA1"
+)
+
+var (
+	ErrSecret    = errors.New("seed must be base32 encoded")
+	ErrDigits    = errors.New("digits must be from 6 to 8")
+	ErrPeriod    = errors.New("period must be a positive number of seconds")
+	ErrAlgorithm = errors.New("algorithm must be SHA1, SHA256 or SHA512")
+	ErrURI       = errors.New("uri must be otpauth://totp/...")
+)
+
+// algorithms - are hash functions of HMAC allowed by RFC 6238.
+var algorithms = map[string]func() hash.Hash{
+	"SHA1":   sha1.New,
+	"SHA256": sha256.New,
+	"SHA512": sha512.New,
+}
+
+// Key - is a seed of TOTP with parameters of codes.
+type Key struct {
+	// Secret - is base32 encoded seed, spaces and padding are allowed.
+	Secret    string
+	Issuer    string
+	Account   string
+	Algorithm string
+	Digits    int
+	Period    int
+}
+
+// NewKey - creates Key with default parameters, as most services use them.
+func NewKey(secret string) (Key, error) {
+	key := Key{Secret: normalizeSecret(secret), Algorithm: DefaultAlgorithm, Digits: DefaultDigits, Period: DefaultPeriod}
+
+	return key, key.Validate()
+}
+
+// ParseURI - parses key from otpauth://totp/Issuer:account?secret=...&issuer=...&algorithm=...&digits=...&period=...
+// URI, which is encoded in QR codes of services.
+func ParseURI(uri string) (Key, error) {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "otpauth" || u.Host != "totp" {
+		return Key{}, ErrURI
+	}
+
+	query := u.Query()
+
+	key := Key{
+		Secret:    normalizeSecret(query.Get("secret")),
+		Issuer:    query.Get("issuer"),
+		Algorithm: strings.ToUpper(query.Get("algorithm")),
+		Digits:    DefaultDigits,
+		Period:    DefaultPeriod,
+	}
+
+	label := strings.TrimPrefix(u.Path, "/")
+	if issuer, account, ok := strings.Cut(label, ":"); ok {
+		key.Account = strings.TrimSpace(account)
+		if key.Issuer == "" {
+			key.Issuer = issuer
+		}
+	} else {
+		key.Account = label
+	}
+
+	if key.Algorithm == "" {
+		key.Algorithm = DefaultAlgorithm
+	}
+
+	if digits := query.Get("digits"); digits != "" {
+		if key.Digits, err = strconv.Atoi(digits); err != nil {
+			return Key{}, ErrDigits
+		}
+	}
+
+	if period := query.Get("period"); period != "" {
+		if key.Period, err = strconv.Atoi(period); err != nil {
+			return Key{}, ErrPeriod
+		}
+	}
+
+	return key, key.Validate()
+}
+
+// Validate - checks parameters of Key.
+func (k Key) Validate() error {
+	if _, err := k.seed(); err != nil || k.Secret == "" {
+		return ErrSecret
+	}
+
+	if k.Digits < 6 || k.Digits > 8 {
+		return ErrDigits
+	}
+
+	if k.Period <= 0 {
+		return ErrPeriod
+	}
+
+	if _, ok := algorithms[k.Algorithm]; !ok {
+		return ErrAlgorithm
+	}
+
+	return nil
+}
+
+// Code - returns code valid at t.
+func (k Key) Code(t time.Time) (string, error) {
+	if err := k.Validate(); err != nil {
+		return "", err
+	}
+
+	seed, _ := k.seed()
+
+	counter := make([]byte, 8)
+	binary.BigEndian.PutUint64(counter, uint64(t.Unix()/int64(k.Period)))
+
+	mac := hmac.New(algorithms[k.Algorithm], seed)
+	mac.Write(counter)
+	sum := mac.Sum(nil)
+
+	// dynamic truncation of RFC 4226
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	modulo := uint32(1)
+	for i := 0; i < k.Digits; i++ {
+		modulo *= 10
+	}
+
+	return fmt.Sprintf("%0*d", k.Digits, value%modulo), nil
+}
+
+// URI - returns otpauth:// URI of Key, which ParseURI reads back and other authenticators import.
+func (k Key) URI() string {
+	label := k.Account
+	if k.Issuer != "" {
+		label = k.Issuer + ":" + k.Account
+	}
+
+	query := url.Values{}
+	query.Set("secret", strings.TrimRight(normalizeSecret(k.Secret), "="))
+
+	if k.Issuer != "" {
+		query.Set("issuer", k.Issuer)
+	}
+
+	if k.Algorithm != "" {
+		query.Set("algorithm", k.Algorithm)
+	}
+
+	if k.Digits != 0 {
+		query.Set("digits", strconv.Itoa(k.Digits))
+	}
+
+	if k.Period != 0 {
+		query.Set("period", strconv.Itoa(k.Period))
+	}
+
+	u := url.URL{Scheme: "otpauth", Host: "totp", Path: "/" + label, RawQuery: query.Encode()}
+
+	return u.String()
+}
+
+// Remaining - returns seconds the code valid at t has left.
+func (k Key) Remaining(t time.Time) int {
+	if k.Period <= 0 {
+		return 0
+	}
+
+	return k.Period - int(t.Unix()%int64(k.Period))
+}
+
+// seed - decodes base32 secret.
+func (k Key) seed() ([]byte, error) {
+	return base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(strings.TrimRight(k.Secret, "="))
+}
+
+// normalizeSecret - removes spaces and dashes, which are used to group seeds for reading, and upper-cases it.
+func normalizeSecret(secret string) string {
+	return strings.ToUpper(strings.NewReplacer(" ", "", "-", "").Replace(secret))
+}
@@ -0,0 +1,87 @@
+package totp
+
+import (
+	"encoding/base32"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKey_Code(t *testing.T) {
+	// seeds of test vectors of RFC 6238, the seed is repeated up to the size of hash
+	seeds := map[string]string{
+		"SHA1":   "12345678901234567890",
+		"SHA256": "12345678901234567890123456789012",
+		"SHA512": "1234567890123456789012345678901234567890123456789012345678901234",
+	}
+
+	tests := []struct {
+		name      string
+		algorithm string
+		unix      int64
+		want      string
+	}{
+		{name: "SHA1 at 59", algorithm: "SHA1", unix: 59, want: "94287082"},
+		{name: "SHA1 at 1111111109", algorithm: "SHA1", unix: 1111111109, want: "07081804"},
+		{name: "SHA1 at 1111111111", algorithm: "SHA1", unix: 1111111111, want: "14050471"},
+		{name: "SHA1 at 1234567890", algorithm: "SHA1", unix: 1234567890, want: "89005924"},
+		{name: "SHA1 at 2000000000", algorithm: "SHA1", unix: 2000000000, want: "69279037"},
+		{name: "SHA1 at 20000000000", algorithm: "SHA1", unix: 20000000000, want: "65353130"},
+		{name: "SHA256 at 59", algorithm: "SHA256", unix: 59, want: "46119246"},
+		{name: "SHA512 at 59", algorithm: "SHA512", unix: 59, want: "90693936"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key := Key{
+				Secret:    base32.StdEncoding.EncodeToString([]byte(seeds[tt.algorithm])),
+				Algorithm: tt.algorithm,
+				Digits:    8,
+				Period:    DefaultPeriod,
+			}
+
+			got, err := key.Code(time.Unix(tt.unix, 0))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestKey_Validate(t *testing.T) {
+	tests := []struct {
+		name string
+		key  Key
+		want error
+	}{
+		{name: "default key is valid", key: Key{Secret: "JBSWY3DPEHPK3PXP", Algorithm: "SHA1", Digits: 6, Period: 30}},
+		{name: "seed must be base32", key: Key{Secret: "not base32!", Algorithm: "SHA1", Digits: 6, Period: 30},
+			want: ErrSecret},
+		{name: "digits are limited", key: Key{Secret: "JBSWY3DPEHPK3PXP", Algorithm: "SHA1", Digits: 9, Period: 30},
+			want: ErrDigits},
+		{name: "period must be positive", key: Key{Secret: "JBSWY3DPEHPK3PXP", Algorithm: "SHA1", Digits: 6},
+			want: ErrPeriod},
+		{name: "algorithm must be known", key: Key{Secret: "JBSWY3DPEHPK3PXP", Algorithm: "MD5", Digits: 6, Period: 30},
+			want: ErrAlgorithm},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, tt.key.Validate(), tt.want)
+		})
+	}
+}
+
+func TestParseURI(t *testing.T) {
+	key, err := ParseURI("otpauth://totp/ACME:alice@example.com?secret=JBSWY3DPEHPK3PXP&issuer=ACME&digits=8&period=60")
+	assert.NoError(t, err)
+	assert.Equal(t, "ACME", key.Issuer)
+	assert.Equal(t, "alice@example.com", key.Account)
+	assert.Equal(t, 8, key.Digits)
+	assert.Equal(t, 60, key.Period)
+
+	parsed, errParse := ParseURI(key.URI())
+	assert.NoError(t, errParse)
+	assert.Equal(t, key, parsed, "URI is read back as is")
+
+	_, err = ParseURI("otpauth://hotp/ACME:alice?secret=JBSWY3DPEHPK3PXP")
+	assert.Error(t, err)
+}
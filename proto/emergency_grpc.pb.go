@@ -0,0 +1,368 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v3.12.4
+// source: proto/emergency.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	Emergency_LookupContact_FullMethodName      = "/proto.Emergency/LookupContact"
+	Emergency_AddContact_FullMethodName         = "/proto.Emergency/AddContact"
+	Emergency_RemoveContact_FullMethodName      = "/proto.Emergency/RemoveContact"
+	Emergency_ListContacts_FullMethodName       = "/proto.Emergency/ListContacts"
+	Emergency_ListGrantors_FullMethodName       = "/proto.Emergency/ListGrantors"
+	Emergency_RequestAccess_FullMethodName      = "/proto.Emergency/RequestAccess"
+	Emergency_DenyAccess_FullMethodName         = "/proto.Emergency/DenyAccess"
+	Emergency_GetEmergencyAccess_FullMethodName = "/proto.Emergency/GetEmergencyAccess"
+)
+
+// EmergencyClient is the client API for Emergency service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type EmergencyClient interface {
+	LookupContact(ctx context.Context, in *LookupContactRequest, opts ...grpc.CallOption) (*LookupContactResponse, error)
+	AddContact(ctx context.Context, in *AddContactRequest, opts ...grpc.CallOption) (*AddContactResponse, error)
+	RemoveContact(ctx context.Context, in *RemoveContactRequest, opts ...grpc.CallOption) (*RemoveContactResponse, error)
+	ListContacts(ctx context.Context, in *ListContactsRequest, opts ...grpc.CallOption) (*ListContactsResponse, error)
+	ListGrantors(ctx context.Context, in *ListGrantorsRequest, opts ...grpc.CallOption) (*ListGrantorsResponse, error)
+	RequestAccess(ctx context.Context, in *RequestAccessRequest, opts ...grpc.CallOption) (*RequestAccessResponse, error)
+	DenyAccess(ctx context.Context, in *DenyAccessRequest, opts ...grpc.CallOption) (*DenyAccessResponse, error)
+	GetEmergencyAccess(ctx context.Context, in *GetEmergencyAccessRequest, opts ...grpc.CallOption) (*GetEmergencyAccessResponse, error)
+}
+
+type emergencyClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewEmergencyClient(cc grpc.ClientConnInterface) EmergencyClient {
+	return &emergencyClient{cc}
+}
+
+func (c *emergencyClient) LookupContact(ctx context.Context, in *LookupContactRequest, opts ...grpc.CallOption) (*LookupContactResponse, error) {
+	out := new(LookupContactResponse)
+	err := c.cc.Invoke(ctx, Emergency_LookupContact_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emergencyClient) AddContact(ctx context.Context, in *AddContactRequest, opts ...grpc.CallOption) (*AddContactResponse, error) {
+	out := new(AddContactResponse)
+	err := c.cc.Invoke(ctx, Emergency_AddContact_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emergencyClient) RemoveContact(ctx context.Context, in *RemoveContactRequest, opts ...grpc.CallOption) (*RemoveContactResponse, error) {
+	out := new(RemoveContactResponse)
+	err := c.cc.Invoke(ctx, Emergency_RemoveContact_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emergencyClient) ListContacts(ctx context.Context, in *ListContactsRequest, opts ...grpc.CallOption) (*ListContactsResponse, error) {
+	out := new(ListContactsResponse)
+	err := c.cc.Invoke(ctx, Emergency_ListContacts_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emergencyClient) ListGrantors(ctx context.Context, in *ListGrantorsRequest, opts ...grpc.CallOption) (*ListGrantorsResponse, error) {
+	out := new(ListGrantorsResponse)
+	err := c.cc.Invoke(ctx, Emergency_ListGrantors_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emergencyClient) RequestAccess(ctx context.Context, in *RequestAccessRequest, opts ...grpc.CallOption) (*RequestAccessResponse, error) {
+	out := new(RequestAccessResponse)
+	err := c.cc.Invoke(ctx, Emergency_RequestAccess_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emergencyClient) DenyAccess(ctx context.Context, in *DenyAccessRequest, opts ...grpc.CallOption) (*DenyAccessResponse, error) {
+	out := new(DenyAccessResponse)
+	err := c.cc.Invoke(ctx, Emergency_DenyAccess_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emergencyClient) GetEmergencyAccess(ctx context.Context, in *GetEmergencyAccessRequest, opts ...grpc.CallOption) (*GetEmergencyAccessResponse, error) {
+	out := new(GetEmergencyAccessResponse)
+	err := c.cc.Invoke(ctx, Emergency_GetEmergencyAccess_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// EmergencyServer is the server API for Emergency service.
+// All implementations must embed UnimplementedEmergencyServer
+// for forward compatibility
+type EmergencyServer interface {
+	LookupContact(context.Context, *LookupContactRequest) (*LookupContactResponse, error)
+	AddContact(context.Context, *AddContactRequest) (*AddContactResponse, error)
+	RemoveContact(context.Context, *RemoveContactRequest) (*RemoveContactResponse, error)
+	ListContacts(context.Context, *ListContactsRequest) (*ListContactsResponse, error)
+	ListGrantors(context.Context, *ListGrantorsRequest) (*ListGrantorsResponse, error)
+	RequestAccess(context.Context, *RequestAccessRequest) (*RequestAccessResponse, error)
+	DenyAccess(context.Context, *DenyAccessRequest) (*DenyAccessResponse, error)
+	GetEmergencyAccess(context.Context, *GetEmergencyAccessRequest) (*GetEmergencyAccessResponse, error)
+	mustEmbedUnimplementedEmergencyServer()
+}
+
+// UnimplementedEmergencyServer must be embedded to have forward compatible implementations.
+type UnimplementedEmergencyServer struct {
+}
+
+func (UnimplementedEmergencyServer) LookupContact(context.Context, *LookupContactRequest) (*LookupContactResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LookupContact not implemented")
+}
+func (UnimplementedEmergencyServer) AddContact(context.Context, *AddContactRequest) (*AddContactResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddContact not implemented")
+}
+func (UnimplementedEmergencyServer) RemoveContact(context.Context, *RemoveContactRequest) (*RemoveContactResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveContact not implemented")
+}
+func (UnimplementedEmergencyServer) ListContacts(context.Context, *ListContactsRequest) (*ListContactsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListContacts not implemented")
+}
+func (UnimplementedEmergencyServer) ListGrantors(context.Context, *ListGrantorsRequest) (*ListGrantorsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListGrantors not implemented")
+}
+func (UnimplementedEmergencyServer) RequestAccess(context.Context, *RequestAccessRequest) (*RequestAccessResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestAccess not implemented")
+}
+func (UnimplementedEmergencyServer) DenyAccess(context.Context, *DenyAccessRequest) (*DenyAccessResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DenyAccess not implemented")
+}
+func (UnimplementedEmergencyServer) GetEmergencyAccess(context.Context, *GetEmergencyAccessRequest) (*GetEmergencyAccessResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEmergencyAccess not implemented")
+}
+func (UnimplementedEmergencyServer) mustEmbedUnimplementedEmergencyServer() {}
+
+// UnsafeEmergencyServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to EmergencyServer will
+// result in compilation errors.
+type UnsafeEmergencyServer interface {
+	mustEmbedUnimplementedEmergencyServer()
+}
+
+func RegisterEmergencyServer(s grpc.ServiceRegistrar, srv EmergencyServer) {
+	s.RegisterService(&Emergency_ServiceDesc, srv)
+}
+
+func _Emergency_LookupContact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LookupContactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmergencyServer).LookupContact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Emergency_LookupContact_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmergencyServer).LookupContact(ctx, req.(*LookupContactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Emergency_AddContact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddContactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmergencyServer).AddContact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Emergency_AddContact_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmergencyServer).AddContact(ctx, req.(*AddContactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Emergency_RemoveContact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveContactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmergencyServer).RemoveContact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Emergency_RemoveContact_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmergencyServer).RemoveContact(ctx, req.(*RemoveContactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Emergency_ListContacts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListContactsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmergencyServer).ListContacts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Emergency_ListContacts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmergencyServer).ListContacts(ctx, req.(*ListContactsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Emergency_ListGrantors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListGrantorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmergencyServer).ListGrantors(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Emergency_ListGrantors_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmergencyServer).ListGrantors(ctx, req.(*ListGrantorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Emergency_RequestAccess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestAccessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmergencyServer).RequestAccess(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Emergency_RequestAccess_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmergencyServer).RequestAccess(ctx, req.(*RequestAccessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Emergency_DenyAccess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DenyAccessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmergencyServer).DenyAccess(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Emergency_DenyAccess_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmergencyServer).DenyAccess(ctx, req.(*DenyAccessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Emergency_GetEmergencyAccess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEmergencyAccessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmergencyServer).GetEmergencyAccess(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Emergency_GetEmergencyAccess_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmergencyServer).GetEmergencyAccess(ctx, req.(*GetEmergencyAccessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Emergency_ServiceDesc is the grpc.ServiceDesc for Emergency service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Emergency_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.Emergency",
+	HandlerType: (*EmergencyServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "LookupContact",
+			Handler:    _Emergency_LookupContact_Handler,
+		},
+		{
+			MethodName: "AddContact",
+			Handler:    _Emergency_AddContact_Handler,
+		},
+		{
+			MethodName: "RemoveContact",
+			Handler:    _Emergency_RemoveContact_Handler,
+		},
+		{
+			MethodName: "ListContacts",
+			Handler:    _Emergency_ListContacts_Handler,
+		},
+		{
+			MethodName: "ListGrantors",
+			Handler:    _Emergency_ListGrantors_Handler,
+		},
+		{
+			MethodName: "RequestAccess",
+			Handler:    _Emergency_RequestAccess_Handler,
+		},
+		{
+			MethodName: "DenyAccess",
+			Handler:    _Emergency_DenyAccess_Handler,
+		},
+		{
+			MethodName: "GetEmergencyAccess",
+			Handler:    _Emergency_GetEmergencyAccess_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/emergency.proto",
+}
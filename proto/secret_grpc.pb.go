@@ -20,10 +20,13 @@ const _ = grpc.SupportPackageIsVersion7
 
 const (
 	Secret_CreateSecret_FullMethodName           = "/proto.Secret/CreateSecret"
+	Secret_CreateSecrets_FullMethodName          = "/proto.Secret/CreateSecrets"
 	Secret_GetSecret_FullMethodName              = "/proto.Secret/GetSecret"
 	Secret_DeleteSecret_FullMethodName           = "/proto.Secret/DeleteSecret"
 	Secret_EditSecret_FullMethodName             = "/proto.Secret/EditSecret"
 	Secret_GetListOfSecretsByType_FullMethodName = "/proto.Secret/GetListOfSecretsByType"
+	Secret_SetSecretExpiry_FullMethodName        = "/proto.Secret/SetSecretExpiry"
+	Secret_GetDueEvents_FullMethodName           = "/proto.Secret/GetDueEvents"
 )
 
 // SecretClient is the client API for Secret service.
@@ -31,10 +34,13 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type SecretClient interface {
 	CreateSecret(ctx context.Context, in *CreateSecretRequest, opts ...grpc.CallOption) (*CreateSecretResponse, error)
+	CreateSecrets(ctx context.Context, in *CreateSecretsRequest, opts ...grpc.CallOption) (*CreateSecretsResponse, error)
 	GetSecret(ctx context.Context, in *GetSecretRequest, opts ...grpc.CallOption) (*GetSecretResponse, error)
 	DeleteSecret(ctx context.Context, in *DeleteSecretRequest, opts ...grpc.CallOption) (*DeleteSecretResponse, error)
 	EditSecret(ctx context.Context, in *EditSecretRequest, opts ...grpc.CallOption) (*EditSecretResponse, error)
 	GetListOfSecretsByType(ctx context.Context, in *GetListOfSecretsByTypeRequest, opts ...grpc.CallOption) (*GetListOfSecretsByTypeResponse, error)
+	SetSecretExpiry(ctx context.Context, in *SetSecretExpiryRequest, opts ...grpc.CallOption) (*SetSecretExpiryResponse, error)
+	GetDueEvents(ctx context.Context, in *GetDueEventsRequest, opts ...grpc.CallOption) (*GetDueEventsResponse, error)
 }
 
 type secretClient struct {
@@ -54,6 +60,15 @@ func (c *secretClient) CreateSecret(ctx context.Context, in *CreateSecretRequest
 	return out, nil
 }
 
+func (c *secretClient) CreateSecrets(ctx context.Context, in *CreateSecretsRequest, opts ...grpc.CallOption) (*CreateSecretsResponse, error) {
+	out := new(CreateSecretsResponse)
+	err := c.cc.Invoke(ctx, Secret_CreateSecrets_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *secretClient) GetSecret(ctx context.Context, in *GetSecretRequest, opts ...grpc.CallOption) (*GetSecretResponse, error) {
 	out := new(GetSecretResponse)
 	err := c.cc.Invoke(ctx, Secret_GetSecret_FullMethodName, in, out, opts...)
@@ -90,15 +105,36 @@ func (c *secretClient) GetListOfSecretsByType(ctx context.Context, in *GetListOf
 	return out, nil
 }
 
+func (c *secretClient) SetSecretExpiry(ctx context.Context, in *SetSecretExpiryRequest, opts ...grpc.CallOption) (*SetSecretExpiryResponse, error) {
+	out := new(SetSecretExpiryResponse)
+	err := c.cc.Invoke(ctx, Secret_SetSecretExpiry_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *secretClient) GetDueEvents(ctx context.Context, in *GetDueEventsRequest, opts ...grpc.CallOption) (*GetDueEventsResponse, error) {
+	out := new(GetDueEventsResponse)
+	err := c.cc.Invoke(ctx, Secret_GetDueEvents_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SecretServer is the server API for Secret service.
 // All implementations must embed UnimplementedSecretServer
 // for forward compatibility
 type SecretServer interface {
 	CreateSecret(context.Context, *CreateSecretRequest) (*CreateSecretResponse, error)
+	CreateSecrets(context.Context, *CreateSecretsRequest) (*CreateSecretsResponse, error)
 	GetSecret(context.Context, *GetSecretRequest) (*GetSecretResponse, error)
 	DeleteSecret(context.Context, *DeleteSecretRequest) (*DeleteSecretResponse, error)
 	EditSecret(context.Context, *EditSecretRequest) (*EditSecretResponse, error)
 	GetListOfSecretsByType(context.Context, *GetListOfSecretsByTypeRequest) (*GetListOfSecretsByTypeResponse, error)
+	SetSecretExpiry(context.Context, *SetSecretExpiryRequest) (*SetSecretExpiryResponse, error)
+	GetDueEvents(context.Context, *GetDueEventsRequest) (*GetDueEventsResponse, error)
 	mustEmbedUnimplementedSecretServer()
 }
 
@@ -109,6 +145,9 @@ type UnimplementedSecretServer struct {
 func (UnimplementedSecretServer) CreateSecret(context.Context, *CreateSecretRequest) (*CreateSecretResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateSecret not implemented")
 }
+func (UnimplementedSecretServer) CreateSecrets(context.Context, *CreateSecretsRequest) (*CreateSecretsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSecrets not implemented")
+}
 func (UnimplementedSecretServer) GetSecret(context.Context, *GetSecretRequest) (*GetSecretResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetSecret not implemented")
 }
@@ -121,6 +160,12 @@ func (UnimplementedSecretServer) EditSecret(context.Context, *EditSecretRequest)
 func (UnimplementedSecretServer) GetListOfSecretsByType(context.Context, *GetListOfSecretsByTypeRequest) (*GetListOfSecretsByTypeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetListOfSecretsByType not implemented")
 }
+func (UnimplementedSecretServer) SetSecretExpiry(context.Context, *SetSecretExpiryRequest) (*SetSecretExpiryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetSecretExpiry not implemented")
+}
+func (UnimplementedSecretServer) GetDueEvents(context.Context, *GetDueEventsRequest) (*GetDueEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDueEvents not implemented")
+}
 func (UnimplementedSecretServer) mustEmbedUnimplementedSecretServer() {}
 
 // UnsafeSecretServer may be embedded to opt out of forward compatibility for this service.
@@ -152,6 +197,24 @@ func _Secret_CreateSecret_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Secret_CreateSecrets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSecretsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretServer).CreateSecrets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Secret_CreateSecrets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretServer).CreateSecrets(ctx, req.(*CreateSecretsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Secret_GetSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetSecretRequest)
 	if err := dec(in); err != nil {
@@ -224,6 +287,42 @@ func _Secret_GetListOfSecretsByType_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Secret_SetSecretExpiry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetSecretExpiryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretServer).SetSecretExpiry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Secret_SetSecretExpiry_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretServer).SetSecretExpiry(ctx, req.(*SetSecretExpiryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Secret_GetDueEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDueEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretServer).GetDueEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Secret_GetDueEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretServer).GetDueEvents(ctx, req.(*GetDueEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Secret_ServiceDesc is the grpc.ServiceDesc for Secret service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -235,6 +334,10 @@ var Secret_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateSecret",
 			Handler:    _Secret_CreateSecret_Handler,
 		},
+		{
+			MethodName: "CreateSecrets",
+			Handler:    _Secret_CreateSecrets_Handler,
+		},
 		{
 			MethodName: "GetSecret",
 			Handler:    _Secret_GetSecret_Handler,
@@ -251,6 +354,14 @@ var Secret_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetListOfSecretsByType",
 			Handler:    _Secret_GetListOfSecretsByType_Handler,
 		},
+		{
+			MethodName: "SetSecretExpiry",
+			Handler:    _Secret_SetSecretExpiry_Handler,
+		},
+		{
+			MethodName: "GetDueEvents",
+			Handler:    _Secret_GetDueEvents_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/secret.proto",
@@ -0,0 +1,391 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.30.0
+// 	protoc        v3.12.4
+// source: proto/send.proto
+
+package proto
+
+import (
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type SendSecretRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Content    []byte `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
+	TtlMinutes uint32 `protobuf:"varint,2,opt,name=ttl_minutes,json=ttlMinutes,proto3" json:"ttl_minutes,omitempty"`
+	MaxViews   uint32 `protobuf:"varint,3,opt,name=max_views,json=maxViews,proto3" json:"max_views,omitempty"`
+}
+
+func (x *SendSecretRequest) Reset() {
+	*x = SendSecretRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_send_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SendSecretRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendSecretRequest) ProtoMessage() {}
+
+func (x *SendSecretRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_send_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendSecretRequest.ProtoReflect.Descriptor instead.
+func (*SendSecretRequest) Descriptor() ([]byte, []int) {
+	return file_proto_send_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SendSecretRequest) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+func (x *SendSecretRequest) GetTtlMinutes() uint32 {
+	if x != nil {
+		return x.TtlMinutes
+	}
+	return 0
+}
+
+func (x *SendSecretRequest) GetMaxViews() uint32 {
+	if x != nil {
+		return x.MaxViews
+	}
+	return 0
+}
+
+type SendSecretResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id        string               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ExpiresAt *timestamp.Timestamp `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+}
+
+func (x *SendSecretResponse) Reset() {
+	*x = SendSecretResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_send_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SendSecretResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendSecretResponse) ProtoMessage() {}
+
+func (x *SendSecretResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_send_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendSecretResponse.ProtoReflect.Descriptor instead.
+func (*SendSecretResponse) Descriptor() ([]byte, []int) {
+	return file_proto_send_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SendSecretResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SendSecretResponse) GetExpiresAt() *timestamp.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type OpenSendRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *OpenSendRequest) Reset() {
+	*x = OpenSendRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_send_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OpenSendRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OpenSendRequest) ProtoMessage() {}
+
+func (x *OpenSendRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_send_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OpenSendRequest.ProtoReflect.Descriptor instead.
+func (*OpenSendRequest) Descriptor() ([]byte, []int) {
+	return file_proto_send_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *OpenSendRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type OpenSendResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Content   []byte `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
+	ViewsLeft uint32 `protobuf:"varint,2,opt,name=views_left,json=viewsLeft,proto3" json:"views_left,omitempty"`
+}
+
+func (x *OpenSendResponse) Reset() {
+	*x = OpenSendResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_send_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OpenSendResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OpenSendResponse) ProtoMessage() {}
+
+func (x *OpenSendResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_send_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OpenSendResponse.ProtoReflect.Descriptor instead.
+func (*OpenSendResponse) Descriptor() ([]byte, []int) {
+	return file_proto_send_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *OpenSendResponse) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+func (x *OpenSendResponse) GetViewsLeft() uint32 {
+	if x != nil {
+		return x.ViewsLeft
+	}
+	return 0
+}
+
+var File_proto_send_proto protoreflect.FileDescriptor
+
+var file_proto_send_proto_rawDesc = []byte{
+	0x0a, 0x10, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x73, 0x65, 0x6e, 0x64, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x6b, 0x0a, 0x11, 0x53, 0x65,
+	0x6e, 0x64, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x74, 0x6c,
+	0x5f, 0x6d, 0x69, 0x6e, 0x75, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a,
+	0x74, 0x74, 0x6c, 0x4d, 0x69, 0x6e, 0x75, 0x74, 0x65, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x61,
+	0x78, 0x5f, 0x76, 0x69, 0x65, 0x77, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x6d,
+	0x61, 0x78, 0x56, 0x69, 0x65, 0x77, 0x73, 0x22, 0x5f, 0x0a, 0x12, 0x53, 0x65, 0x6e, 0x64, 0x53,
+	0x65, 0x63, 0x72, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x39, 0x0a,
+	0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x5f, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x65,
+	0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x22, 0x21, 0x0a, 0x0f, 0x4f, 0x70, 0x65, 0x6e,
+	0x53, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x4b, 0x0a, 0x10, 0x4f,
+	0x70, 0x65, 0x6e, 0x53, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x76, 0x69, 0x65,
+	0x77, 0x73, 0x5f, 0x6c, 0x65, 0x66, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x76,
+	0x69, 0x65, 0x77, 0x73, 0x4c, 0x65, 0x66, 0x74, 0x32, 0x86, 0x01, 0x0a, 0x04, 0x53, 0x65, 0x6e,
+	0x64, 0x12, 0x41, 0x0a, 0x0a, 0x53, 0x65, 0x6e, 0x64, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x12,
+	0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x53, 0x65, 0x63, 0x72,
+	0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3b, 0x0a, 0x08, 0x4f, 0x70, 0x65, 0x6e, 0x53, 0x65, 0x6e, 0x64,
+	0x12, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x53, 0x65, 0x6e,
+	0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x53, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x42, 0x2b, 0x5a, 0x29, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x73, 0x65, 0x72, 0x67, 0x61, 0x6c, 0x6b, 0x69, 0x6e, 0x2f, 0x67, 0x6f, 0x70, 0x68, 0x6b, 0x65,
+	0x65, 0x70, 0x65, 0x72, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_proto_send_proto_rawDescOnce sync.Once
+	file_proto_send_proto_rawDescData = file_proto_send_proto_rawDesc
+)
+
+func file_proto_send_proto_rawDescGZIP() []byte {
+	file_proto_send_proto_rawDescOnce.Do(func() {
+		file_proto_send_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_send_proto_rawDescData)
+	})
+	return file_proto_send_proto_rawDescData
+}
+
+var file_proto_send_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_proto_send_proto_goTypes = []interface{}{
+	(*SendSecretRequest)(nil),   // 0: proto.SendSecretRequest
+	(*SendSecretResponse)(nil),  // 1: proto.SendSecretResponse
+	(*OpenSendRequest)(nil),     // 2: proto.OpenSendRequest
+	(*OpenSendResponse)(nil),    // 3: proto.OpenSendResponse
+	(*timestamp.Timestamp)(nil), // 4: google.protobuf.Timestamp
+}
+var file_proto_send_proto_depIdxs = []int32{
+	4, // 0: proto.SendSecretResponse.expires_at:type_name -> google.protobuf.Timestamp
+	0, // 1: proto.Send.SendSecret:input_type -> proto.SendSecretRequest
+	2, // 2: proto.Send.OpenSend:input_type -> proto.OpenSendRequest
+	1, // 3: proto.Send.SendSecret:output_type -> proto.SendSecretResponse
+	3, // 4: proto.Send.OpenSend:output_type -> proto.OpenSendResponse
+	3, // [3:5] is the sub-list for method output_type
+	1, // [1:3] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_proto_send_proto_init() }
+func file_proto_send_proto_init() {
+	if File_proto_send_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_proto_send_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SendSecretRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_send_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SendSecretResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_send_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OpenSendRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_send_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OpenSendResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_proto_send_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_send_proto_goTypes,
+		DependencyIndexes: file_proto_send_proto_depIdxs,
+		MessageInfos:      file_proto_send_proto_msgTypes,
+	}.Build()
+	File_proto_send_proto = out.File
+	file_proto_send_proto_rawDesc = nil
+	file_proto_send_proto_goTypes = nil
+	file_proto_send_proto_depIdxs = nil
+}
@@ -0,0 +1,146 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v3.12.4
+// source: proto/send.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	Send_SendSecret_FullMethodName = "/proto.Send/SendSecret"
+	Send_OpenSend_FullMethodName   = "/proto.Send/OpenSend"
+)
+
+// SendClient is the client API for Send service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SendClient interface {
+	SendSecret(ctx context.Context, in *SendSecretRequest, opts ...grpc.CallOption) (*SendSecretResponse, error)
+	OpenSend(ctx context.Context, in *OpenSendRequest, opts ...grpc.CallOption) (*OpenSendResponse, error)
+}
+
+type sendClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSendClient(cc grpc.ClientConnInterface) SendClient {
+	return &sendClient{cc}
+}
+
+func (c *sendClient) SendSecret(ctx context.Context, in *SendSecretRequest, opts ...grpc.CallOption) (*SendSecretResponse, error) {
+	out := new(SendSecretResponse)
+	err := c.cc.Invoke(ctx, Send_SendSecret_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sendClient) OpenSend(ctx context.Context, in *OpenSendRequest, opts ...grpc.CallOption) (*OpenSendResponse, error) {
+	out := new(OpenSendResponse)
+	err := c.cc.Invoke(ctx, Send_OpenSend_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SendServer is the server API for Send service.
+// All implementations must embed UnimplementedSendServer
+// for forward compatibility
+type SendServer interface {
+	SendSecret(context.Context, *SendSecretRequest) (*SendSecretResponse, error)
+	OpenSend(context.Context, *OpenSendRequest) (*OpenSendResponse, error)
+	mustEmbedUnimplementedSendServer()
+}
+
+// UnimplementedSendServer must be embedded to have forward compatible implementations.
+type UnimplementedSendServer struct {
+}
+
+func (UnimplementedSendServer) SendSecret(context.Context, *SendSecretRequest) (*SendSecretResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendSecret not implemented")
+}
+func (UnimplementedSendServer) OpenSend(context.Context, *OpenSendRequest) (*OpenSendResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OpenSend not implemented")
+}
+func (UnimplementedSendServer) mustEmbedUnimplementedSendServer() {}
+
+// UnsafeSendServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SendServer will
+// result in compilation errors.
+type UnsafeSendServer interface {
+	mustEmbedUnimplementedSendServer()
+}
+
+func RegisterSendServer(s grpc.ServiceRegistrar, srv SendServer) {
+	s.RegisterService(&Send_ServiceDesc, srv)
+}
+
+func _Send_SendSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendSecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SendServer).SendSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Send_SendSecret_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SendServer).SendSecret(ctx, req.(*SendSecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Send_OpenSend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OpenSendRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SendServer).OpenSend(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Send_OpenSend_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SendServer).OpenSend(ctx, req.(*OpenSendRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Send_ServiceDesc is the grpc.ServiceDesc for Send service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Send_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.Send",
+	HandlerType: (*SendServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SendSecret",
+			Handler:    _Send_SendSecret_Handler,
+		},
+		{
+			MethodName: "OpenSend",
+			Handler:    _Send_OpenSend_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/send.proto",
+}
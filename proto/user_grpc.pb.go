@@ -1,8 +1,8 @@
 // Code generated by protoc-gen-go-grpc. DO NOT EDIT.
 // versions:
-// - protoc-gen-go-grpc v1.2.0
-// - protoc             v3.21.6
-// source: api/proto/user.proto
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v3.12.4
+// source: proto/user.proto
 
 package proto
 
@@ -18,6 +18,18 @@ import (
 // Requires gRPC-Go v1.32.0 or later.
 const _ = grpc.SupportPackageIsVersion7
 
+const (
+	User_Register_FullMethodName       = "/proto.User/Register"
+	User_Login_FullMethodName          = "/proto.User/Login"
+	User_Delete_FullMethodName         = "/proto.User/Delete"
+	User_SetPublicKey_FullMethodName   = "/proto.User/SetPublicKey"
+	User_SetPersonalKey_FullMethodName = "/proto.User/SetPersonalKey"
+	User_GetPersonalKey_FullMethodName = "/proto.User/GetPersonalKey"
+	User_SetRecovery_FullMethodName    = "/proto.User/SetRecovery"
+	User_GetRecoveryKey_FullMethodName = "/proto.User/GetRecoveryKey"
+	User_Recover_FullMethodName        = "/proto.User/Recover"
+)
+
 // UserClient is the client API for User service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
@@ -25,6 +37,12 @@ type UserClient interface {
 	Register(ctx context.Context, in *RegisterRequest, opts ...grpc.CallOption) (*RegisterResponse, error)
 	Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginResponse, error)
 	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+	SetPublicKey(ctx context.Context, in *SetPublicKeyRequest, opts ...grpc.CallOption) (*SetPublicKeyResponse, error)
+	SetPersonalKey(ctx context.Context, in *SetPersonalKeyRequest, opts ...grpc.CallOption) (*SetPersonalKeyResponse, error)
+	GetPersonalKey(ctx context.Context, in *GetPersonalKeyRequest, opts ...grpc.CallOption) (*GetPersonalKeyResponse, error)
+	SetRecovery(ctx context.Context, in *SetRecoveryRequest, opts ...grpc.CallOption) (*SetRecoveryResponse, error)
+	GetRecoveryKey(ctx context.Context, in *GetRecoveryKeyRequest, opts ...grpc.CallOption) (*GetRecoveryKeyResponse, error)
+	Recover(ctx context.Context, in *RecoverRequest, opts ...grpc.CallOption) (*RecoverResponse, error)
 }
 
 type userClient struct {
@@ -37,7 +55,7 @@ func NewUserClient(cc grpc.ClientConnInterface) UserClient {
 
 func (c *userClient) Register(ctx context.Context, in *RegisterRequest, opts ...grpc.CallOption) (*RegisterResponse, error) {
 	out := new(RegisterResponse)
-	err := c.cc.Invoke(ctx, "/proto.User/Register", in, out, opts...)
+	err := c.cc.Invoke(ctx, User_Register_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -46,7 +64,7 @@ func (c *userClient) Register(ctx context.Context, in *RegisterRequest, opts ...
 
 func (c *userClient) Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginResponse, error) {
 	out := new(LoginResponse)
-	err := c.cc.Invoke(ctx, "/proto.User/Login", in, out, opts...)
+	err := c.cc.Invoke(ctx, User_Login_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -55,7 +73,61 @@ func (c *userClient) Login(ctx context.Context, in *LoginRequest, opts ...grpc.C
 
 func (c *userClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
 	out := new(DeleteResponse)
-	err := c.cc.Invoke(ctx, "/proto.User/Delete", in, out, opts...)
+	err := c.cc.Invoke(ctx, User_Delete_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) SetPublicKey(ctx context.Context, in *SetPublicKeyRequest, opts ...grpc.CallOption) (*SetPublicKeyResponse, error) {
+	out := new(SetPublicKeyResponse)
+	err := c.cc.Invoke(ctx, User_SetPublicKey_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) SetPersonalKey(ctx context.Context, in *SetPersonalKeyRequest, opts ...grpc.CallOption) (*SetPersonalKeyResponse, error) {
+	out := new(SetPersonalKeyResponse)
+	err := c.cc.Invoke(ctx, User_SetPersonalKey_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) GetPersonalKey(ctx context.Context, in *GetPersonalKeyRequest, opts ...grpc.CallOption) (*GetPersonalKeyResponse, error) {
+	out := new(GetPersonalKeyResponse)
+	err := c.cc.Invoke(ctx, User_GetPersonalKey_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) SetRecovery(ctx context.Context, in *SetRecoveryRequest, opts ...grpc.CallOption) (*SetRecoveryResponse, error) {
+	out := new(SetRecoveryResponse)
+	err := c.cc.Invoke(ctx, User_SetRecovery_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) GetRecoveryKey(ctx context.Context, in *GetRecoveryKeyRequest, opts ...grpc.CallOption) (*GetRecoveryKeyResponse, error) {
+	out := new(GetRecoveryKeyResponse)
+	err := c.cc.Invoke(ctx, User_GetRecoveryKey_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) Recover(ctx context.Context, in *RecoverRequest, opts ...grpc.CallOption) (*RecoverResponse, error) {
+	out := new(RecoverResponse)
+	err := c.cc.Invoke(ctx, User_Recover_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -69,6 +141,12 @@ type UserServer interface {
 	Register(context.Context, *RegisterRequest) (*RegisterResponse, error)
 	Login(context.Context, *LoginRequest) (*LoginResponse, error)
 	Delete(context.Context, *DeleteRequest) (*DeleteResponse, error)
+	SetPublicKey(context.Context, *SetPublicKeyRequest) (*SetPublicKeyResponse, error)
+	SetPersonalKey(context.Context, *SetPersonalKeyRequest) (*SetPersonalKeyResponse, error)
+	GetPersonalKey(context.Context, *GetPersonalKeyRequest) (*GetPersonalKeyResponse, error)
+	SetRecovery(context.Context, *SetRecoveryRequest) (*SetRecoveryResponse, error)
+	GetRecoveryKey(context.Context, *GetRecoveryKeyRequest) (*GetRecoveryKeyResponse, error)
+	Recover(context.Context, *RecoverRequest) (*RecoverResponse, error)
 	mustEmbedUnimplementedUserServer()
 }
 
@@ -85,6 +163,24 @@ func (UnimplementedUserServer) Login(context.Context, *LoginRequest) (*LoginResp
 func (UnimplementedUserServer) Delete(context.Context, *DeleteRequest) (*DeleteResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
 }
+func (UnimplementedUserServer) SetPublicKey(context.Context, *SetPublicKeyRequest) (*SetPublicKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetPublicKey not implemented")
+}
+func (UnimplementedUserServer) SetPersonalKey(context.Context, *SetPersonalKeyRequest) (*SetPersonalKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetPersonalKey not implemented")
+}
+func (UnimplementedUserServer) GetPersonalKey(context.Context, *GetPersonalKeyRequest) (*GetPersonalKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPersonalKey not implemented")
+}
+func (UnimplementedUserServer) SetRecovery(context.Context, *SetRecoveryRequest) (*SetRecoveryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetRecovery not implemented")
+}
+func (UnimplementedUserServer) GetRecoveryKey(context.Context, *GetRecoveryKeyRequest) (*GetRecoveryKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRecoveryKey not implemented")
+}
+func (UnimplementedUserServer) Recover(context.Context, *RecoverRequest) (*RecoverResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Recover not implemented")
+}
 func (UnimplementedUserServer) mustEmbedUnimplementedUserServer() {}
 
 // UnsafeUserServer may be embedded to opt out of forward compatibility for this service.
@@ -108,7 +204,7 @@ func _User_Register_Handler(srv interface{}, ctx context.Context, dec func(inter
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/proto.User/Register",
+		FullMethod: User_Register_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(UserServer).Register(ctx, req.(*RegisterRequest))
@@ -126,7 +222,7 @@ func _User_Login_Handler(srv interface{}, ctx context.Context, dec func(interfac
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/proto.User/Login",
+		FullMethod: User_Login_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(UserServer).Login(ctx, req.(*LoginRequest))
@@ -144,7 +240,7 @@ func _User_Delete_Handler(srv interface{}, ctx context.Context, dec func(interfa
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/proto.User/Delete",
+		FullMethod: User_Delete_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(UserServer).Delete(ctx, req.(*DeleteRequest))
@@ -152,6 +248,114 @@ func _User_Delete_Handler(srv interface{}, ctx context.Context, dec func(interfa
 	return interceptor(ctx, in, info, handler)
 }
 
+func _User_SetPublicKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetPublicKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).SetPublicKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: User_SetPublicKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).SetPublicKey(ctx, req.(*SetPublicKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_SetPersonalKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetPersonalKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).SetPersonalKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: User_SetPersonalKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).SetPersonalKey(ctx, req.(*SetPersonalKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_GetPersonalKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPersonalKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).GetPersonalKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: User_GetPersonalKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).GetPersonalKey(ctx, req.(*GetPersonalKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_SetRecovery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetRecoveryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).SetRecovery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: User_SetRecovery_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).SetRecovery(ctx, req.(*SetRecoveryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_GetRecoveryKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRecoveryKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).GetRecoveryKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: User_GetRecoveryKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).GetRecoveryKey(ctx, req.(*GetRecoveryKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_Recover_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecoverRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).Recover(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: User_Recover_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).Recover(ctx, req.(*RecoverRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // User_ServiceDesc is the grpc.ServiceDesc for User service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -171,7 +375,31 @@ var User_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Delete",
 			Handler:    _User_Delete_Handler,
 		},
+		{
+			MethodName: "SetPublicKey",
+			Handler:    _User_SetPublicKey_Handler,
+		},
+		{
+			MethodName: "SetPersonalKey",
+			Handler:    _User_SetPersonalKey_Handler,
+		},
+		{
+			MethodName: "GetPersonalKey",
+			Handler:    _User_GetPersonalKey_Handler,
+		},
+		{
+			MethodName: "SetRecovery",
+			Handler:    _User_SetRecovery_Handler,
+		},
+		{
+			MethodName: "GetRecoveryKey",
+			Handler:    _User_GetRecoveryKey_Handler,
+		},
+		{
+			MethodName: "Recover",
+			Handler:    _User_Recover_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
-	Metadata: "api/proto/user.proto",
+	Metadata: "proto/user.proto",
 }